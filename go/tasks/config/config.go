@@ -10,6 +10,9 @@ import (
 
 const configSectionKey = "plugins"
 
+// FailFastPluginID is the ID of the plugin that fails every task execution it handles.
+const FailFastPluginID = "fail-fast"
+
 var (
 	// Root config section. If you are a plugin developer and your plugin needs a config, you should register
 	// your config as a subsection for this root section.
@@ -19,18 +22,140 @@ var (
 // Top level plugins config.
 type Config struct {
 	EnabledPlugins []string `json:"enabled-plugins" pflag:"[]string{\"*\"},List of enabled plugins, default value is to enable all plugins."`
+	// Routes are evaluated in order, the first route matching a task execution selects the plugin that handles it.
+	Routes []PluginRoute `json:"routes" pflag:"-,Selects the plugin that handles a task type per project and domain."`
+	// Prices used to estimate the cost of the resources consumed by task executions.
+	ResourcePrices ResourcePrices `json:"resource-prices" pflag:"-,Unit prices used to estimate the cost of task executions."`
+	// Rules are evaluated in order, the first rule matching a task execution sets the priority of its allocations.
+	ResourcePriorities []ResourcePriorityRule `json:"resource-priorities" pflag:"-,Sets the priority class of resource allocations per project and domain."`
+}
+
+// ResourcePrices are the unit prices used to estimate the cost of the resources consumed by task executions. They're
+// expressed in the currency costs should be reported in.
+type ResourcePrices struct {
+	// Price of a CPU core-second.
+	CPUSecond float64 `json:"cpu-second"`
+	// Price of a GPU-second.
+	GPUSecond float64 `json:"gpu-second"`
+	// Price of a terabyte of data scanned.
+	TerabyteScanned float64 `json:"terabyte-scanned"`
+}
+
+// ResourcePriorityRule sets the priority class of the resource allocations of the executions of a project and domain,
+// one of the core.ResourcePriority names. Empty project and domain match any project and domain. Tasks can lower the
+// priority of their allocations but never raise it above the one set by the first matching rule.
+//
+// For example, the following rules let production executions claim resources ahead of development ones:
+//
+//	resource-priorities:
+//	  - domain: production
+//	    priority: high
+//	  - domain: development
+//	    priority: low
+type ResourcePriorityRule struct {
+	Project  string `json:"project"`
+	Domain   string `json:"domain"`
+	Priority string `json:"priority"`
+}
+
+// Matches returns whether the rule applies to the executions of the project and domain.
+func (r ResourcePriorityRule) Matches(project, domain string) bool {
+	return (len(r.Project) == 0 || r.Project == project) && (len(r.Domain) == 0 || r.Domain == domain)
 }
 
+// ResolveResourcePriority returns the priority class set by the first rule that applies to the executions of the
+// project and domain.
+func (cfg Config) ResolveResourcePriority(project, domain string) (priority string, found bool) {
+	for _, r := range cfg.ResourcePriorities {
+		if r.Matches(project, domain) {
+			return r.Priority, true
+		}
+	}
+
+	return "", false
+}
+
+// PluginRoute selects the plugin that handles a task type for the executions of a project and domain. Empty project
+// and domain match any project and domain. A route that doesn't name a plugin sends the matching executions to the
+// fail-fast plugin, which fails them with FailFastMessage.
+//
+// Plugins register the task types they handle before the config is loaded, so a route can only select a plugin
+// registered for its task type, the fail-fast plugin included. The plugin registry's ValidateRoutes checks it.
+//
+// For example, the following routes let spark tasks run only in the ml project and hand hive tasks over to the athena
+// plugin in the development domain:
+//
+//	routes:
+//	  - task-type: spark
+//	    project: ml
+//	    plugin: spark
+//	  - task-type: spark
+//	    fail-fast-message: Spark is only available in the ml project.
+//	  - task-type: hive
+//	    domain: development
+//	    plugin: athena
+type PluginRoute struct {
+	TaskType        string `json:"task-type"`
+	Project         string `json:"project"`
+	Domain          string `json:"domain"`
+	Plugin          string `json:"plugin"`
+	FailFastMessage string `json:"fail-fast-message"`
+}
+
+// Matches returns whether the route applies to the executions of the task type in the project and domain.
+func (r PluginRoute) Matches(taskType, project, domain string) bool {
+	return r.TaskType == taskType && (len(r.Project) == 0 || r.Project == project) &&
+		(len(r.Domain) == 0 || r.Domain == domain)
+}
+
+// GetPlugin returns the ID of the plugin the route sends the matching executions to.
+func (r PluginRoute) GetPlugin() string {
+	if len(r.Plugin) == 0 {
+		return FailFastPluginID
+	}
+
+	return r.Plugin
+}
+
+// ResolveRoute returns the first route that applies to the executions of the task type in the project and domain. When
+// none does, the task type is handled by the plugin it's registered with, if that plugin is enabled.
+func (cfg Config) ResolveRoute(taskType, project, domain string) (route PluginRoute, found bool) {
+	for _, r := range cfg.Routes {
+		if r.Matches(taskType, project, domain) {
+			return r, true
+		}
+	}
+
+	return PluginRoute{}, false
+}
+
+// IsEnabled returns whether the plugin is enabled globally. Routes can still restrict the projects and domains the
+// plugin handles, see ResolveRoute.
 func (cfg Config) IsEnabled(pluginToCheck string) bool {
 	return cfg.EnabledPlugins != nil && len(cfg.EnabledPlugins) >= 1 &&
 		(cfg.EnabledPlugins[0] == "*" || utils.Contains(cfg.EnabledPlugins, pluginToCheck))
 }
 
+// IsEnabledFor returns whether the plugin handles the executions of the task type in the project and domain. The first
+// matching route decides, the plugin has to be enabled globally otherwise.
+func (cfg Config) IsEnabledFor(pluginToCheck, taskType, project, domain string) bool {
+	if route, found := cfg.ResolveRoute(taskType, project, domain); found {
+		return route.GetPlugin() == pluginToCheck
+	}
+
+	return cfg.IsEnabled(pluginToCheck)
+}
+
 // Retrieves the current config value or default.
 func GetConfig() *Config {
 	return rootSection.GetConfig().(*Config)
 }
 
+// [FOR TESTING ONLY] Sets current value for the config.
+func SetConfig(cfg *Config) error {
+	return rootSection.SetConfig(cfg)
+}
+
 func MustRegisterSubSection(subSectionKey string, section config.Config) config.Section {
 	return rootSection.MustRegisterSection(subSectionKey, section)
 }
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ResolveRoute(t *testing.T) {
+	cfg := Config{
+		EnabledPlugins: []string{"*"},
+		Routes: []PluginRoute{
+			{TaskType: "spark", Project: "ml", Plugin: "spark"},
+			{TaskType: "spark", FailFastMessage: "Spark is only available in the ml project."},
+			{TaskType: "hive", Domain: "development", Plugin: "athena"},
+		},
+	}
+
+	t.Run("Project route", func(t *testing.T) {
+		route, found := cfg.ResolveRoute("spark", "ml", "production")
+		assert.True(t, found)
+		assert.Equal(t, "spark", route.GetPlugin())
+		assert.True(t, cfg.IsEnabledFor("spark", "spark", "ml", "production"))
+	})
+
+	t.Run("Fall through to fail-fast", func(t *testing.T) {
+		route, found := cfg.ResolveRoute("spark", "flytesnacks", "production")
+		assert.True(t, found)
+		assert.Equal(t, FailFastPluginID, route.GetPlugin())
+		assert.Equal(t, "Spark is only available in the ml project.", route.FailFastMessage)
+		assert.False(t, cfg.IsEnabledFor("spark", "spark", "flytesnacks", "production"))
+		assert.True(t, cfg.IsEnabledFor(FailFastPluginID, "spark", "flytesnacks", "production"))
+	})
+
+	t.Run("Domain route", func(t *testing.T) {
+		route, found := cfg.ResolveRoute("hive", "flytesnacks", "development")
+		assert.True(t, found)
+		assert.Equal(t, "athena", route.GetPlugin())
+		assert.False(t, cfg.IsEnabledFor("qubole-hive-executor", "hive", "flytesnacks", "development"))
+	})
+
+	t.Run("No route", func(t *testing.T) {
+		_, found := cfg.ResolveRoute("hive", "flytesnacks", "production")
+		assert.False(t, found)
+		assert.True(t, cfg.IsEnabledFor("qubole-hive-executor", "hive", "flytesnacks", "production"))
+		assert.False(t, Config{}.IsEnabledFor("qubole-hive-executor", "hive", "flytesnacks", "production"))
+	})
+}
+
+func TestConfig_ResolveResourcePriority(t *testing.T) {
+	cfg := Config{
+		ResourcePriorities: []ResourcePriorityRule{
+			{Project: "ml", Domain: "development", Priority: "default"},
+			{Domain: "production", Priority: "high"},
+			{Domain: "development", Priority: "low"},
+		},
+	}
+
+	priority, found := cfg.ResolveResourcePriority("flytesnacks", "production")
+	assert.True(t, found)
+	assert.Equal(t, "high", priority)
+
+	priority, found = cfg.ResolveResourcePriority("ml", "development")
+	assert.True(t, found)
+	assert.Equal(t, "default", priority)
+
+	_, found = cfg.ResolveResourcePriority("flytesnacks", "staging")
+	assert.False(t, found)
+}
@@ -13,8 +13,11 @@ import (
 
 	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	_ "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/bundle"
 	flyteK8sConfig "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/k8s/spark"
+	_ "github.com/flyteorg/flyteplugins/go/tasks/plugins/webapi/athena"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -28,6 +31,12 @@ func TestLoadConfig(t *testing.T) {
 
 	t.Run("root-config-test", func(t *testing.T) {
 		assert.Equal(t, 1, len(pluginsConfig.GetConfig().EnabledPlugins))
+		assert.Equal(t, 3, len(pluginsConfig.GetConfig().Routes))
+		route, found := pluginsConfig.GetConfig().ResolveRoute("hive", "flytesnacks", "development")
+		assert.True(t, found)
+		assert.Equal(t, "athena", route.GetPlugin())
+		assert.NoError(t, pluginmachinery.PluginRegistry().ValidateRoutes(pluginsConfig.GetConfig().Routes))
+		assert.Equal(t, float64(5), pluginsConfig.GetConfig().ResourcePrices.TerabyteScanned)
 	})
 
 	t.Run("k8s-config-test", func(t *testing.T) {
@@ -18,6 +18,7 @@ const (
 	CorruptedPluginState       errors.ErrorCode = "CorruptedPluginState"
 	ResourceManagerFailure     errors.ErrorCode = "ResourceManagerFailure"
 	BackOffError               errors.ErrorCode = "BackOffError"
+	TaskTimedOut               errors.ErrorCode = "TaskTimedOut"
 )
 
 func Errorf(errorCode errors.ErrorCode, msgFmt string, args ...interface{}) error {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 
 	pluginMachinery "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
@@ -12,7 +13,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 )
 
-const failFastExecutorName = "fail-fast"
+const failFastExecutorName = config.FailFastPluginID
 
 type failFastHandler struct{}
 
@@ -31,11 +32,28 @@ func (h failFastHandler) Handle(ctx context.Context, tCtx core.TaskExecutionCont
 		return core.UnknownTransition,
 			errors.Errorf(errors.BadTaskSpecification, "unable to fetch task specification [%v]", err.Error())
 	}
-	return core.DoTransition(core.PhaseInfoFailure("AlwaysFail",
-		fmt.Sprintf("Task [%s] type [%+v] not supported by platform for this project/domain/workflow",
-			taskTemplate.Type, tCtx.TaskExecutionMetadata().GetTaskExecutionID()), &core.TaskInfo{
-			OccurredAt: &occuredAt,
-		})), nil
+
+	message := fmt.Sprintf("Task [%s] type [%+v] not supported by platform for this project/domain/workflow",
+		taskTemplate.Type, tCtx.TaskExecutionMetadata().GetTaskExecutionID())
+	if routeMessage := failFastMessage(taskTemplate.Type, tCtx.TaskExecutionMetadata()); len(routeMessage) > 0 {
+		message = routeMessage
+	}
+
+	return core.DoTransition(core.PhaseInfoFailure("AlwaysFail", message, &core.TaskInfo{
+		OccurredAt: &occuredAt,
+	})), nil
+}
+
+// failFastMessage returns the message of the route that sent the task execution to this plugin, if any.
+func failFastMessage(taskType string, metadata core.TaskExecutionMetadata) string {
+	taskExecutionID := metadata.GetTaskExecutionID().GetID()
+	executionID := taskExecutionID.GetNodeExecutionId().GetExecutionId()
+	route, found := config.GetConfig().ResolveRoute(taskType, executionID.GetProject(), executionID.GetDomain())
+	if !found || route.GetPlugin() != failFastExecutorName {
+		return ""
+	}
+
+	return route.FailFastMessage
 }
 
 func (h failFastHandler) Abort(_ context.Context, _ core.TaskExecutionContext) error {
@@ -54,6 +72,8 @@ func init() {
 	// TODO(katrogan): Once we move pluginmachinery to flyteidl make these task types named constants that flyteplugins
 	// can reference in other handler definitions.
 	// NOTE: these should match the constants defined flytekit
+	// Routes without a plugin can only fail the task types listed here, since plugins are registered before the config
+	// with the routes is loaded. PluginRegistry().ValidateRoutes rejects routes for other task types.
 	taskTypes := []core.TaskType{
 		"container", "sidecar", "container_array", "hive", "presto", "spark", "pytorch",
 		"sagemaker_custom_training_job_task", "sagemaker_training_job_task", "sagemaker_hyperparameter_tuning_job_task",
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
@@ -53,6 +54,49 @@ func TestHandleAlwaysFails(t *testing.T) {
 	assert.Contains(t, transition.Info().Err().Message, "Task [unsupportedtype]")
 }
 
+func TestHandleRouteMessage(t *testing.T) {
+	previous := *config.GetConfig()
+	defer func() {
+		assert.NoError(t, config.SetConfig(&previous))
+	}()
+
+	assert.NoError(t, config.SetConfig(&config.Config{
+		EnabledPlugins: []string{"*"},
+		Routes: []config.PluginRoute{
+			{TaskType: "spark", Project: "ml", Plugin: "spark"},
+			{TaskType: "spark", FailFastMessage: "Spark is only available in the ml project."},
+		},
+	}))
+
+	newTaskCtx := func(project string) *mocks.TaskExecutionContext {
+		tID := &mocks.TaskExecutionID{}
+		tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+			NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+				ExecutionId: &idlCore.WorkflowExecutionIdentifier{Project: project, Domain: "development"},
+			},
+		})
+
+		taskExecutionMetadata := &mocks.TaskExecutionMetadata{}
+		taskExecutionMetadata.OnGetTaskExecutionID().Return(tID)
+		taskReader := &mocks.TaskReader{}
+		taskReader.OnReadMatch(mock.Anything).Return(&idlCore.TaskTemplate{Type: "spark"}, nil)
+		taskCtx := &mocks.TaskExecutionContext{}
+		taskCtx.OnTaskExecutionMetadata().Return(taskExecutionMetadata)
+		taskCtx.OnTaskReader().Return(taskReader)
+		return taskCtx
+	}
+
+	transition, err := testHandler.Handle(context.TODO(), newTaskCtx("flytesnacks"))
+	assert.NoError(t, err)
+	assert.Equal(t, core.PhasePermanentFailure, transition.Info().Phase())
+	assert.Equal(t, "Spark is only available in the ml project.", transition.Info().Err().Message)
+
+	// The route doesn't send the executions of the ml project to this plugin, its message doesn't apply.
+	transition, err = testHandler.Handle(context.TODO(), newTaskCtx("ml"))
+	assert.NoError(t, err)
+	assert.Contains(t, transition.Info().Err().Message, "Task [spark]")
+}
+
 func TestAbort(t *testing.T) {
 	err := testHandler.Abort(context.TODO(), nil)
 	assert.NoError(t, err)
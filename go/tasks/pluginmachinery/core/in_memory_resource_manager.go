@@ -0,0 +1,362 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// ResourceScope identifies who an allocation is made on behalf of. It's used to enforce the project-scope and
+// namespace-scope constraints of a ResourceConstraintsSpec.
+type ResourceScope struct {
+	Project   string
+	Namespace string
+}
+
+// ResourceScopeForTask returns the scope of the task: the project of its execution and the namespace it runs in.
+func ResourceScopeForTask(tMeta TaskExecutionMetadata) ResourceScope {
+	id := tMeta.GetTaskExecutionID().GetID()
+	return ResourceScope{
+		Project:   id.GetNodeExecutionId().GetExecutionId().GetProject(),
+		Namespace: tMeta.GetNamespace(),
+	}
+}
+
+const (
+	// The time a waiting allocation needs to wait to be promoted to the next priority class. This guarantees that
+	// allocations of a low priority are eventually granted even while higher priority ones keep coming.
+	priorityAgingInterval = 5 * time.Minute
+	// Waiting allocations that aren't retried within this interval are assumed to be abandoned and stop holding back
+	// the allocations behind them.
+	waiterTimeout = 10 * time.Minute
+)
+
+type allocation struct {
+	scope ResourceScope
+	units int64
+	// The time the lease of the token expires. It's zero for tokens allocated without a lease.
+	expiresAt time.Time
+}
+
+// waiter is an allocation that couldn't be granted because the quota of the namespace was exhausted.
+type waiter struct {
+	token    string
+	units    int64
+	priority ResourcePriority
+	// The time of the first and of the latest attempt to allocate the token.
+	since    time.Time
+	lastSeen time.Time
+}
+
+// rank returns the priority of the waiter, raised by one for every priorityAgingInterval it has been waiting.
+func (w *waiter) rank(now time.Time) int {
+	return w.priority.Rank() + int(now.Sub(w.since)/priorityAgingInterval)
+}
+
+type tokenPool struct {
+	quota  int64
+	tokens map[string]allocation
+	// The number of units held by all tokens, by each project and by each namespace of a project.
+	usage          int64
+	projectUsage   map[string]int64
+	namespaceUsage map[ResourceScope]int64
+	waiters        map[string]*waiter
+}
+
+// InMemoryResourceManager is a thread-safe ResourceManager that keeps track of allocated tokens in memory. It's meant
+// for single-process deployments and tests; allocations are lost when the process restarts.
+//
+// Quotas are registered through RegisterResourceQuota. Allocations are made through the ResourceManager returned by
+// ForScope or ForTask, which enforces the constraints of the ResourceConstraintsSpec for the given scope. Allocating a
+// token that is already allocated is granted again without consuming more of the quota. Tokens can be allocated as
+// leases, see LeasedResourceManager; expired leases are reclaimed on the next call that touches their namespace.
+//
+// Quotas and constraints are expressed in units, see ResourceConstraintsSpec. Allocations that don't fit in the quota
+// wait in line: an allocation is only granted if it fits next to the units claimed by the allocations waiting ahead of
+// it. Waiting allocations are ordered by priority then by arrival, and their priority is raised the longer they wait, so
+// neither large nor low priority allocations are starved.
+type InMemoryResourceManager struct {
+	id    string
+	clock clock.PassiveClock
+	lock  sync.Mutex
+	pools map[ResourceNamespace]*tokenPool
+}
+
+// NewInMemoryResourceManager creates an InMemoryResourceManager without any registered quota.
+func NewInMemoryResourceManager(id string) *InMemoryResourceManager {
+	return NewInMemoryResourceManagerWithClock(id, clock.RealClock{})
+}
+
+// NewInMemoryResourceManagerWithClock creates an InMemoryResourceManager that uses the given clock to expire leases.
+func NewInMemoryResourceManagerWithClock(id string, c clock.PassiveClock) *InMemoryResourceManager {
+	return &InMemoryResourceManager{
+		id:    id,
+		clock: c,
+		pools: map[ResourceNamespace]*tokenPool{},
+	}
+}
+
+// RegisterResourceQuota registers the number of tokens available in the namespace. Registering the same quota again is
+// a no-op.
+func (m *InMemoryResourceManager) RegisterResourceQuota(_ context.Context, namespace ResourceNamespace, quota int) error {
+	if quota <= 0 {
+		return fmt.Errorf("quota for resource namespace [%v] must be positive, got [%v]", namespace, quota)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if pool, found := m.pools[namespace]; found {
+		if pool.quota != int64(quota) {
+			return fmt.Errorf("resource namespace [%v] is already registered with a quota of [%v]", namespace,
+				pool.quota)
+		}
+
+		return nil
+	}
+
+	m.pools[namespace] = &tokenPool{
+		quota:          int64(quota),
+		tokens:         map[string]allocation{},
+		projectUsage:   map[string]int64{},
+		namespaceUsage: map[ResourceScope]int64{},
+		waiters:        map[string]*waiter{},
+	}
+
+	return nil
+}
+
+// ForScope returns a ResourceManager that allocates tokens on behalf of the given scope. It implements
+// LeasedResourceManager.
+func (m *InMemoryResourceManager) ForScope(scope ResourceScope) ResourceManager {
+	return scopedResourceManager{manager: m, scope: scope}
+}
+
+// ForTask returns a ResourceManager that allocates tokens on behalf of the scope of the task.
+func (m *InMemoryResourceManager) ForTask(tMeta TaskExecutionMetadata) ResourceManager {
+	return m.ForScope(ResourceScopeForTask(tMeta))
+}
+
+// getPool returns the pool of the namespace after reclaiming the tokens whose lease expired and dropping the abandoned
+// waiters.
+func (m *InMemoryResourceManager) getPool(namespace ResourceNamespace) (*tokenPool, error) {
+	pool, found := m.pools[namespace]
+	if !found {
+		return nil, fmt.Errorf("resource namespace [%v] has no registered quota", namespace)
+	}
+
+	now := m.clock.Now()
+	for token, a := range pool.tokens {
+		if !a.expiresAt.IsZero() && !now.Before(a.expiresAt) {
+			pool.remove(token)
+		}
+	}
+
+	for token, w := range pool.waiters {
+		if now.Sub(w.lastSeen) >= waiterTimeout {
+			delete(pool.waiters, token)
+		}
+	}
+
+	return pool, nil
+}
+
+func (p *tokenPool) remove(token string) {
+	a, found := p.tokens[token]
+	if !found {
+		return
+	}
+
+	delete(p.tokens, token)
+	p.usage -= a.units
+	p.projectUsage[a.scope.Project] -= a.units
+	if p.projectUsage[a.scope.Project] == 0 {
+		delete(p.projectUsage, a.scope.Project)
+	}
+
+	p.namespaceUsage[a.scope] -= a.units
+	if p.namespaceUsage[a.scope] == 0 {
+		delete(p.namespaceUsage, a.scope)
+	}
+}
+
+// leaseExpiry returns the time a lease of ttl granted now expires, or zero if ttl isn't positive.
+func (m *InMemoryResourceManager) leaseExpiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+
+	return m.clock.Now().Add(ttl)
+}
+
+func (p *tokenPool) add(token string, a allocation) {
+	p.tokens[token] = a
+	p.usage += a.units
+	p.projectUsage[a.scope.Project] += a.units
+	p.namespaceUsage[a.scope] += a.units
+}
+
+// wait records the attempt to allocate the token and returns the number of units claimed by the waiters ahead of it.
+func (p *tokenPool) wait(token string, units int64, priority ResourcePriority, now time.Time) int64 {
+	w, found := p.waiters[token]
+	if !found {
+		w = &waiter{token: token, since: now}
+		p.waiters[token] = w
+	}
+
+	w.units = units
+	w.priority = priority
+	w.lastSeen = now
+
+	waiters := make([]*waiter, 0, len(p.waiters))
+	for _, other := range p.waiters {
+		waiters = append(waiters, other)
+	}
+
+	sort.Slice(waiters, func(i, j int) bool {
+		if ri, rj := waiters[i].rank(now), waiters[j].rank(now); ri != rj {
+			return ri > rj
+		}
+
+		if !waiters[i].since.Equal(waiters[j].since) {
+			return waiters[i].since.Before(waiters[j].since)
+		}
+
+		return waiters[i].token < waiters[j].token
+	})
+
+	var ahead int64
+	for _, other := range waiters {
+		if other == w {
+			break
+		}
+
+		ahead += other.units
+	}
+
+	return ahead
+}
+
+func exceeds(constraint *ResourceConstraint, usage, units int64) bool {
+	return constraint != nil && usage+units > constraint.Value
+}
+
+func (m *InMemoryResourceManager) allocate(scope ResourceScope, namespace ResourceNamespace, allocationToken string,
+	constraintsSpec ResourceConstraintsSpec, ttl time.Duration) (AllocationStatus, error) {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	pool, err := m.getPool(namespace)
+	if err != nil {
+		return AllocationUndefined, err
+	}
+
+	if a, found := pool.tokens[allocationToken]; found {
+		if !a.expiresAt.IsZero() && ttl > 0 {
+			a.expiresAt = m.leaseExpiry(ttl)
+			pool.tokens[allocationToken] = a
+		}
+
+		return AllocationStatusGranted, nil
+	}
+
+	units := constraintsSpec.GetUnits()
+	if units < 0 || units > pool.quota {
+		return AllocationUndefined, fmt.Errorf("cannot allocate [%v] units in resource namespace [%v] with a quota of [%v]",
+			units, namespace, pool.quota)
+	}
+
+	// Allocations held back by their own constraints don't wait in line, they would block others for no reason.
+	if exceeds(constraintsSpec.ProjectScopeResourceConstraint, pool.projectUsage[scope.Project], units) ||
+		exceeds(constraintsSpec.NamespaceScopeResourceConstraint, pool.namespaceUsage[scope], units) {
+		delete(pool.waiters, allocationToken)
+		if pool.usage+units > pool.quota {
+			return AllocationStatusExhausted, nil
+		}
+
+		return AllocationStatusNamespaceQuotaExceeded, nil
+	}
+
+	ahead := pool.wait(allocationToken, units, constraintsSpec.Priority, m.clock.Now())
+	if pool.usage+ahead+units > pool.quota {
+		return AllocationStatusExhausted, nil
+	}
+
+	delete(pool.waiters, allocationToken)
+	pool.add(allocationToken, allocation{scope: scope, units: units, expiresAt: m.leaseExpiry(ttl)})
+	return AllocationStatusGranted, nil
+}
+
+func (m *InMemoryResourceManager) release(namespace ResourceNamespace, allocationToken string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	pool, err := m.getPool(namespace)
+	if err != nil {
+		return err
+	}
+
+	pool.remove(allocationToken)
+	delete(pool.waiters, allocationToken)
+	return nil
+}
+
+func (m *InMemoryResourceManager) renew(namespace ResourceNamespace, allocationToken string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("lease ttl must be positive, got [%v]", ttl)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	pool, err := m.getPool(namespace)
+	if err != nil {
+		return err
+	}
+
+	a, found := pool.tokens[allocationToken]
+	if !found {
+		return fmt.Errorf("token [%v] isn't allocated in resource namespace [%v]", allocationToken, namespace)
+	}
+
+	a.expiresAt = m.leaseExpiry(ttl)
+	pool.tokens[allocationToken] = a
+	return nil
+}
+
+type scopedResourceManager struct {
+	manager *InMemoryResourceManager
+	scope   ResourceScope
+}
+
+func (s scopedResourceManager) GetID() string {
+	return s.manager.id
+}
+
+func (s scopedResourceManager) AllocateResource(_ context.Context, namespace ResourceNamespace, allocationToken string,
+	constraintsSpec ResourceConstraintsSpec) (AllocationStatus, error) {
+	return s.manager.allocate(s.scope, namespace, allocationToken, constraintsSpec, 0)
+}
+
+func (s scopedResourceManager) AllocateResourceWithLease(_ context.Context, namespace ResourceNamespace,
+	allocationToken string, constraintsSpec ResourceConstraintsSpec, ttl time.Duration) (AllocationStatus, error) {
+	if ttl <= 0 {
+		return AllocationUndefined, fmt.Errorf("lease ttl must be positive, got [%v]", ttl)
+	}
+
+	return s.manager.allocate(s.scope, namespace, allocationToken, constraintsSpec, ttl)
+}
+
+func (s scopedResourceManager) RenewResource(_ context.Context, namespace ResourceNamespace, allocationToken string,
+	ttl time.Duration) error {
+	return s.manager.renew(namespace, allocationToken, ttl)
+}
+
+func (s scopedResourceManager) ReleaseResource(_ context.Context, namespace ResourceNamespace, allocationToken string) error {
+	return s.manager.release(namespace, allocationToken)
+}
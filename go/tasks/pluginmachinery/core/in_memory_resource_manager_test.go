@@ -0,0 +1,181 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	testing2 "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/resourcemanagertest"
+)
+
+func TestInMemoryResourceManager_Conformance(t *testing.T) {
+	resourcemanagertest.RunConformanceTests(t, func(t *testing.T) resourcemanagertest.Subject {
+		clck := testing2.NewFakeClock(time.Now())
+		m := core.NewInMemoryResourceManagerWithClock("in-memory", clck)
+		return resourcemanagertest.Subject{
+			Registrar: m,
+			ForScope:  m.ForScope,
+			Step:      clck.Step,
+		}
+	})
+}
+
+func TestInMemoryResourceManager_RegisterResourceQuota(t *testing.T) {
+	ctx := context.Background()
+	m := core.NewInMemoryResourceManager("in-memory")
+	assert.NoError(t, m.RegisterResourceQuota(ctx, "ns", 2))
+	assert.NoError(t, m.RegisterResourceQuota(ctx, "ns", 2))
+	assert.Error(t, m.RegisterResourceQuota(ctx, "ns", 3))
+	assert.Error(t, m.RegisterResourceQuota(ctx, "other", 0))
+}
+
+func TestInMemoryResourceManager_ForTask(t *testing.T) {
+	ctx := context.Background()
+	newTaskMetadata := func(project, namespace string) *mocks.TaskExecutionMetadata {
+		tID := &mocks.TaskExecutionID{}
+		tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{
+			NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+				ExecutionId: &idlCore.WorkflowExecutionIdentifier{Project: project},
+			},
+		})
+
+		tMeta := &mocks.TaskExecutionMetadata{}
+		tMeta.OnGetTaskExecutionID().Return(tID)
+		tMeta.OnGetNamespace().Return(namespace)
+		return tMeta
+	}
+
+	assert.Equal(t, core.ResourceScope{Project: "flytesnacks", Namespace: "flytesnacks-development"},
+		core.ResourceScopeForTask(newTaskMetadata("flytesnacks", "flytesnacks-development")))
+
+	m := core.NewInMemoryResourceManager("in-memory")
+	assert.NoError(t, m.RegisterResourceQuota(ctx, "ns", 10))
+	spec := core.ResourceConstraintsSpec{ProjectScopeResourceConstraint: &core.ResourceConstraint{Value: 1}}
+
+	rm := m.ForTask(newTaskMetadata("flytesnacks", "flytesnacks-development"))
+	assert.Equal(t, "in-memory", rm.GetID())
+	status, err := rm.AllocateResource(ctx, "ns", "token-1", spec)
+	assert.NoError(t, err)
+	assert.Equal(t, core.AllocationStatusGranted, status)
+
+	status, err = m.ForTask(newTaskMetadata("flytesnacks", "flytesnacks-production")).AllocateResource(ctx, "ns",
+		"token-2", spec)
+	assert.NoError(t, err)
+	assert.Equal(t, core.AllocationStatusNamespaceQuotaExceeded, status)
+}
+
+func TestInMemoryResourceManager_PriorityAging(t *testing.T) {
+	ctx := context.Background()
+	clck := testing2.NewFakeClock(time.Now())
+	m := core.NewInMemoryResourceManagerWithClock("in-memory", clck)
+	assert.NoError(t, m.RegisterResourceQuota(ctx, "ns", 1))
+	rm := m.ForScope(core.ResourceScope{Project: "flytesnacks", Namespace: "flytesnacks-development"})
+	low := core.ResourceConstraintsSpec{Priority: core.ResourcePriorityLow}
+	high := core.ResourceConstraintsSpec{Priority: core.ResourcePriorityHigh}
+
+	allocate := func(token string, spec core.ResourceConstraintsSpec) core.AllocationStatus {
+		status, err := rm.AllocateResource(ctx, "ns", token, spec)
+		assert.NoError(t, err)
+		return status
+	}
+
+	assert.Equal(t, core.AllocationStatusGranted, allocate("token-1", high))
+	assert.Equal(t, core.AllocationStatusExhausted, allocate("low", low))
+
+	// The low priority allocation keeps retrying while high priority ones keep coming.
+	for i := 0; i < 3; i++ {
+		clck.Step(5 * time.Minute)
+		assert.Equal(t, core.AllocationStatusExhausted, allocate("low", low))
+	}
+
+	assert.Equal(t, core.AllocationStatusExhausted, allocate("high", high))
+	assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-1"))
+	assert.Equal(t, core.AllocationStatusExhausted, allocate("high", high))
+	assert.Equal(t, core.AllocationStatusGranted, allocate("low", low))
+
+	t.Run("Abandoned waiter", func(t *testing.T) {
+		assert.Equal(t, core.AllocationStatusExhausted, allocate("high", high))
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "low"))
+
+		// The high priority allocation is never retried, it stops holding back the others after a while.
+		clck.Step(10 * time.Minute)
+		assert.Equal(t, core.AllocationStatusGranted, allocate("token-2", low))
+	})
+}
+
+func TestResourceConstraintsSpec_WithTaskOverrides(t *testing.T) {
+	spec := core.ResourceConstraintsSpec{ProjectScopeResourceConstraint: &core.ResourceConstraint{Value: 10}}
+	assert.Equal(t, int64(1), spec.GetUnits())
+
+	t.Run("No overrides", func(t *testing.T) {
+		overridden, err := spec.WithTaskOverrides(&idlCore.TaskTemplate{}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, spec, overridden)
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		overridden, err := spec.WithTaskOverrides(&idlCore.TaskTemplate{Config: map[string]string{
+			core.ResourceUnitsConfigKey:    "3",
+			core.ResourcePriorityConfigKey: "low",
+		}}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), overridden.GetUnits())
+		assert.Equal(t, core.ResourcePriorityLow, overridden.Priority)
+		assert.Equal(t, spec.ProjectScopeResourceConstraint, overridden.ProjectScopeResourceConstraint)
+	})
+
+	t.Run("Granted priority", func(t *testing.T) {
+		overridden, err := spec.WithTaskOverrides(&idlCore.TaskTemplate{}, "high")
+		assert.NoError(t, err)
+		assert.Equal(t, core.ResourcePriorityHigh, overridden.Priority)
+
+		overridden, err = spec.WithTaskOverrides(&idlCore.TaskTemplate{}, "low")
+		assert.NoError(t, err)
+		assert.Equal(t, core.ResourcePriorityLow, overridden.Priority)
+	})
+
+	t.Run("Tasks can only lower the priority", func(t *testing.T) {
+		overridden, err := spec.WithTaskOverrides(&idlCore.TaskTemplate{Config: map[string]string{
+			core.ResourcePriorityConfigKey: "high",
+		}}, "low")
+		assert.NoError(t, err)
+		assert.Equal(t, core.ResourcePriorityLow, overridden.Priority)
+
+		overridden, err = spec.WithTaskOverrides(&idlCore.TaskTemplate{Config: map[string]string{
+			core.ResourcePriorityConfigKey: "high",
+		}}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, core.ResourcePriorityDefault, overridden.Priority)
+
+		overridden, err = spec.WithTaskOverrides(&idlCore.TaskTemplate{Config: map[string]string{
+			core.ResourcePriorityConfigKey: "default",
+		}}, "high")
+		assert.NoError(t, err)
+		assert.Equal(t, core.ResourcePriorityDefault, overridden.Priority)
+	})
+
+	t.Run("Invalid units", func(t *testing.T) {
+		_, err := spec.WithTaskOverrides(&idlCore.TaskTemplate{Config: map[string]string{
+			core.ResourceUnitsConfigKey: "0",
+		}}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid priority", func(t *testing.T) {
+		_, err := spec.WithTaskOverrides(&idlCore.TaskTemplate{Config: map[string]string{
+			core.ResourcePriorityConfigKey: "urgent",
+		}}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid granted priority", func(t *testing.T) {
+		_, err := spec.WithTaskOverrides(&idlCore.TaskTemplate{}, "urgent")
+		assert.Error(t, err)
+	})
+}
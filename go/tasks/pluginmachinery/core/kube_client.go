@@ -14,3 +14,20 @@ type KubeClient interface {
 	// GetCache returns a cache.Cache
 	GetCache() cache.Cache
 }
+
+// KubeReaderProvider is an optional interface a TaskExecutionContext can implement to let plugins read objects, e.g.
+// Secrets, from the cluster the task runs in.
+type KubeReaderProvider interface {
+	// KubeReader returns a reader of the cluster the task runs in, typically backed by the plugin's informer cache.
+	KubeReader() client.Reader
+}
+
+// GetKubeReader returns the reader of the cluster the task runs in, or nil if the TaskExecutionContext doesn't implement
+// KubeReaderProvider.
+func GetKubeReader(tCtx TaskExecutionContext) client.Reader {
+	if provider, ok := tCtx.(KubeReaderProvider); ok {
+		return provider.KubeReader()
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthChecker is an autogenerated mock type for the HealthChecker type
+type HealthChecker struct {
+	mock.Mock
+}
+
+type HealthChecker_CheckHealth struct {
+	*mock.Call
+}
+
+func (_m HealthChecker_CheckHealth) Return(_a0 error) *HealthChecker_CheckHealth {
+	return &HealthChecker_CheckHealth{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *HealthChecker) OnCheckHealth(ctx context.Context) *HealthChecker_CheckHealth {
+	c := _m.On("CheckHealth", ctx)
+	return &HealthChecker_CheckHealth{Call: c}
+}
+
+func (_m *HealthChecker) OnCheckHealthMatch(matchers ...interface{}) *HealthChecker_CheckHealth {
+	c := _m.On("CheckHealth", matchers...)
+	return &HealthChecker_CheckHealth{Call: c}
+}
+
+// CheckHealth provides a mock function with given fields: ctx
+func (_m *HealthChecker) CheckHealth(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,191 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	core "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LeasedResourceManager is an autogenerated mock type for the LeasedResourceManager type
+type LeasedResourceManager struct {
+	mock.Mock
+}
+
+type LeasedResourceManager_AllocateResource struct {
+	*mock.Call
+}
+
+func (_m LeasedResourceManager_AllocateResource) Return(_a0 core.AllocationStatus, _a1 error) *LeasedResourceManager_AllocateResource {
+	return &LeasedResourceManager_AllocateResource{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *LeasedResourceManager) OnAllocateResource(ctx context.Context, namespace core.ResourceNamespace, allocationToken string, constraintsSpec core.ResourceConstraintsSpec) *LeasedResourceManager_AllocateResource {
+	c := _m.On("AllocateResource", ctx, namespace, allocationToken, constraintsSpec)
+	return &LeasedResourceManager_AllocateResource{Call: c}
+}
+
+func (_m *LeasedResourceManager) OnAllocateResourceMatch(matchers ...interface{}) *LeasedResourceManager_AllocateResource {
+	c := _m.On("AllocateResource", matchers...)
+	return &LeasedResourceManager_AllocateResource{Call: c}
+}
+
+// AllocateResource provides a mock function with given fields: ctx, namespace, allocationToken, constraintsSpec
+func (_m *LeasedResourceManager) AllocateResource(ctx context.Context, namespace core.ResourceNamespace, allocationToken string, constraintsSpec core.ResourceConstraintsSpec) (core.AllocationStatus, error) {
+	ret := _m.Called(ctx, namespace, allocationToken, constraintsSpec)
+
+	var r0 core.AllocationStatus
+	if rf, ok := ret.Get(0).(func(context.Context, core.ResourceNamespace, string, core.ResourceConstraintsSpec) core.AllocationStatus); ok {
+		r0 = rf(ctx, namespace, allocationToken, constraintsSpec)
+	} else {
+		r0 = ret.Get(0).(core.AllocationStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, core.ResourceNamespace, string, core.ResourceConstraintsSpec) error); ok {
+		r1 = rf(ctx, namespace, allocationToken, constraintsSpec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type LeasedResourceManager_AllocateResourceWithLease struct {
+	*mock.Call
+}
+
+func (_m LeasedResourceManager_AllocateResourceWithLease) Return(_a0 core.AllocationStatus, _a1 error) *LeasedResourceManager_AllocateResourceWithLease {
+	return &LeasedResourceManager_AllocateResourceWithLease{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *LeasedResourceManager) OnAllocateResourceWithLease(ctx context.Context, namespace core.ResourceNamespace, allocationToken string, constraintsSpec core.ResourceConstraintsSpec, ttl time.Duration) *LeasedResourceManager_AllocateResourceWithLease {
+	c := _m.On("AllocateResourceWithLease", ctx, namespace, allocationToken, constraintsSpec, ttl)
+	return &LeasedResourceManager_AllocateResourceWithLease{Call: c}
+}
+
+func (_m *LeasedResourceManager) OnAllocateResourceWithLeaseMatch(matchers ...interface{}) *LeasedResourceManager_AllocateResourceWithLease {
+	c := _m.On("AllocateResourceWithLease", matchers...)
+	return &LeasedResourceManager_AllocateResourceWithLease{Call: c}
+}
+
+// AllocateResourceWithLease provides a mock function with given fields: ctx, namespace, allocationToken, constraintsSpec, ttl
+func (_m *LeasedResourceManager) AllocateResourceWithLease(ctx context.Context, namespace core.ResourceNamespace, allocationToken string, constraintsSpec core.ResourceConstraintsSpec, ttl time.Duration) (core.AllocationStatus, error) {
+	ret := _m.Called(ctx, namespace, allocationToken, constraintsSpec, ttl)
+
+	var r0 core.AllocationStatus
+	if rf, ok := ret.Get(0).(func(context.Context, core.ResourceNamespace, string, core.ResourceConstraintsSpec, time.Duration) core.AllocationStatus); ok {
+		r0 = rf(ctx, namespace, allocationToken, constraintsSpec, ttl)
+	} else {
+		r0 = ret.Get(0).(core.AllocationStatus)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, core.ResourceNamespace, string, core.ResourceConstraintsSpec, time.Duration) error); ok {
+		r1 = rf(ctx, namespace, allocationToken, constraintsSpec, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type LeasedResourceManager_GetID struct {
+	*mock.Call
+}
+
+func (_m LeasedResourceManager_GetID) Return(_a0 string) *LeasedResourceManager_GetID {
+	return &LeasedResourceManager_GetID{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *LeasedResourceManager) OnGetID() *LeasedResourceManager_GetID {
+	c := _m.On("GetID")
+	return &LeasedResourceManager_GetID{Call: c}
+}
+
+func (_m *LeasedResourceManager) OnGetIDMatch(matchers ...interface{}) *LeasedResourceManager_GetID {
+	c := _m.On("GetID", matchers...)
+	return &LeasedResourceManager_GetID{Call: c}
+}
+
+// GetID provides a mock function with given fields:
+func (_m *LeasedResourceManager) GetID() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type LeasedResourceManager_ReleaseResource struct {
+	*mock.Call
+}
+
+func (_m LeasedResourceManager_ReleaseResource) Return(_a0 error) *LeasedResourceManager_ReleaseResource {
+	return &LeasedResourceManager_ReleaseResource{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *LeasedResourceManager) OnReleaseResource(ctx context.Context, namespace core.ResourceNamespace, allocationToken string) *LeasedResourceManager_ReleaseResource {
+	c := _m.On("ReleaseResource", ctx, namespace, allocationToken)
+	return &LeasedResourceManager_ReleaseResource{Call: c}
+}
+
+func (_m *LeasedResourceManager) OnReleaseResourceMatch(matchers ...interface{}) *LeasedResourceManager_ReleaseResource {
+	c := _m.On("ReleaseResource", matchers...)
+	return &LeasedResourceManager_ReleaseResource{Call: c}
+}
+
+// ReleaseResource provides a mock function with given fields: ctx, namespace, allocationToken
+func (_m *LeasedResourceManager) ReleaseResource(ctx context.Context, namespace core.ResourceNamespace, allocationToken string) error {
+	ret := _m.Called(ctx, namespace, allocationToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, core.ResourceNamespace, string) error); ok {
+		r0 = rf(ctx, namespace, allocationToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type LeasedResourceManager_RenewResource struct {
+	*mock.Call
+}
+
+func (_m LeasedResourceManager_RenewResource) Return(_a0 error) *LeasedResourceManager_RenewResource {
+	return &LeasedResourceManager_RenewResource{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *LeasedResourceManager) OnRenewResource(ctx context.Context, namespace core.ResourceNamespace, allocationToken string, ttl time.Duration) *LeasedResourceManager_RenewResource {
+	c := _m.On("RenewResource", ctx, namespace, allocationToken, ttl)
+	return &LeasedResourceManager_RenewResource{Call: c}
+}
+
+func (_m *LeasedResourceManager) OnRenewResourceMatch(matchers ...interface{}) *LeasedResourceManager_RenewResource {
+	c := _m.On("RenewResource", matchers...)
+	return &LeasedResourceManager_RenewResource{Call: c}
+}
+
+// RenewResource provides a mock function with given fields: ctx, namespace, allocationToken, ttl
+func (_m *LeasedResourceManager) RenewResource(ctx context.Context, namespace core.ResourceNamespace, allocationToken string, ttl time.Duration) error {
+	ret := _m.Called(ctx, namespace, allocationToken, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, core.ResourceNamespace, string, time.Duration) error); ok {
+		r0 = rf(ctx, namespace, allocationToken, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
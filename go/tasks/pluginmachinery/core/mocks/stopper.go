@@ -0,0 +1,46 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Stopper is an autogenerated mock type for the Stopper type
+type Stopper struct {
+	mock.Mock
+}
+
+type Stopper_Stop struct {
+	*mock.Call
+}
+
+func (_m Stopper_Stop) Return(_a0 error) *Stopper_Stop {
+	return &Stopper_Stop{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *Stopper) OnStop(ctx context.Context) *Stopper_Stop {
+	c := _m.On("Stop", ctx)
+	return &Stopper_Stop{Call: c}
+}
+
+func (_m *Stopper) OnStopMatch(matchers ...interface{}) *Stopper_Stop {
+	c := _m.On("Stop", matchers...)
+	return &Stopper_Stop{Call: c}
+}
+
+// Stop provides a mock function with given fields: ctx
+func (_m *Stopper) Stop(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
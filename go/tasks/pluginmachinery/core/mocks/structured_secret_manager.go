@@ -0,0 +1,93 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	core "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StructuredSecretManager is an autogenerated mock type for the StructuredSecretManager type
+type StructuredSecretManager struct {
+	mock.Mock
+}
+
+type StructuredSecretManager_Get struct {
+	*mock.Call
+}
+
+func (_m StructuredSecretManager_Get) Return(_a0 string, _a1 error) *StructuredSecretManager_Get {
+	return &StructuredSecretManager_Get{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *StructuredSecretManager) OnGet(ctx context.Context, key string) *StructuredSecretManager_Get {
+	c := _m.On("Get", ctx, key)
+	return &StructuredSecretManager_Get{Call: c}
+}
+
+func (_m *StructuredSecretManager) OnGetMatch(matchers ...interface{}) *StructuredSecretManager_Get {
+	c := _m.On("Get", matchers...)
+	return &StructuredSecretManager_Get{Call: c}
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *StructuredSecretManager) Get(ctx context.Context, key string) (string, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type StructuredSecretManager_GetSecret struct {
+	*mock.Call
+}
+
+func (_m StructuredSecretManager_GetSecret) Return(_a0 string, _a1 error) *StructuredSecretManager_GetSecret {
+	return &StructuredSecretManager_GetSecret{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *StructuredSecretManager) OnGetSecret(ctx context.Context, ref core.SecretReference) *StructuredSecretManager_GetSecret {
+	c := _m.On("GetSecret", ctx, ref)
+	return &StructuredSecretManager_GetSecret{Call: c}
+}
+
+func (_m *StructuredSecretManager) OnGetSecretMatch(matchers ...interface{}) *StructuredSecretManager_GetSecret {
+	c := _m.On("GetSecret", matchers...)
+	return &StructuredSecretManager_GetSecret{Call: c}
+}
+
+// GetSecret provides a mock function with given fields: ctx, ref
+func (_m *StructuredSecretManager) GetSecret(ctx context.Context, ref core.SecretReference) (string, error) {
+	ret := _m.Called(ctx, ref)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, core.SecretReference) string); ok {
+		r0 = rf(ctx, ref)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, core.SecretReference) error); ok {
+		r1 = rf(ctx, ref)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,47 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	core "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TaskValidator is an autogenerated mock type for the TaskValidator type
+type TaskValidator struct {
+	mock.Mock
+}
+
+type TaskValidator_ValidateTask struct {
+	*mock.Call
+}
+
+func (_m TaskValidator_ValidateTask) Return(_a0 error) *TaskValidator_ValidateTask {
+	return &TaskValidator_ValidateTask{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *TaskValidator) OnValidateTask(ctx context.Context, taskTemplate *core.TaskTemplate) *TaskValidator_ValidateTask {
+	c := _m.On("ValidateTask", ctx, taskTemplate)
+	return &TaskValidator_ValidateTask{Call: c}
+}
+
+func (_m *TaskValidator) OnValidateTaskMatch(matchers ...interface{}) *TaskValidator_ValidateTask {
+	c := _m.On("ValidateTask", matchers...)
+	return &TaskValidator_ValidateTask{Call: c}
+}
+
+// ValidateTask provides a mock function with given fields: ctx, taskTemplate
+func (_m *TaskValidator) ValidateTask(ctx context.Context, taskTemplate *core.TaskTemplate) error {
+	ret := _m.Called(ctx, taskTemplate)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.TaskTemplate) error); ok {
+		r0 = rf(ctx, taskTemplate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
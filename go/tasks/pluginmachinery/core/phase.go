@@ -76,6 +76,8 @@ type TaskInfo struct {
 	CustomInfo *structpb.Struct
 	// Metadata around how a task was executed
 	Metadata *event.TaskExecutionMetadata
+	// The resources consumed by the task execution so far, if the plugin can tell.
+	ResourceUsage *ResourceUsage
 }
 
 func (t *TaskInfo) String() string {
@@ -3,6 +3,10 @@ package core
 import (
 	"context"
 	"fmt"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 )
 
 //go:generate mockery -all -case=underscore
@@ -59,6 +63,70 @@ type Plugin interface {
 	Finalize(ctx context.Context, tCtx TaskExecutionContext) error
 }
 
+// HealthChecker is an optional interface a Plugin can implement to report whether it's able to handle tasks, e.g.
+// whether the remote service it submits tasks to is reachable.
+type HealthChecker interface {
+	// CheckHealth returns an error describing why the plugin is unhealthy, nil if it's healthy.
+	CheckHealth(ctx context.Context) error
+}
+
+// Stopper is an optional interface a Plugin can implement to shut down the background processing it starts when it's
+// loaded (e.g. auto-refresh caches and work queue workers).
+type Stopper interface {
+	// Stop signals the background processing of the plugin to exit and waits for it to drain, or until ctx is done.
+	// No Handle/Abort/Finalize function is invoked after Stop.
+	Stop(ctx context.Context) error
+}
+
+// CheckPluginHealth returns the health of the plugin. Plugins that don't implement HealthChecker are always healthy.
+func CheckPluginHealth(ctx context.Context, plugin Plugin) error {
+	if checker, ok := plugin.(HealthChecker); ok {
+		return checker.CheckHealth(ctx)
+	}
+
+	return nil
+}
+
+// StopPlugin stops the plugin. It's a no-op for plugins that don't implement Stopper.
+func StopPlugin(ctx context.Context, plugin Plugin) error {
+	if stopper, ok := plugin.(Stopper); ok {
+		return stopper.Stop(ctx)
+	}
+
+	return nil
+}
+
+// TaskValidator is an optional interface a Plugin can implement to validate task templates ahead of their execution,
+// e.g. when tasks are registered, instead of failing when the task is launched.
+type TaskValidator interface {
+	// ValidateTask returns a BadTaskSpecification error if the plugin can't execute the task, e.g. because the template
+	// is malformed or its task type version isn't supported. It must only depend on the task template.
+	ValidateTask(ctx context.Context, taskTemplate *core.TaskTemplate) error
+}
+
+// ValidateTask validates the task template with the plugin. Plugins that don't implement TaskValidator accept all the
+// task templates.
+func ValidateTask(ctx context.Context, plugin Plugin, taskTemplate *core.TaskTemplate) error {
+	if validator, ok := plugin.(TaskValidator); ok {
+		return validator.ValidateTask(ctx, taskTemplate)
+	}
+
+	return nil
+}
+
+// ValidateTaskTypeVersion returns a BadTaskSpecification error if the task type version of the template isn't one of
+// the supported versions.
+func ValidateTaskTypeVersion(taskTemplate *core.TaskTemplate, supportedVersions ...int32) error {
+	for _, version := range supportedVersions {
+		if taskTemplate.GetTaskTypeVersion() == version {
+			return nil
+		}
+	}
+
+	return errors.Errorf(errors.BadTaskSpecification, "Unsupported version [%v] of task type [%v], expected one of %v.",
+		taskTemplate.GetTaskTypeVersion(), taskTemplate.GetType(), supportedVersions)
+}
+
 // Loads and validates a plugin.
 func LoadPlugin(ctx context.Context, iCtx SetupContext, entry PluginEntry) (Plugin, error) {
 	plugin, err := entry.LoadPlugin(ctx, iCtx)
@@ -2,8 +2,13 @@ package core_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 	"gotest.tools/assert"
@@ -92,3 +97,52 @@ func TestLoadPlugin(t *testing.T) {
 	})
 
 }
+
+type lifecyclePlugin struct {
+	*mocks.Plugin
+	*mocks.HealthChecker
+	*mocks.Stopper
+}
+
+func TestCheckPluginHealth(t *testing.T) {
+	ctx := context.TODO()
+	assert.NilError(t, core.CheckPluginHealth(ctx, &mocks.Plugin{}))
+
+	checker := &mocks.HealthChecker{}
+	checker.OnCheckHealth(ctx).Return(fmt.Errorf("unreachable"))
+	assert.Error(t, core.CheckPluginHealth(ctx, lifecyclePlugin{Plugin: &mocks.Plugin{}, HealthChecker: checker}),
+		"unreachable")
+}
+
+func TestStopPlugin(t *testing.T) {
+	ctx := context.TODO()
+	assert.NilError(t, core.StopPlugin(ctx, &mocks.Plugin{}))
+
+	stopper := &mocks.Stopper{}
+	stopper.OnStop(ctx).Return(nil)
+	assert.NilError(t, core.StopPlugin(ctx, lifecyclePlugin{Plugin: &mocks.Plugin{}, Stopper: stopper}))
+	stopper.AssertCalled(t, "Stop", ctx)
+}
+
+func TestValidateTask(t *testing.T) {
+	ctx := context.TODO()
+	taskTemplate := &idlCore.TaskTemplate{Type: "test"}
+	assert.NilError(t, core.ValidateTask(ctx, &mocks.Plugin{}, taskTemplate))
+
+	validator := &mocks.TaskValidator{}
+	validator.OnValidateTask(ctx, taskTemplate).Return(fmt.Errorf("bad task"))
+	assert.Error(t, core.ValidateTask(ctx, struct {
+		*mocks.Plugin
+		*mocks.TaskValidator
+	}{Plugin: &mocks.Plugin{}, TaskValidator: validator}, taskTemplate), "bad task")
+}
+
+func TestValidateTaskTypeVersion(t *testing.T) {
+	assert.NilError(t, core.ValidateTaskTypeVersion(&idlCore.TaskTemplate{}, 0, 1))
+	assert.NilError(t, core.ValidateTaskTypeVersion(&idlCore.TaskTemplate{TaskTypeVersion: 1}, 0, 1))
+
+	err := core.ValidateTaskTypeVersion(&idlCore.TaskTemplate{Type: "test", TaskTypeVersion: 2}, 0, 1)
+	code, found := stdErrors.GetErrorCode(err)
+	assert.Assert(t, found)
+	assert.Equal(t, errors.BadTaskSpecification, code)
+}
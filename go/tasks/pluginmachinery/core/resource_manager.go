@@ -2,6 +2,12 @@ package core
 
 import (
 	"context"
+	"strconv"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 )
 
 //go:generate enumer -type=AllocationStatus -trimprefix=AllocationStatus
@@ -18,8 +24,8 @@ const (
 	// This means that no resources are available globally.  This is the only rejection message we use right now.
 	AllocationStatusExhausted
 
-	// We're not currently using this - but this would indicate that things globally are okay, but that your
-	// own namespace is too busy
+	// This indicates that things globally are okay, but that your own project or namespace is too busy, as defined by
+	// the ResourceConstraintsSpec of the allocation.
 	AllocationStatusNamespaceQuotaExceeded
 )
 
@@ -45,7 +51,7 @@ type ResourceRegistrar interface {
 //	             manages resources by managing the tokens of the resources.
 // 2. Description
 // 		ResourceManager provides a task-type-specific pooling system for Flyte Tasks. Plugin writers can optionally
-//		request for resources in their tasks, in single quantity.
+//		request for resources in their tasks, in one or more units (see ResourceConstraintsSpec).
 // 3. Usage
 // 		A Flyte plugin registers the resources and the desired quota of each resource with ResourceRegistrar at the
 //		setup time of Flyte Propeller. At the end of the setup time, Flyte Propeller builds a ResourceManager based on
@@ -85,6 +91,75 @@ type ResourceManager interface {
 	ReleaseResource(ctx context.Context, namespace ResourceNamespace, allocationToken string) error
 }
 
+// LeasedResourceManager is an optional interface a ResourceManager can implement to grant tokens as leases. A leased
+// token is released automatically once its lease expires, which prevents tokens from leaking when the plugin never
+// gets to release them (e.g. because Finalize never runs). Plugins must renew the lease while the token is in use.
+type LeasedResourceManager interface {
+	ResourceManager
+
+	// AllocateResourceWithLease behaves like AllocateResource but the token is reclaimed if its lease isn't renewed
+	// within ttl. Allocating a token that is already allocated renews its lease.
+	AllocateResourceWithLease(ctx context.Context, namespace ResourceNamespace, allocationToken string,
+		constraintsSpec ResourceConstraintsSpec, ttl time.Duration) (AllocationStatus, error)
+
+	// RenewResource extends the lease of the token to ttl from now. It fails if the token isn't allocated, including
+	// when its lease has already expired.
+	RenewResource(ctx context.Context, namespace ResourceNamespace, allocationToken string, ttl time.Duration) error
+}
+
+// AllocateLeasedResource allocates the token as a lease of ttl if the ResourceManager supports leases and ttl is
+// positive. Otherwise, the token is allocated until it's released.
+func AllocateLeasedResource(ctx context.Context, rm ResourceManager, namespace ResourceNamespace,
+	allocationToken string, constraintsSpec ResourceConstraintsSpec, ttl time.Duration) (AllocationStatus, error) {
+	if leased, ok := rm.(LeasedResourceManager); ok && ttl > 0 {
+		return leased.AllocateResourceWithLease(ctx, namespace, allocationToken, constraintsSpec, ttl)
+	}
+
+	return rm.AllocateResource(ctx, namespace, allocationToken, constraintsSpec)
+}
+
+// RenewResourceLease renews the lease of a token allocated through AllocateLeasedResource. It's a no-op if the
+// ResourceManager doesn't support leases or ttl isn't positive.
+func RenewResourceLease(ctx context.Context, rm ResourceManager, namespace ResourceNamespace, allocationToken string,
+	ttl time.Duration) error {
+	if leased, ok := rm.(LeasedResourceManager); ok && ttl > 0 {
+		return leased.RenewResource(ctx, namespace, allocationToken, ttl)
+	}
+
+	return nil
+}
+
+const (
+	// ResourceUnitsConfigKey is the key of the task template config that sets the number of units the task claims.
+	ResourceUnitsConfigKey = "resource_units"
+	// ResourcePriorityConfigKey is the key of the task template config that sets the priority class of the task, one of
+	// the ResourcePriority names (e.g. "high").
+	ResourcePriorityConfigKey = "resource_priority"
+)
+
+//go:generate enumer -type=ResourcePriority -trimprefix=ResourcePriority -transform=snake
+
+// ResourcePriority is the priority class of an allocation.
+type ResourcePriority int
+
+const (
+	ResourcePriorityDefault ResourcePriority = iota
+	ResourcePriorityLow
+	ResourcePriorityHigh
+)
+
+// Rank returns a number that orders priority classes, the higher the rank the sooner the allocation is granted.
+func (p ResourcePriority) Rank() int {
+	switch p {
+	case ResourcePriorityLow:
+		return -1
+	case ResourcePriorityHigh:
+		return 1
+	default:
+		return 0
+	}
+}
+
 type ResourceConstraint struct {
 	Value int64
 }
@@ -95,7 +170,65 @@ type ResourceConstraint struct {
 // Setting constraints in a ResourceConstraintsSpec to nil objects is valid, meaning there's no constraint at the corresponding level.
 // For example, a ResourceConstraintsSpec with nil ProjectScopeResourceConstraint and a non-nil NamespaceScopeResourceConstraint means
 // that it only poses a cap at the namespace level. A zero-value ResourceConstraintsSpec means there's no constraints posed at any level.
+//
+// Units and Priority describe the weight of the allocation. The constraints and the quota of the namespace are expressed
+// in units, a zero-value ResourceConstraintsSpec claims a single unit at the default priority.
 type ResourceConstraintsSpec struct {
 	ProjectScopeResourceConstraint   *ResourceConstraint
 	NamespaceScopeResourceConstraint *ResourceConstraint
+	// The number of units of the resource the allocation claims. Zero means a single unit.
+	Units int64
+	// The priority class of the allocation. Among the allocations waiting for the same resource, the ones with a higher
+	// priority are granted first.
+	Priority ResourcePriority
+}
+
+// GetUnits returns the number of units the allocation claims.
+func (s ResourceConstraintsSpec) GetUnits() int64 {
+	if s.Units == 0 {
+		return 1
+	}
+
+	return s.Units
+}
+
+// WithTaskOverrides returns a copy of the spec with the units and the priority of the allocations of the task. Tasks
+// set them through the ResourceUnitsConfigKey and ResourcePriorityConfigKey entries of their config. The priority is
+// the one granted to the execution (e.g. by the ResourcePriorities of the plugins config), tasks can only lower it. An
+// empty granted priority keeps the priority of the spec.
+func (s ResourceConstraintsSpec) WithTaskOverrides(taskTemplate *core.TaskTemplate, grantedPriority string) (
+	ResourceConstraintsSpec, error) {
+	if units, found := taskTemplate.GetConfig()[ResourceUnitsConfigKey]; found {
+		value, err := strconv.ParseInt(units, 10, 64)
+		if err != nil || value <= 0 {
+			return s, errors.Errorf(errors.BadTaskSpecification,
+				"Invalid %v [%v], expected a positive integer.", ResourceUnitsConfigKey, units)
+		}
+
+		s.Units = value
+	}
+
+	if len(grantedPriority) > 0 {
+		value, err := ResourcePriorityString(grantedPriority)
+		if err != nil {
+			return s, errors.Wrapf(errors.RuntimeFailure, err, "Invalid resource priority [%v] granted to the execution.",
+				grantedPriority)
+		}
+
+		s.Priority = value
+	}
+
+	if priority, found := taskTemplate.GetConfig()[ResourcePriorityConfigKey]; found {
+		value, err := ResourcePriorityString(priority)
+		if err != nil {
+			return s, errors.Wrapf(errors.BadTaskSpecification, err, "Invalid %v [%v].", ResourcePriorityConfigKey,
+				priority)
+		}
+
+		if value.Rank() < s.Priority.Rank() {
+			s.Priority = value
+		}
+	}
+
+	return s, nil
 }
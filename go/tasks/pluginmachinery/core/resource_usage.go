@@ -0,0 +1,64 @@
+package core
+
+import (
+	"time"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+const bytesPerTerabyte = 1e12
+
+// ResourceUsage describes the resources consumed by a task execution, e.g. to charge them back to its project. Fields
+// that don't apply to a plugin are left empty.
+type ResourceUsage struct {
+	// The CPU time reserved by the execution, in core-seconds.
+	CPUSeconds float64
+	// The peak amount of memory reserved by the execution, in bytes.
+	MemoryHighWaterMarkBytes int64
+	// The GPU time reserved by the execution, in GPU-seconds.
+	GPUSeconds float64
+	// The amount of data the execution scanned, in bytes.
+	BytesScanned int64
+	// The estimated cost of the execution, in the currency of the prices it was estimated with.
+	EstimatedCost float64
+}
+
+// Add accumulates the usage of other, consumed after the usage, into the usage. The memory high-water mark becomes the
+// highest of both.
+func (u *ResourceUsage) Add(other ResourceUsage) {
+	u.CPUSeconds += other.CPUSeconds
+	u.GPUSeconds += other.GPUSeconds
+	u.BytesScanned += other.BytesScanned
+	u.EstimatedCost += other.EstimatedCost
+	if other.MemoryHighWaterMarkBytes > u.MemoryHighWaterMarkBytes {
+		u.MemoryHighWaterMarkBytes = other.MemoryHighWaterMarkBytes
+	}
+}
+
+// AddConcurrent accumulates the usage of other, consumed alongside the usage, into the usage. The memory high-water
+// marks add up.
+func (u *ResourceUsage) AddConcurrent(other ResourceUsage) {
+	memoryBytes := u.MemoryHighWaterMarkBytes + other.MemoryHighWaterMarkBytes
+	u.Add(other)
+	u.MemoryHighWaterMarkBytes = memoryBytes
+}
+
+// ResourceUsageOver returns the usage of holding the CPU cores, the GPUs and the bytes of memory for the duration.
+func ResourceUsageOver(d time.Duration, cpu, gpu float64, memoryBytes int64) ResourceUsage {
+	if d < 0 {
+		d = 0
+	}
+
+	return ResourceUsage{
+		CPUSeconds:               cpu * d.Seconds(),
+		GPUSeconds:               gpu * d.Seconds(),
+		MemoryHighWaterMarkBytes: memoryBytes,
+	}
+}
+
+// WithEstimatedCost returns a copy of the usage with its cost estimated from the unit prices.
+func (u ResourceUsage) WithEstimatedCost(prices config.ResourcePrices) ResourceUsage {
+	u.EstimatedCost = u.CPUSeconds*prices.CPUSecond + u.GPUSeconds*prices.GPUSecond +
+		float64(u.BytesScanned)/bytesPerTerabyte*prices.TerabyteScanned
+	return u
+}
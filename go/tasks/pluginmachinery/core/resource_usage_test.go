@@ -0,0 +1,37 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+func TestResourceUsage(t *testing.T) {
+	usage := core.ResourceUsageOver(time.Minute, 2, 1, 100)
+	assert.Equal(t, core.ResourceUsage{CPUSeconds: 120, GPUSeconds: 60, MemoryHighWaterMarkBytes: 100}, usage)
+	assert.Equal(t, core.ResourceUsage{MemoryHighWaterMarkBytes: 100}, core.ResourceUsageOver(-time.Minute, 2, 1, 100))
+
+	t.Run("Add", func(t *testing.T) {
+		total := usage
+		total.Add(core.ResourceUsage{CPUSeconds: 30, MemoryHighWaterMarkBytes: 50, BytesScanned: 10})
+		assert.Equal(t, core.ResourceUsage{CPUSeconds: 150, GPUSeconds: 60, MemoryHighWaterMarkBytes: 100,
+			BytesScanned: 10}, total)
+	})
+
+	t.Run("AddConcurrent", func(t *testing.T) {
+		total := usage
+		total.AddConcurrent(core.ResourceUsage{CPUSeconds: 30, MemoryHighWaterMarkBytes: 50})
+		assert.Equal(t, core.ResourceUsage{CPUSeconds: 150, GPUSeconds: 60, MemoryHighWaterMarkBytes: 150}, total)
+	})
+
+	t.Run("WithEstimatedCost", func(t *testing.T) {
+		usage := core.ResourceUsage{CPUSeconds: 100, GPUSeconds: 10, BytesScanned: 2e12}
+		prices := config.ResourcePrices{CPUSecond: 0.01, GPUSecond: 0.1, TerabyteScanned: 5}
+		assert.InDelta(t, 12, usage.WithEstimatedCost(prices).EstimatedCost, 1e-9)
+		assert.Zero(t, usage.EstimatedCost)
+	})
+}
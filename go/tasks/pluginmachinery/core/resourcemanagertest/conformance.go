@@ -0,0 +1,352 @@
+// Package resourcemanagertest provides a conformance test suite for implementations of core.ResourceManager.
+package resourcemanagertest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// Subject is the ResourceManager implementation under test.
+type Subject struct {
+	Registrar core.ResourceRegistrar
+	// ForScope returns the ResourceManager used by tasks running in the given scope.
+	ForScope func(scope core.ResourceScope) core.ResourceManager
+	// Step advances the clock the implementation uses to expire leases. The lease tests are skipped if it's nil or if
+	// the ResourceManager doesn't implement core.LeasedResourceManager.
+	Step func(d time.Duration)
+}
+
+var (
+	scopeA1 = core.ResourceScope{Project: "project-a", Namespace: "namespace-1"}
+	scopeA2 = core.ResourceScope{Project: "project-a", Namespace: "namespace-2"}
+	scopeB1 = core.ResourceScope{Project: "project-b", Namespace: "namespace-1"}
+)
+
+func constraint(value int64) *core.ResourceConstraint {
+	return &core.ResourceConstraint{Value: value}
+}
+
+func allocate(t *testing.T, rm core.ResourceManager, namespace core.ResourceNamespace, token string,
+	spec core.ResourceConstraintsSpec) core.AllocationStatus {
+	status, err := rm.AllocateResource(context.Background(), namespace, token, spec)
+	require.NoError(t, err)
+	return status
+}
+
+// RunConformanceTests runs the conformance test suite. newSubject is called once per test and must return an
+// implementation without any registered quota or allocated token.
+func RunConformanceTests(t *testing.T, newSubject func(t *testing.T) Subject) {
+	ctx := context.Background()
+	noConstraints := core.ResourceConstraintsSpec{}
+
+	t.Run("Quota", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 2))
+		rm := s.ForScope(scopeA1)
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-1", noConstraints))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-2", noConstraints))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "token-3", noConstraints))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, s.ForScope(scopeB1), "ns", "token-4",
+			noConstraints))
+	})
+
+	t.Run("Quotas are independent", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns-1", 1))
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns-2", 1))
+		rm := s.ForScope(scopeA1)
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns-1", "token-1", noConstraints))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns-2", "token-2", noConstraints))
+	})
+
+	t.Run("Idempotent allocation", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 1))
+		rm := s.ForScope(scopeA1)
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-1", noConstraints))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-1", noConstraints))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "token-2", noConstraints))
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 1))
+		rm := s.ForScope(scopeA1)
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-1", noConstraints))
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-1"))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-2", noConstraints))
+	})
+
+	t.Run("Release unknown token", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 1))
+		rm := s.ForScope(scopeA1)
+
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-1"))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-1", noConstraints))
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-1"))
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-1"))
+	})
+
+	t.Run("Project constraint", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 10))
+		spec := core.ResourceConstraintsSpec{ProjectScopeResourceConstraint: constraint(2)}
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, s.ForScope(scopeA1), "ns", "token-1", spec))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, s.ForScope(scopeA2), "ns", "token-2", spec))
+		assert.Equal(t, core.AllocationStatusNamespaceQuotaExceeded, allocate(t, s.ForScope(scopeA1), "ns",
+			"token-3", spec))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, s.ForScope(scopeB1), "ns", "token-4", spec))
+
+		assert.NoError(t, s.ForScope(scopeA2).ReleaseResource(ctx, "ns", "token-2"))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, s.ForScope(scopeA1), "ns", "token-3", spec))
+	})
+
+	t.Run("Namespace constraint", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 10))
+		spec := core.ResourceConstraintsSpec{NamespaceScopeResourceConstraint: constraint(1)}
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, s.ForScope(scopeA1), "ns", "token-1", spec))
+		assert.Equal(t, core.AllocationStatusNamespaceQuotaExceeded, allocate(t, s.ForScope(scopeA1), "ns",
+			"token-2", spec))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, s.ForScope(scopeA2), "ns", "token-3", spec))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, s.ForScope(scopeB1), "ns", "token-4", spec))
+	})
+
+	t.Run("Global quota takes precedence", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 1))
+		spec := core.ResourceConstraintsSpec{NamespaceScopeResourceConstraint: constraint(1)}
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, s.ForScope(scopeA1), "ns", "token-1", spec))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, s.ForScope(scopeA1), "ns", "token-2", spec))
+	})
+
+	t.Run("Unregistered namespace", func(t *testing.T) {
+		s := newSubject(t)
+		_, err := s.ForScope(scopeA1).AllocateResource(ctx, "unknown", "token-1", noConstraints)
+		assert.Error(t, err)
+	})
+
+	t.Run("Concurrent allocations", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 5))
+		rm := s.ForScope(scopeA1)
+
+		var granted int
+		var lock sync.Mutex
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				status, err := rm.AllocateResource(ctx, "ns", fmt.Sprintf("token-%v", i), noConstraints)
+				assert.NoError(t, err)
+				if status == core.AllocationStatusGranted {
+					lock.Lock()
+					granted++
+					lock.Unlock()
+				}
+			}(i)
+		}
+
+		wg.Wait()
+		assert.Equal(t, 5, granted)
+	})
+
+	runWeightTests(t, newSubject)
+	runLeaseTests(t, newSubject)
+}
+
+func runWeightTests(t *testing.T, newSubject func(t *testing.T) Subject) {
+	ctx := context.Background()
+	units := func(units int64) core.ResourceConstraintsSpec {
+		return core.ResourceConstraintsSpec{Units: units}
+	}
+
+	priority := func(priority core.ResourcePriority) core.ResourceConstraintsSpec {
+		return core.ResourceConstraintsSpec{Priority: priority}
+	}
+
+	t.Run("Units", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 4))
+		rm := s.ForScope(scopeA1)
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-1", units(3)))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-2", units(1)))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "token-3", units(1)))
+
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-1"))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-3", units(3)))
+	})
+
+	t.Run("Units exceeding the quota", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 2))
+		_, err := s.ForScope(scopeA1).AllocateResource(ctx, "ns", "token-1", units(3))
+		assert.Error(t, err)
+	})
+
+	t.Run("Units count against constraints", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 10))
+		spec := core.ResourceConstraintsSpec{ProjectScopeResourceConstraint: constraint(3), Units: 2}
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, s.ForScope(scopeA1), "ns", "token-1", spec))
+		assert.Equal(t, core.AllocationStatusNamespaceQuotaExceeded, allocate(t, s.ForScope(scopeA2), "ns",
+			"token-2", spec))
+		spec.Units = 1
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, s.ForScope(scopeA2), "ns", "token-2", spec))
+	})
+
+	t.Run("Large allocations aren't starved", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 4))
+		rm := s.ForScope(scopeA1)
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-1", units(2)))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-2", units(1)))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "large", units(3)))
+
+		// The units freed are held for the large allocation waiting ahead.
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-2"))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "token-3", units(1)))
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-1"))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "large", units(3)))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-3", units(1)))
+	})
+
+	t.Run("Priority", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 1))
+		rm := s.ForScope(scopeA1)
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-1", priority(core.ResourcePriorityLow)))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "low",
+			priority(core.ResourcePriorityLow)))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "default",
+			priority(core.ResourcePriorityDefault)))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "high",
+			priority(core.ResourcePriorityHigh)))
+
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-1"))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "low",
+			priority(core.ResourcePriorityLow)))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "default",
+			priority(core.ResourcePriorityDefault)))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "high",
+			priority(core.ResourcePriorityHigh)))
+
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "high"))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "default",
+			priority(core.ResourcePriorityDefault)))
+	})
+
+	t.Run("Abandoned allocations stop waiting once released", func(t *testing.T) {
+		s := newSubject(t)
+		require.NoError(t, s.Registrar.RegisterResourceQuota(ctx, "ns", 1))
+		rm := s.ForScope(scopeA1)
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-1", units(1)))
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "high",
+			priority(core.ResourcePriorityHigh)))
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "high"))
+
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-1"))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-2", units(1)))
+	})
+}
+
+func newLeasedSubject(t *testing.T, newSubject func(t *testing.T) Subject) (Subject, core.LeasedResourceManager) {
+	s := newSubject(t)
+	rm, ok := s.ForScope(scopeA1).(core.LeasedResourceManager)
+	if !ok || s.Step == nil {
+		t.Skip("The implementation doesn't support leases.")
+	}
+
+	require.NoError(t, s.Registrar.RegisterResourceQuota(context.Background(), "ns", 1))
+	return s, rm
+}
+
+func runLeaseTests(t *testing.T, newSubject func(t *testing.T) Subject) {
+	ctx := context.Background()
+	noConstraints := core.ResourceConstraintsSpec{}
+
+	t.Run("Lease expires", func(t *testing.T) {
+		s, rm := newLeasedSubject(t, newSubject)
+
+		status, err := rm.AllocateResourceWithLease(ctx, "ns", "token-1", noConstraints, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusGranted, status)
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "token-2", noConstraints))
+
+		s.Step(time.Minute)
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-2", noConstraints))
+		assert.Error(t, rm.RenewResource(ctx, "ns", "token-1", time.Minute))
+	})
+
+	t.Run("Lease renewed", func(t *testing.T) {
+		s, rm := newLeasedSubject(t, newSubject)
+
+		_, err := rm.AllocateResourceWithLease(ctx, "ns", "token-1", noConstraints, time.Minute)
+		require.NoError(t, err)
+
+		s.Step(50 * time.Second)
+		assert.NoError(t, rm.RenewResource(ctx, "ns", "token-1", time.Minute))
+
+		s.Step(50 * time.Second)
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "token-2", noConstraints))
+	})
+
+	t.Run("Lease renewed by re-allocation", func(t *testing.T) {
+		s, rm := newLeasedSubject(t, newSubject)
+
+		_, err := rm.AllocateResourceWithLease(ctx, "ns", "token-1", noConstraints, time.Minute)
+		require.NoError(t, err)
+
+		s.Step(50 * time.Second)
+		status, err := rm.AllocateResourceWithLease(ctx, "ns", "token-1", noConstraints, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusGranted, status)
+
+		s.Step(50 * time.Second)
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "token-2", noConstraints))
+	})
+
+	t.Run("Leased token released", func(t *testing.T) {
+		_, rm := newLeasedSubject(t, newSubject)
+
+		_, err := rm.AllocateResourceWithLease(ctx, "ns", "token-1", noConstraints, time.Minute)
+		require.NoError(t, err)
+		assert.NoError(t, rm.ReleaseResource(ctx, "ns", "token-1"))
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-2", noConstraints))
+	})
+
+	t.Run("Token without lease never expires", func(t *testing.T) {
+		s, rm := newLeasedSubject(t, newSubject)
+
+		assert.Equal(t, core.AllocationStatusGranted, allocate(t, rm, "ns", "token-1", noConstraints))
+		s.Step(24 * time.Hour)
+		assert.Equal(t, core.AllocationStatusExhausted, allocate(t, rm, "ns", "token-2", noConstraints))
+	})
+
+	t.Run("Renew unknown token", func(t *testing.T) {
+		_, rm := newLeasedSubject(t, newSubject)
+		assert.Error(t, rm.RenewResource(ctx, "ns", "token-1", time.Minute))
+	})
+}
@@ -0,0 +1,50 @@
+// Code generated by "enumer -type=ResourcePriority -trimprefix=ResourcePriority -transform=snake"; DO NOT EDIT.
+
+package core
+
+import (
+	"fmt"
+)
+
+const _ResourcePriorityName = "defaultlowhigh"
+
+var _ResourcePriorityIndex = [...]uint8{0, 7, 10, 14}
+
+func (i ResourcePriority) String() string {
+	if i < 0 || i >= ResourcePriority(len(_ResourcePriorityIndex)-1) {
+		return fmt.Sprintf("ResourcePriority(%d)", i)
+	}
+	return _ResourcePriorityName[_ResourcePriorityIndex[i]:_ResourcePriorityIndex[i+1]]
+}
+
+var _ResourcePriorityValues = []ResourcePriority{0, 1, 2}
+
+var _ResourcePriorityNameToValueMap = map[string]ResourcePriority{
+	_ResourcePriorityName[0:7]:   0,
+	_ResourcePriorityName[7:10]:  1,
+	_ResourcePriorityName[10:14]: 2,
+}
+
+// ResourcePriorityString retrieves an enum value from the enum constants string name.
+// Throws an error if the param is not part of the enum.
+func ResourcePriorityString(s string) (ResourcePriority, error) {
+	if val, ok := _ResourcePriorityNameToValueMap[s]; ok {
+		return val, nil
+	}
+	return 0, fmt.Errorf("%s does not belong to ResourcePriority values", s)
+}
+
+// ResourcePriorityValues returns all values of the enum
+func ResourcePriorityValues() []ResourcePriority {
+	return _ResourcePriorityValues
+}
+
+// IsAResourcePriority returns "true" if the value is listed in the enum definition. "false" otherwise
+func (i ResourcePriority) IsAResourcePriority() bool {
+	for _, v := range _ResourcePriorityValues {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
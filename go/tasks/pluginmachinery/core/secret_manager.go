@@ -1,7 +1,73 @@
 package core
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"strings"
+)
 
 type SecretManager interface {
 	Get(ctx context.Context, key string) (string, error)
 }
+
+// SecretReference identifies a secret by the group it belongs to (e.g. the credentials of a service or of a project),
+// its key within the group and, optionally, its version. An empty version refers to the latest version of the secret.
+type SecretReference struct {
+	Group   string
+	Key     string
+	Version string
+}
+
+// ParseSecretReference parses a reference of the form [group/]key[@version].
+func ParseSecretReference(s string) (SecretReference, error) {
+	ref := SecretReference{Key: s}
+	if idx := strings.LastIndex(ref.Key, "@"); idx >= 0 {
+		ref.Key, ref.Version = ref.Key[:idx], ref.Key[idx+1:]
+	}
+
+	if idx := strings.Index(ref.Key, "/"); idx >= 0 {
+		ref.Group, ref.Key = ref.Key[:idx], ref.Key[idx+1:]
+	}
+
+	if len(ref.Key) == 0 || strings.Contains(ref.Key, "/") || (strings.Contains(s, "@") && len(ref.Version) == 0) {
+		return SecretReference{}, fmt.Errorf("invalid secret reference [%v], expected [group/]key[@version]", s)
+	}
+
+	return ref, nil
+}
+
+// String returns the reference in the form accepted by ParseSecretReference.
+func (r SecretReference) String() string {
+	s := r.Key
+	if len(r.Group) > 0 {
+		s = r.Group + "/" + s
+	}
+
+	if len(r.Version) > 0 {
+		s += "@" + r.Version
+	}
+
+	return s
+}
+
+// StructuredSecretManager is an optional interface a SecretManager can implement to look secrets up by reference.
+type StructuredSecretManager interface {
+	SecretManager
+
+	// GetSecret returns the value of the referenced secret.
+	GetSecret(ctx context.Context, ref SecretReference) (string, error)
+}
+
+// GetSecret returns the value of the referenced secret. SecretManagers that don't implement StructuredSecretManager are
+// asked for the key of the reference if it has neither group nor version, it's an error otherwise.
+func GetSecret(ctx context.Context, sm SecretManager, ref SecretReference) (string, error) {
+	if structured, ok := sm.(StructuredSecretManager); ok {
+		return structured.GetSecret(ctx, ref)
+	}
+
+	if len(ref.Group) > 0 || len(ref.Version) > 0 {
+		return "", fmt.Errorf("secret manager doesn't support looking up secret [%v] by group or version", ref)
+	}
+
+	return sm.Get(ctx, ref.Key)
+}
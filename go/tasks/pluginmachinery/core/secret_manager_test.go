@@ -0,0 +1,58 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+func TestParseSecretReference(t *testing.T) {
+	for _, s := range []string{"key", "group/key", "key@1", "group/key@1"} {
+		t.Run(s, func(t *testing.T) {
+			ref, err := core.ParseSecretReference(s)
+			assert.NoError(t, err)
+			assert.Equal(t, s, ref.String())
+		})
+	}
+
+	ref, err := core.ParseSecretReference("group/key@1")
+	assert.NoError(t, err)
+	assert.Equal(t, core.SecretReference{Group: "group", Key: "key", Version: "1"}, ref)
+
+	for _, s := range []string{"", "group/", "group/key@", "a/b/c"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := core.ParseSecretReference(s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	ctx := context.TODO()
+	ref := core.SecretReference{Group: "group", Key: "key"}
+
+	t.Run("Structured", func(t *testing.T) {
+		sm := &mocks.StructuredSecretManager{}
+		sm.OnGetSecret(ctx, ref).Return("secret", nil)
+
+		value, err := core.GetSecret(ctx, sm, ref)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", value)
+	})
+
+	t.Run("Key only", func(t *testing.T) {
+		sm := &mocks.SecretManager{}
+		sm.OnGet(ctx, "key").Return("secret", nil)
+
+		value, err := core.GetSecret(ctx, sm, core.SecretReference{Key: "key"})
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", value)
+
+		_, err = core.GetSecret(ctx, sm, ref)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+)
+
+// PluginStateMigration upgrades the custom state a plugin persisted at a given version to the state of the next
+// version.
+type PluginStateMigration struct {
+	// NewState returns a pointer to an empty value of the state persisted at the version this migration upgrades from.
+	// It's only used when reading state persisted at that version.
+	NewState func() interface{}
+
+	// Migrate converts the state of the version this migration upgrades from into the state of the next version.
+	// Migrations are chained, the value returned is passed to the migration of the next version until the current
+	// version is reached.
+	Migrate func(state interface{}) (interface{}, error)
+}
+
+// PluginStateMigrator reads the custom state of a plugin and upgrades state persisted by older versions of the plugin
+// to the current schema. This allows plugins to evolve their state without breaking in-flight executions.
+//
+// Version 0 is also the version reported when no state has been persisted yet. Unless a migration is registered for
+// it, state at version 0 is read as is. Otherwise, the migration must convert the zero value of the old state into the
+// zero value of the new one.
+type PluginStateMigrator struct {
+	version    uint8
+	migrations map[uint8]PluginStateMigration
+}
+
+// NewPluginStateMigrator creates a migrator for state persisted at the given current version.
+func NewPluginStateMigrator(version uint8) *PluginStateMigrator {
+	return &PluginStateMigrator{
+		version:    version,
+		migrations: map[uint8]PluginStateMigration{},
+	}
+}
+
+// Version returns the current version of the state. It's the version to pass to PluginStateWriter.Put.
+func (m *PluginStateMigrator) Version() uint8 {
+	return m.version
+}
+
+// RegisterMigration registers the migration from the given version to the next one. It panics if the version isn't
+// older than the current one or if a migration is already registered for it, since both are programming errors.
+func (m *PluginStateMigrator) RegisterMigration(fromVersion uint8, migration PluginStateMigration) *PluginStateMigrator {
+	if fromVersion >= m.version {
+		panic(fmt.Sprintf("cannot register a migration from version [%v], the current version is [%v]", fromVersion,
+			m.version))
+	}
+
+	if _, found := m.migrations[fromVersion]; found {
+		panic(fmt.Sprintf("a migration from version [%v] is already registered", fromVersion))
+	}
+
+	if migration.NewState == nil || migration.Migrate == nil {
+		panic(fmt.Sprintf("the migration from version [%v] must define both NewState and Migrate", fromVersion))
+	}
+
+	m.migrations[fromVersion] = migration
+	return m
+}
+
+// Get retrieves the state from the reader into t, upgrading it to the current version if needed. It returns a
+// CorruptedPluginState error if the state can't be read or if there is no migration path from the version it was
+// persisted at.
+func (m *PluginStateMigrator) Get(reader PluginStateReader, t interface{}) error {
+	version := reader.GetStateVersion()
+	migration, found := m.migrations[version]
+	if version == m.version || (version == 0 && !found) {
+		if _, err := reader.Get(t); err != nil {
+			return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to read plugin state at version [%v].",
+				version)
+		}
+
+		return nil
+	}
+
+	if !found {
+		return errors.Errorf(errors.CorruptedPluginState, "No migration path from plugin state version [%v] to [%v].",
+			version, m.version)
+	}
+
+	state := migration.NewState()
+	if _, err := reader.Get(state); err != nil {
+		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to read plugin state at version [%v].", version)
+	}
+
+	for v := version; v < m.version; v++ {
+		migration, found = m.migrations[v]
+		if !found {
+			return errors.Errorf(errors.CorruptedPluginState,
+				"No migration path from plugin state version [%v] to [%v]. Missing a migration from version [%v].",
+				version, m.version, v)
+		}
+
+		var err error
+		if state, err = migration.Migrate(state); err != nil {
+			return errors.Wrapf(errors.CorruptedPluginState, err,
+				"Failed to migrate plugin state from version [%v] to [%v].", v, v+1)
+		}
+	}
+
+	return assignState(t, state)
+}
+
+// assignState sets the value t points to to the migrated state, which may be either a value or a pointer.
+func assignState(t, state interface{}) error {
+	target := reflect.ValueOf(t)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return errors.Errorf(errors.CorruptedPluginState, "Expected a non-nil pointer to read the plugin state into, "+
+			"got [%T].", t)
+	}
+
+	value := reflect.ValueOf(state)
+	if value.IsValid() && value.Kind() == reflect.Ptr && !value.Type().AssignableTo(target.Elem().Type()) {
+		if value.IsNil() {
+			return errors.Errorf(errors.CorruptedPluginState, "Migrated plugin state is nil.")
+		}
+
+		value = value.Elem()
+	}
+
+	if !value.IsValid() || !value.Type().AssignableTo(target.Elem().Type()) {
+		return errors.Errorf(errors.CorruptedPluginState, "Migrated plugin state of type [%T] can't be read into [%T].",
+			state, t)
+	}
+
+	target.Elem().Set(value)
+	return nil
+}
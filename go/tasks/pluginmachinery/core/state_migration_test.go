@@ -0,0 +1,153 @@
+package core
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+)
+
+// gobStateReader reads state encoded the same way the engine persists it.
+type gobStateReader struct {
+	version uint8
+	state   []byte
+}
+
+func (r gobStateReader) GetStateVersion() uint8 {
+	return r.version
+}
+
+func (r gobStateReader) Get(t interface{}) (uint8, error) {
+	if r.state == nil {
+		return r.version, nil
+	}
+
+	return r.version, gob.NewDecoder(bytes.NewReader(r.state)).Decode(t)
+}
+
+func newGobStateReader(t *testing.T, version uint8, state interface{}) gobStateReader {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, gob.NewEncoder(buf).Encode(state))
+	return gobStateReader{version: version, state: buf.Bytes()}
+}
+
+type stateV0 struct {
+	Phase int
+}
+
+type stateV1 struct {
+	Phase   int
+	Retries int
+}
+
+type stateV2 struct {
+	PhaseName string
+	Retries   int
+}
+
+func newTestMigrator() *PluginStateMigrator {
+	return NewPluginStateMigrator(2).
+		RegisterMigration(0, PluginStateMigration{
+			NewState: func() interface{} { return &stateV0{} },
+			Migrate: func(state interface{}) (interface{}, error) {
+				return &stateV1{Phase: state.(*stateV0).Phase}, nil
+			},
+		}).
+		RegisterMigration(1, PluginStateMigration{
+			NewState: func() interface{} { return &stateV1{} },
+			Migrate: func(state interface{}) (interface{}, error) {
+				s := state.(*stateV1)
+				if s.Phase < 0 {
+					return nil, fmt.Errorf("invalid phase [%v]", s.Phase)
+				}
+
+				return stateV2{PhaseName: fmt.Sprintf("phase-%v", s.Phase), Retries: s.Retries}, nil
+			},
+		})
+}
+
+func assertCorrupted(t *testing.T, err error) {
+	assert.Error(t, err)
+	code, found := stdErrors.GetErrorCode(err)
+	assert.True(t, found)
+	assert.Equal(t, errors.CorruptedPluginState, code)
+}
+
+func TestPluginStateMigrator_Get(t *testing.T) {
+	m := newTestMigrator()
+	assert.Equal(t, uint8(2), m.Version())
+
+	t.Run("Current version", func(t *testing.T) {
+		s := stateV2{}
+		assert.NoError(t, m.Get(newGobStateReader(t, 2, stateV2{PhaseName: "running", Retries: 1}), &s))
+		assert.Equal(t, stateV2{PhaseName: "running", Retries: 1}, s)
+	})
+
+	t.Run("Migrated from previous version", func(t *testing.T) {
+		s := stateV2{}
+		assert.NoError(t, m.Get(newGobStateReader(t, 1, stateV1{Phase: 3, Retries: 2}), &s))
+		assert.Equal(t, stateV2{PhaseName: "phase-3", Retries: 2}, s)
+	})
+
+	t.Run("Migrated through all versions", func(t *testing.T) {
+		s := stateV2{}
+		assert.NoError(t, m.Get(newGobStateReader(t, 0, stateV0{Phase: 4}), &s))
+		assert.Equal(t, stateV2{PhaseName: "phase-4"}, s)
+	})
+
+	t.Run("Failed migration", func(t *testing.T) {
+		s := stateV2{}
+		assertCorrupted(t, m.Get(newGobStateReader(t, 1, stateV1{Phase: -1}), &s))
+	})
+
+	t.Run("Unknown version", func(t *testing.T) {
+		s := stateV2{}
+		assertCorrupted(t, m.Get(newGobStateReader(t, 3, stateV2{}), &s))
+	})
+
+	t.Run("Undecodable state", func(t *testing.T) {
+		s := stateV2{}
+		assertCorrupted(t, m.Get(gobStateReader{version: 2, state: []byte("not gob")}, &s))
+	})
+
+	t.Run("Mismatched migrated state", func(t *testing.T) {
+		s := stateV1{}
+		assertCorrupted(t, m.Get(newGobStateReader(t, 1, stateV1{Phase: 3}), &s))
+	})
+}
+
+func TestPluginStateMigrator_Get_NoMigrations(t *testing.T) {
+	m := NewPluginStateMigrator(1)
+
+	t.Run("No state", func(t *testing.T) {
+		s := stateV1{}
+		assert.NoError(t, m.Get(gobStateReader{}, &s))
+		assert.Equal(t, stateV1{}, s)
+	})
+
+	t.Run("Version without migration path", func(t *testing.T) {
+		m := NewPluginStateMigrator(3).RegisterMigration(2, PluginStateMigration{
+			NewState: func() interface{} { return &stateV1{} },
+			Migrate:  func(state interface{}) (interface{}, error) { return state, nil },
+		})
+
+		s := stateV1{}
+		assertCorrupted(t, m.Get(newGobStateReader(t, 1, stateV1{}), &s))
+	})
+}
+
+func TestPluginStateMigrator_RegisterMigration(t *testing.T) {
+	migration := PluginStateMigration{
+		NewState: func() interface{} { return &stateV0{} },
+		Migrate:  func(state interface{}) (interface{}, error) { return state, nil },
+	}
+
+	assert.Panics(t, func() { NewPluginStateMigrator(1).RegisterMigration(1, migration) })
+	assert.Panics(t, func() { NewPluginStateMigrator(1).RegisterMigration(0, migration).RegisterMigration(0, migration) })
+	assert.Panics(t, func() { NewPluginStateMigrator(1).RegisterMigration(0, PluginStateMigration{}) })
+}
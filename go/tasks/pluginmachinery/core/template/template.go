@@ -87,6 +87,18 @@ var rawOutputDataPrefixRegex = regexp.MustCompile(`(?i){{\s*[\.$]RawOutputDataPr
 var perRetryUniqueKey = regexp.MustCompile(`(?i){{\s*[\.$]PerRetryUniqueKey\s*}}`)
 var taskTemplateRegex = regexp.MustCompile(`(?i){{\s*[\.$]TaskTemplatePath\s*}}`)
 
+// InputNames returns the names of the inputs referenced by the template, e.g. x for {{ .Inputs.x }}, in order of
+// appearance.
+func InputNames(inputTemplate string) []string {
+	matches := inputVarRegex.FindAllStringSubmatch(inputTemplate, -1)
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match[1])
+	}
+
+	return names
+}
+
 func render(ctx context.Context, inputTemplate string, params Parameters, perRetryKey string) (string, error) {
 
 	val := inputFileRegex.ReplaceAllString(inputTemplate, params.Inputs.GetInputPath().String())
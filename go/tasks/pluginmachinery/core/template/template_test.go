@@ -527,6 +527,11 @@ func TestInputRegexMatch(t *testing.T) {
 	assert.False(t, inputFileRegex.MatchString("{$input}}"), "Missing Brace")
 }
 
+func TestInputNames(t *testing.T) {
+	assert.Equal(t, []string{"x", "y"}, InputNames(`{"a": {{ .Inputs.x }}, "b": "{{$inputs.y}}", "c": "{{ .Input }}"}`))
+	assert.Empty(t, InputNames("{{ .OutputPrefix }}"))
+}
+
 func TestOutputRegexMatch(t *testing.T) {
 	assert.True(t, outputRegex.MatchString("{{.OutputPrefix}}"))
 	assert.True(t, outputRegex.MatchString("{{ .OutputPrefix }}"))
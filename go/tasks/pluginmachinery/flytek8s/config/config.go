@@ -40,6 +40,15 @@ var (
 		},
 		DefaultCPURequest:    defaultCPURequest,
 		DefaultMemoryRequest: defaultMemoryRequest,
+		SecretInjection: SecretInjectionConfig{
+			DefaultMountType: SecretMountTypeFile,
+			MountPath:        "/etc/flyte/secrets",
+			EnvVarPrefix:     "_FSEC_",
+		},
+		FatalPodEventMinCount: 3,
+		FatalPodEventGracePeriod: config2.Duration{
+			Duration: time.Minute * 2,
+		},
 	}
 
 	// K8sPluginConfigSection provides a singular top level config section for all plugins.
@@ -81,6 +90,14 @@ type K8sPluginConfig struct {
 	// Default scheduler that should be used for all pods or CRD that accept Scheduler name.
 	SchedulerName string `json:"scheduler-name" pflag:",Defines scheduler name."`
 
+	// Name of the PodTemplate, looked up in the namespace of the task, that pods launched by Flyte start from. The
+	// primary container generated by Flyte is merged onto the container named 'default' of the template. The framework
+	// must watch PodTemplates, see flytek8s.WatchPodTemplates, for the template to be found.
+	DefaultPodTemplateName string `json:"default-pod-template-name" pflag:",Name of the PodTemplate, in the namespace of the task, that pods launched by Flyte start from."`
+	// PodTemplate that pods launched by Flyte start from if no template named DefaultPodTemplateName exists in the
+	// namespace of the task.
+	DefaultPodTemplate *v1.PodTemplate `json:"default-pod-template,omitempty" pflag:"-,PodTemplate that pods launched by Flyte start from if the named one doesn't exist."`
+
 	// -----------------------------------------------------------------
 	// Special tolerations and node selector for Interruptible tasks. This allows scheduling interruptible tasks onto specific hardward
 
@@ -100,6 +117,30 @@ type K8sPluginConfig struct {
 
 	// Flyte CoPilot Configuration
 	CoPilot FlyteCoPilotConfig `json:"co-pilot" pflag:",Co-Pilot Configuration"`
+
+	// Injection of the secrets requested by tasks
+	SecretInjection SecretInjectionConfig `json:"secret-injection" pflag:",Configures how the secrets requested by tasks are injected into their pods."`
+
+	// Warning events known to be fatal, e.g. a secret volume that doesn't exist, can be transient, e.g. if the secret is
+	// created right after the pod. A pending pod only fails on such an event once the event has been reported
+	// FatalPodEventMinCount times, or once the event is still reported FatalPodEventGracePeriod after the pod was created.
+	FatalPodEventMinCount    int32            `json:"fatal-pod-event-min-count" pflag:",Number of times a warning event known to be fatal must be reported before a pending pod fails."`
+	FatalPodEventGracePeriod config2.Duration `json:"fatal-pod-event-grace-period" pflag:",How long after its creation a pending pod may report a warning event known to be fatal before it fails."`
+}
+
+const (
+	SecretMountTypeFile   = "file"
+	SecretMountTypeEnvVar = "env-var"
+)
+
+// SecretInjectionConfig configures how the secrets requested in the security context of tasks are injected into their
+// pods. A secret is read from the Kubernetes Secret named after its group, in the namespace of the task, under its key
+// (suffixed with .<version> if versioned).
+type SecretInjectionConfig struct {
+	Enabled          bool   `json:"enabled" pflag:",Injects the secrets requested by tasks into their pods. Secrets are left to other injection mechanisms (e.g. a webhook) if disabled."`
+	DefaultMountType string `json:"default-mount-type" pflag:",How secrets are injected if the task doesn't require a mount type: file or env-var."`
+	MountPath        string `json:"mount-path" pflag:",Directory secrets are mounted in. A secret is mounted at <mount-path>/<group>/<key>."`
+	EnvVarPrefix     string `json:"env-var-prefix" pflag:",Prefix of the environment variables secrets are exposed as. A secret is exposed as <prefix><GROUP>_<KEY>."`
 }
 
 type FlyteCoPilotConfig struct {
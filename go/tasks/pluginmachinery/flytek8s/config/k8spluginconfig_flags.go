@@ -45,6 +45,7 @@ func (cfg K8sPluginConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "default-cpus"), defaultK8sConfig.DefaultCPURequest, "Defines a default value for cpu for containers if not specified.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "default-memory"), defaultK8sConfig.DefaultMemoryRequest, "Defines a default value for memory for containers if not specified.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "scheduler-name"), defaultK8sConfig.SchedulerName, "Defines scheduler name.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "default-pod-template-name"), defaultK8sConfig.DefaultPodTemplateName, "Name of the PodTemplate,  in the namespace of the task,  that pods launched by Flyte start from.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "co-pilot.name"), defaultK8sConfig.CoPilot.NamePrefix, "Flyte co-pilot sidecar container name prefix. (additional bits will be added after this)")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "co-pilot.image"), defaultK8sConfig.CoPilot.Image, "Flyte co-pilot Docker Image FQN")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "co-pilot.default-input-path"), defaultK8sConfig.CoPilot.DefaultInputDataPath, "Default path where the volume should be mounted")
@@ -54,5 +55,11 @@ func (cfg K8sPluginConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "co-pilot.cpu"), defaultK8sConfig.CoPilot.CPU, "Used to set cpu for co-pilot containers")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "co-pilot.memory"), defaultK8sConfig.CoPilot.Memory, "Used to set memory for co-pilot containers")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "co-pilot.storage"), defaultK8sConfig.CoPilot.Storage, "Default storage limit for individual inputs / outputs")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "secret-injection.enabled"), defaultK8sConfig.SecretInjection.Enabled, "Injects the secrets requested by tasks into their pods. Secrets are left to other injection mechanisms (e.g. a webhook) if disabled.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "secret-injection.default-mount-type"), defaultK8sConfig.SecretInjection.DefaultMountType, "How secrets are injected if the task doesn't require a mount type: file or env-var.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "secret-injection.mount-path"), defaultK8sConfig.SecretInjection.MountPath, "Directory secrets are mounted in. A secret is mounted at <mount-path>/<group>/<key>.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "secret-injection.env-var-prefix"), defaultK8sConfig.SecretInjection.EnvVarPrefix, "Prefix of the environment variables secrets are exposed as. A secret is exposed as <prefix><GROUP>_<KEY>.")
+	cmdFlags.Int32(fmt.Sprintf("%v%v", prefix, "fatal-pod-event-min-count"), defaultK8sConfig.FatalPodEventMinCount, "Number of times a warning event known to be fatal must be reported before a pending pod fails.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "fatal-pod-event-grace-period"), defaultK8sConfig.FatalPodEventGracePeriod.String(), "How long after its creation a pending pod may report a warning event known to be fatal before it fails.")
 	return cmdFlags
 }
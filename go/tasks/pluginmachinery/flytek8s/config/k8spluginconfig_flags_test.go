@@ -187,6 +187,28 @@ func TestK8sPluginConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_default-pod-template-name", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("default-pod-template-name"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.DefaultPodTemplateName), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("default-pod-template-name", testValue)
+			if vString, err := cmdFlags.GetString("default-pod-template-name"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.DefaultPodTemplateName)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_co-pilot.name", func(t *testing.T) {
 		t.Run("DefaultValue", func(t *testing.T) {
 			// Test that default value is set properly
@@ -385,4 +407,136 @@ func TestK8sPluginConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_secret-injection.enabled", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("secret-injection.enabled"); err == nil {
+				assert.Equal(t, bool(defaultK8sConfig.SecretInjection.Enabled), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("secret-injection.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("secret-injection.enabled"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vBool), &actual.SecretInjection.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_secret-injection.default-mount-type", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("secret-injection.default-mount-type"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.SecretInjection.DefaultMountType), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("secret-injection.default-mount-type", testValue)
+			if vString, err := cmdFlags.GetString("secret-injection.default-mount-type"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.SecretInjection.DefaultMountType)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_secret-injection.mount-path", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("secret-injection.mount-path"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.SecretInjection.MountPath), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("secret-injection.mount-path", testValue)
+			if vString, err := cmdFlags.GetString("secret-injection.mount-path"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.SecretInjection.MountPath)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_secret-injection.env-var-prefix", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("secret-injection.env-var-prefix"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.SecretInjection.EnvVarPrefix), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("secret-injection.env-var-prefix", testValue)
+			if vString, err := cmdFlags.GetString("secret-injection.env-var-prefix"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.SecretInjection.EnvVarPrefix)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_fatal-pod-event-min-count", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt32, err := cmdFlags.GetInt32("fatal-pod-event-min-count"); err == nil {
+				assert.Equal(t, int32(defaultK8sConfig.FatalPodEventMinCount), vInt32)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("fatal-pod-event-min-count", testValue)
+			if vInt32, err := cmdFlags.GetInt32("fatal-pod-event-min-count"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vInt32), &actual.FatalPodEventMinCount)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_fatal-pod-event-grace-period", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("fatal-pod-event-grace-period"); err == nil {
+				assert.Equal(t, string(defaultK8sConfig.FatalPodEventGracePeriod.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultK8sConfig.FatalPodEventGracePeriod.String()
+
+			cmdFlags.Set("fatal-pod-event-grace-period", testValue)
+			if vString, err := cmdFlags.GetString("fatal-pod-event-grace-period"); err == nil {
+				testDecodeJson_K8sPluginConfig(t, fmt.Sprintf("%v", vString), &actual.FatalPodEventGracePeriod)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -82,6 +82,19 @@ func ApplyResourceOverrides(ctx context.Context, resources v1.ResourceRequiremen
 }
 
 // Returns a K8s Container for the execution
+// ValidateContainerTask checks that the task template defines the container the pod of the task is built from.
+func ValidateContainerTask(task *core.TaskTemplate) error {
+	if task.GetContainer() == nil {
+		return errors.Errorf(errors.BadTaskSpecification, "container not specified in task template")
+	}
+
+	if len(task.GetContainer().GetImage()) == 0 {
+		return errors.Errorf(errors.BadTaskSpecification, "container image not specified in task template")
+	}
+
+	return nil
+}
+
 func ToK8sContainer(ctx context.Context, taskContainer *core.Container, iFace *core.TypedInterface, parameters template.Parameters) (*v1.Container, error) {
 	modifiedCommand, err := template.Render(ctx, taskContainer.GetCommand(), parameters)
 	if err != nil {
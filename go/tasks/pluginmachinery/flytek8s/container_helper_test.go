@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -123,3 +124,13 @@ func TestApplyResourceOverrides_OverrideGpu(t *testing.T) {
 	})
 	assert.EqualValues(t, gpuRequest, overrides.Limits[ResourceNvidiaGPU])
 }
+
+func TestValidateContainerTask(t *testing.T) {
+	assert.NoError(t, ValidateContainerTask(&core.TaskTemplate{
+		Target: &core.TaskTemplate_Container{Container: &core.Container{Image: "image"}},
+	}))
+	assert.Error(t, ValidateContainerTask(&core.TaskTemplate{}))
+	assert.Error(t, ValidateContainerTask(&core.TaskTemplate{
+		Target: &core.TaskTemplate_Container{Container: &core.Container{}},
+	}))
+}
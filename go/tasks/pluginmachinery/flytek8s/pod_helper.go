@@ -3,9 +3,11 @@ package flytek8s
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
@@ -13,9 +15,11 @@ import (
 	"github.com/flyteorg/flytestdlib/logger"
 	v1 "k8s.io/api/core/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 )
 
 const PodKind = "pod"
@@ -23,9 +27,10 @@ const OOMKilled = "OOMKilled"
 const Interrupted = "Interrupted"
 const SIGKILL = 137
 
-// Updates the base pod spec used to execute tasks. This is configured with plugins and task metadata-specific options
-func UpdatePod(taskExecutionMetadata pluginsCore.TaskExecutionMetadata,
-	resourceRequirements []v1.ResourceRequirements, podSpec *v1.PodSpec) {
+// Updates the base pod spec used to execute tasks. This is configured with plugins and task metadata-specific options,
+// including the secrets requested by the task. The secrets are checked to exist with the reader, unless it's nil.
+func UpdatePod(ctx context.Context, reader client.Reader, taskExecutionMetadata pluginsCore.TaskExecutionMetadata,
+	resourceRequirements []v1.ResourceRequirements, podSpec *v1.PodSpec) error {
 	if len(podSpec.RestartPolicy) == 0 {
 		podSpec.RestartPolicy = v1.RestartPolicyNever
 	}
@@ -44,6 +49,13 @@ func UpdatePod(taskExecutionMetadata pluginsCore.TaskExecutionMetadata,
 	if podSpec.Affinity == nil {
 		podSpec.Affinity = config.GetK8sPluginConfig().DefaultAffinity
 	}
+
+	if cfg := config.GetK8sPluginConfig().SecretInjection; cfg.Enabled {
+		return InjectSecrets(ctx, cfg, reader, taskExecutionMetadata.GetNamespace(),
+			taskExecutionMetadata.GetSecurityContext(), podSpec)
+	}
+
+	return nil
 }
 
 func ToK8sPodSpec(ctx context.Context, tCtx pluginsCore.TaskExecutionContext) (*v1.PodSpec, error) {
@@ -72,13 +84,16 @@ func ToK8sPodSpec(ctx context.Context, tCtx pluginsCore.TaskExecutionContext) (*
 	pod := &v1.PodSpec{
 		Containers: containers,
 	}
-	UpdatePod(tCtx.TaskExecutionMetadata(), []v1.ResourceRequirements{c.Resources}, pod)
+	if err := UpdatePod(ctx, pluginsCore.GetKubeReader(tCtx), tCtx.TaskExecutionMetadata(),
+		[]v1.ResourceRequirements{c.Resources}, pod); err != nil {
+		return nil, err
+	}
 
 	if err := AddCoPilotToPod(ctx, config.GetK8sPluginConfig().CoPilot, pod, task.GetInterface(), tCtx.TaskExecutionMetadata(), tCtx.InputReader(), tCtx.OutputWriter(), task.GetContainer().GetDataConfig()); err != nil {
 		return nil, err
 	}
 
-	return pod, nil
+	return ApplyBasePodTemplate(ctx, tCtx.TaskExecutionMetadata().GetNamespace(), c.Name, pod)
 }
 
 func BuildPodWithSpec(podSpec *v1.PodSpec) *v1.Pod {
@@ -213,6 +228,91 @@ func DemystifyPending(status v1.PodStatus) (pluginsCore.PhaseInfo, error) {
 	return pluginsCore.PhaseInfoQueued(time.Now(), pluginsCore.DefaultPhaseVersion, "Scheduling"), nil
 }
 
+// fatalPodEvents matches the messages of the warning events, indexed by reason, after which a pod will never start.
+var fatalPodEvents = map[string]*regexp.Regexp{
+	// e.g. MountVolume.SetUp failed for volume "flyte-secrets-0" : secret "my-group" not found
+	"FailedMount": regexp.MustCompile(`(secret|configmap) "[^"]*" not found`),
+}
+
+// isFatalPodEvent returns whether the event is known to be fatal and has persisted, i.e. it was reported at least
+// FatalPodEventMinCount times or still reported FatalPodEventGracePeriod after the pod was created.
+func isFatalPodEvent(e v1.Event, createdAt time.Time) bool {
+	pattern, found := fatalPodEvents[e.Reason]
+	if !found || !pattern.MatchString(e.Message) {
+		return false
+	}
+
+	cfg := config.GetK8sPluginConfig()
+	if e.Count >= cfg.FatalPodEventMinCount {
+		return true
+	}
+
+	return !createdAt.IsZero() && eventTime(e).Sub(createdAt) >= cfg.FatalPodEventGracePeriod.Duration
+}
+
+// eventTime returns when the event was last observed.
+func eventTime(e v1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.FirstTimestamp.Time
+	}
+}
+
+// DemystifyPendingWithEvents behaves like DemystifyPending but also relies on the recent events of the pod (see
+// k8s.GetRecentEvents). The pod fails if a warning event is known to be fatal, e.g. a secret volume that doesn't exist,
+// and has persisted (see isFatalPodEvent). Otherwise, the most recent warning event is appended to the reason the pod
+// is pending.
+func DemystifyPendingWithEvents(status v1.PodStatus, createdAt time.Time, events []v1.Event) (pluginsCore.PhaseInfo, error) {
+	var latest *v1.Event
+	for i, e := range events {
+		if e.Type != v1.EventTypeWarning {
+			continue
+		}
+
+		if isFatalPodEvent(e, createdAt) {
+			t := eventTime(e)
+			return pluginsCore.PhaseInfoFailure(string(errors.BadTaskSpecification),
+				fmt.Sprintf("%s: %s", e.Reason, e.Message), &pluginsCore.TaskInfo{OccurredAt: &t}), nil
+		}
+
+		if latest == nil || eventTime(e).After(eventTime(*latest)) {
+			latest = &events[i]
+		}
+	}
+
+	phaseInfo, err := DemystifyPending(status)
+	if err != nil || latest == nil {
+		return phaseInfo, err
+	}
+
+	reason := fmt.Sprintf("%s [%s: %s]", phaseInfo.Reason(), latest.Reason, latest.Message)
+	switch phaseInfo.Phase() {
+	case pluginsCore.PhaseQueued:
+		return pluginsCore.PhaseInfoQueued(*phaseInfo.Info().OccurredAt, phaseInfo.Version(), reason), nil
+	case pluginsCore.PhaseInitializing:
+		return pluginsCore.PhaseInfoInitializing(*phaseInfo.Info().OccurredAt, phaseInfo.Version(), reason,
+			phaseInfo.Info()), nil
+	}
+
+	return phaseInfo, nil
+}
+
+// DemystifyPendingPod demystifies the pending pod with DemystifyPendingWithEvents, relying on the recent events of the pod
+// if the PluginContext provides them.
+func DemystifyPendingPod(ctx context.Context, pluginContext k8s.PluginContext, pod *v1.Pod) (pluginsCore.PhaseInfo, error) {
+	events, err := k8s.GetRecentEvents(ctx, pluginContext, pod)
+	if err != nil {
+		// Events only refine the diagnostic, the pod can still be demystified without them.
+		logger.Warnf(ctx, "Failed to read the events of pod [%v]. Error: %v", pod.GetName(), err)
+	}
+
+	return DemystifyPendingWithEvents(pod.Status, pod.CreationTimestamp.Time, events)
+}
+
 func DemystifySuccess(status v1.PodStatus, info pluginsCore.TaskInfo) (pluginsCore.PhaseInfo, error) {
 	for _, status := range append(
 		append(status.InitContainerStatuses, status.ContainerStatuses...), status.EphemeralContainerStatuses...) {
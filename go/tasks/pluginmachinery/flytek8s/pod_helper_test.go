@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"testing"
+	"time"
 
 	config1 "github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/config/viper"
@@ -22,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 	pluginsIOMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
@@ -130,7 +132,7 @@ func updatePod(t *testing.T) {
 			},
 		},
 	}
-	UpdatePod(taskExecutionMetadata, []v1.ResourceRequirements{}, &pod.Spec)
+	assert.NoError(t, UpdatePod(context.TODO(), nil, taskExecutionMetadata, []v1.ResourceRequirements{}, &pod.Spec))
 	assert.Equal(t, v1.RestartPolicyNever, pod.Spec.RestartPolicy)
 	for _, tol := range pod.Spec.Tolerations {
 		if tol.Key == "x/flyte" {
@@ -578,6 +580,112 @@ func TestConvertPodFailureToError(t *testing.T) {
 	})
 }
 
+func TestDemystifyPendingWithEvents(t *testing.T) {
+	now := time.Now()
+	containerCreating := v1.PodStatus{
+		Phase: v1.PodPending,
+		Conditions: []v1.PodCondition{
+			{
+				Type:    v1.PodReady,
+				Status:  v1.ConditionFalse,
+				Reason:  "ContainersNotReady",
+				Message: "containers with unready status: [main]",
+			},
+		},
+		ContainerStatuses: []v1.ContainerStatus{
+			{
+				Ready: false,
+				State: v1.ContainerState{
+					Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"},
+				},
+			},
+		},
+	}
+
+	newEvent := func(eventType, reason, message string, at time.Time) v1.Event {
+		return v1.Event{Type: eventType, Reason: reason, Message: message, LastTimestamp: metaV1.NewTime(at)}
+	}
+
+	t.Run("No events", func(t *testing.T) {
+		withEvents, err := DemystifyPendingWithEvents(containerCreating, now, nil)
+		assert.NoError(t, err)
+		withoutEvents, err := DemystifyPending(containerCreating)
+		assert.NoError(t, err)
+		assert.Equal(t, withoutEvents, withEvents)
+	})
+
+	t.Run("Latest warning", func(t *testing.T) {
+		taskStatus, err := DemystifyPendingWithEvents(containerCreating, now, []v1.Event{
+			newEvent(v1.EventTypeWarning, "FailedAttachVolume", "attach failed", now.Add(-time.Minute)),
+			newEvent(v1.EventTypeWarning, "FailedMount", "timed out waiting for the condition", now),
+			newEvent(v1.EventTypeNormal, "Pulling", "Pulling image", now.Add(time.Minute)),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseInitializing, taskStatus.Phase())
+		assert.Contains(t, taskStatus.Reason(), "[FailedMount: timed out waiting for the condition]")
+	})
+
+	t.Run("Unschedulable", func(t *testing.T) {
+		s := v1.PodStatus{
+			Phase: v1.PodPending,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: "Unschedulable"},
+			},
+		}
+
+		taskStatus, err := DemystifyPendingWithEvents(s, now, []v1.Event{
+			newEvent(v1.EventTypeWarning, "FailedScheduling", "0/3 nodes are available: 3 Insufficient cpu.", now),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseQueued, taskStatus.Phase())
+		assert.Contains(t, taskStatus.Reason(), "3 Insufficient cpu.")
+	})
+
+	t.Run("Missing secret", func(t *testing.T) {
+		previous := *config.GetK8sPluginConfig()
+		defer func() { assert.NoError(t, config.SetK8sPluginConfig(&previous)) }()
+		assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+			FatalPodEventMinCount:    3,
+			FatalPodEventGracePeriod: config1.Duration{Duration: time.Minute},
+		}))
+
+		message := `MountVolume.SetUp failed for volume "flyte-secrets-0" : secret "my-group" not found`
+
+		// The secret may still be created.
+		taskStatus, err := DemystifyPendingWithEvents(containerCreating, now, []v1.Event{
+			newEvent(v1.EventTypeWarning, "FailedMount", message, now.Add(time.Second)),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhaseInitializing, taskStatus.Phase())
+		assert.Contains(t, taskStatus.Reason(), message)
+
+		t.Run("Repeated", func(t *testing.T) {
+			e := newEvent(v1.EventTypeWarning, "FailedMount", message, now.Add(time.Second))
+			e.Count = 3
+			taskStatus, err := DemystifyPendingWithEvents(containerCreating, now, []v1.Event{e})
+
+			assert.NoError(t, err)
+			assert.Equal(t, pluginsCore.PhasePermanentFailure, taskStatus.Phase())
+			assert.Equal(t, string(errors.BadTaskSpecification), taskStatus.Err().GetCode())
+			assert.Equal(t, "FailedMount: "+message, taskStatus.Err().GetMessage())
+		})
+
+		t.Run("Past the grace period", func(t *testing.T) {
+			createdAt := now.Add(-time.Minute)
+			taskStatus, err := DemystifyPendingWithEvents(containerCreating, createdAt, []v1.Event{
+				newEvent(v1.EventTypeWarning, "FailedMount", message, now),
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, pluginsCore.PhasePermanentFailure, taskStatus.Phase())
+			assert.Equal(t, string(errors.BadTaskSpecification), taskStatus.Err().GetCode())
+		})
+	})
+}
+
 func TestDemystifyPending_testcases(t *testing.T) {
 
 	tests := []struct {
@@ -0,0 +1,206 @@
+package flytek8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/tools/cache"
+	ctrlCache "sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+// DefaultPodTemplateContainerName is the name of the container, in a base PodTemplate, that the primary container
+// generated by Flyte is merged onto unless the template defines a container with the same name.
+const DefaultPodTemplateContainerName = "default"
+
+// DefaultPodTemplateStore holds the PodTemplates that pods launched by Flyte start from. Nothing in the plugins feeds
+// it: the framework must call WatchPodTemplates at setup, otherwise the PodTemplate named in config is never found.
+var DefaultPodTemplateStore = NewPodTemplateStore()
+
+// PodTemplateStore is a thread-safe cache of PodTemplates, keyed by namespace and name. It implements
+// cache.ResourceEventHandler so that it can be fed by an informer.
+type PodTemplateStore struct {
+	templates sync.Map
+}
+
+// Sanity test that the store can be registered with an informer
+var _ cache.ResourceEventHandler = &PodTemplateStore{}
+
+func NewPodTemplateStore() *PodTemplateStore {
+	return &PodTemplateStore{}
+}
+
+func podTemplateKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// Load returns the PodTemplate with the given name in the given namespace, or nil if there is none.
+func (p *PodTemplateStore) Load(namespace, name string) *v1.PodTemplate {
+	if podTemplate, ok := p.templates.Load(podTemplateKey(namespace, name)); ok {
+		return podTemplate.(*v1.PodTemplate)
+	}
+
+	return nil
+}
+
+// Store adds or replaces a PodTemplate.
+func (p *PodTemplateStore) Store(podTemplate *v1.PodTemplate) {
+	p.templates.Store(podTemplateKey(podTemplate.Namespace, podTemplate.Name), podTemplate)
+}
+
+// Delete removes a PodTemplate.
+func (p *PodTemplateStore) Delete(podTemplate *v1.PodTemplate) {
+	p.templates.Delete(podTemplateKey(podTemplate.Namespace, podTemplate.Name))
+}
+
+func (p *PodTemplateStore) OnAdd(obj interface{}) {
+	if podTemplate, ok := obj.(*v1.PodTemplate); ok {
+		p.Store(podTemplate)
+	}
+}
+
+func (p *PodTemplateStore) OnUpdate(_, newObj interface{}) {
+	p.OnAdd(newObj)
+}
+
+func (p *PodTemplateStore) OnDelete(obj interface{}) {
+	// The informer hands over the last known state of the object if it missed the delete event.
+	if deleted, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = deleted.Obj
+	}
+
+	if podTemplate, ok := obj.(*v1.PodTemplate); ok {
+		p.Delete(podTemplate)
+	}
+}
+
+// WatchPodTemplates registers DefaultPodTemplateStore as the event handler of the PodTemplate informer of the given
+// cache, e.g. the one of the KubeClient of the plugins' SetupContext. It does nothing if no PodTemplate is named in
+// config, so that Flyte doesn't need to watch PodTemplates unless it uses them.
+func WatchPodTemplates(ctx context.Context, kubeCache ctrlCache.Cache) error {
+	if len(config.GetK8sPluginConfig().DefaultPodTemplateName) == 0 {
+		return nil
+	}
+
+	informer, err := kubeCache.GetInformer(ctx, &v1.PodTemplate{})
+	if err != nil {
+		return fmt.Errorf("failed to get the PodTemplate informer: %w", err)
+	}
+
+	informer.AddEventHandler(DefaultPodTemplateStore)
+	return nil
+}
+
+// GetBasePodTemplate returns the PodTemplate that pods launched in the given namespace start from: the template named
+// in config if it exists in the namespace, the template defined inline in config otherwise. It returns nil if neither
+// is configured.
+func GetBasePodTemplate(ctx context.Context, namespace string) *v1.PodTemplate {
+	cfg := config.GetK8sPluginConfig()
+	if len(cfg.DefaultPodTemplateName) > 0 {
+		if podTemplate := DefaultPodTemplateStore.Load(namespace, cfg.DefaultPodTemplateName); podTemplate != nil {
+			return podTemplate
+		}
+
+		logger.Debugf(ctx, "PodTemplate [%v] not found in namespace [%v], using the PodTemplate defined in config instead, if any.",
+			cfg.DefaultPodTemplateName, namespace)
+	}
+
+	return cfg.DefaultPodTemplate
+}
+
+// ApplyBasePodTemplate merges the pod spec generated by Flyte onto the base PodTemplate of the namespace, if any. See
+// MergeWithBasePodTemplate.
+func ApplyBasePodTemplate(ctx context.Context, namespace, primaryContainerName string, podSpec *v1.PodSpec) (
+	*v1.PodSpec, error) {
+	podTemplate := GetBasePodTemplate(ctx, namespace)
+	if podTemplate == nil {
+		return podSpec, nil
+	}
+
+	logger.Debugf(ctx, "Merging the pod spec onto the base PodTemplate [%v/%v]", podTemplate.Namespace, podTemplate.Name)
+	return MergeWithBasePodTemplate(podSpec, podTemplate, primaryContainerName)
+}
+
+// MergeWithBasePodTemplate strategically merges the pod spec generated by Flyte onto the spec of a PodTemplate, the
+// values set by Flyte taking precedence. Each container generated by Flyte is merged onto the template container with
+// the same name. The primary container, if it has no such container, is merged onto the 'default' one; the other
+// generated containers (e.g. the copilot sidecars) are left as generated. The other containers of the template, except
+// 'default', are appended to the generated ones.
+func MergeWithBasePodTemplate(podSpec *v1.PodSpec, podTemplate *v1.PodTemplate, primaryContainerName string) (
+	*v1.PodSpec, error) {
+	if podTemplate == nil {
+		return podSpec, nil
+	}
+
+	baseSpec := podTemplate.Template.Spec.DeepCopy()
+	baseContainers := make(map[string]v1.Container, len(baseSpec.Containers))
+	for _, container := range baseSpec.Containers {
+		baseContainers[container.Name] = container
+	}
+
+	containers := make([]v1.Container, 0, len(podSpec.Containers)+len(baseSpec.Containers))
+	for _, container := range podSpec.Containers {
+		base, found := baseContainers[container.Name]
+		if !found && container.Name == primaryContainerName {
+			base, found = baseContainers[DefaultPodTemplateContainerName]
+		}
+
+		if found {
+			merged := v1.Container{}
+			if err := strategicMerge(base, container, &merged); err != nil {
+				return nil, fmt.Errorf("failed to merge container [%v] onto the base PodTemplate: %w", container.Name, err)
+			}
+
+			merged.Name = container.Name
+			container = merged
+		}
+
+		containers = append(containers, container)
+		delete(baseContainers, container.Name)
+	}
+
+	for _, container := range baseSpec.Containers {
+		if _, remaining := baseContainers[container.Name]; remaining && container.Name != DefaultPodTemplateContainerName {
+			containers = append(containers, container)
+		}
+	}
+
+	// Containers are merged above, so that the generated ones can be merged onto the default container of the template.
+	baseSpec.Containers = nil
+	patchSpec := podSpec.DeepCopy()
+	patchSpec.Containers = nil
+
+	merged := &v1.PodSpec{}
+	if err := strategicMerge(baseSpec, patchSpec, merged); err != nil {
+		return nil, fmt.Errorf("failed to merge the pod spec onto the base PodTemplate: %w", err)
+	}
+
+	merged.Containers = containers
+	return merged, nil
+}
+
+// strategicMerge applies patch onto base using the patch strategies of the type of out, and stores the result in out.
+func strategicMerge(base, patch, out interface{}) error {
+	baseJSON, err := json.Marshal(base)
+	if err != nil {
+		return err
+	}
+
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	mergedJSON, err := strategicpatch.StrategicMergePatch(baseJSON, patchJSON, out)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(mergedJSON, out)
+}
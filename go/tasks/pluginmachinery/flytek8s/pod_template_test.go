@@ -0,0 +1,205 @@
+package flytek8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+func dummyPodTemplate(namespace, name string) *v1.PodTemplate {
+	return &v1.PodTemplate{
+		ObjectMeta: metaV1.ObjectMeta{Namespace: namespace, Name: name},
+		Template: v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name:  DefaultPodTemplateContainerName,
+						Image: "template-image",
+						Env:   []v1.EnvVar{{Name: "FROM_TEMPLATE", Value: "template"}},
+						VolumeMounts: []v1.VolumeMount{
+							{Name: "cache", MountPath: "/cache"},
+						},
+					},
+					{
+						Name:  "proxy",
+						Image: "proxy-image",
+					},
+				},
+				Volumes:           []v1.Volume{{Name: "cache"}},
+				PriorityClassName: "flyte-tasks",
+				Tolerations:       []v1.Toleration{{Key: "template"}},
+			},
+		},
+	}
+}
+
+func TestPodTemplateStore(t *testing.T) {
+	store := NewPodTemplateStore()
+	podTemplate := dummyPodTemplate("test-namespace", "flyte-template")
+
+	store.OnAdd(podTemplate)
+	assert.Equal(t, podTemplate, store.Load("test-namespace", "flyte-template"))
+	assert.Nil(t, store.Load("other-namespace", "flyte-template"))
+
+	updated := podTemplate.DeepCopy()
+	updated.Template.Spec.PriorityClassName = "updated"
+	store.OnUpdate(podTemplate, updated)
+	assert.Equal(t, updated, store.Load("test-namespace", "flyte-template"))
+
+	store.OnDelete(updated)
+	assert.Nil(t, store.Load("test-namespace", "flyte-template"))
+
+	store.OnAdd(podTemplate)
+	store.OnDelete(cache.DeletedFinalStateUnknown{Key: "test-namespace/flyte-template", Obj: podTemplate})
+	assert.Nil(t, store.Load("test-namespace", "flyte-template"))
+
+	// Objects of other types are ignored
+	store.OnAdd(&v1.Pod{})
+}
+
+func TestWatchPodTemplates(t *testing.T) {
+	ctx := context.Background()
+	previous := *config.GetK8sPluginConfig()
+	defer func() { assert.NoError(t, config.SetK8sPluginConfig(&previous)) }()
+
+	t.Run("No PodTemplate named", func(t *testing.T) {
+		cfg := previous
+		cfg.DefaultPodTemplateName = ""
+		assert.NoError(t, config.SetK8sPluginConfig(&cfg))
+
+		kubeCache := &informertest.FakeInformers{}
+		assert.NoError(t, WatchPodTemplates(ctx, kubeCache))
+		assert.Empty(t, kubeCache.InformersByGVK)
+	})
+
+	t.Run("Feeds the default store", func(t *testing.T) {
+		cfg := previous
+		cfg.DefaultPodTemplateName = "watched-template"
+		assert.NoError(t, config.SetK8sPluginConfig(&cfg))
+
+		kubeCache := &informertest.FakeInformers{}
+		assert.NoError(t, WatchPodTemplates(ctx, kubeCache))
+		informer, err := kubeCache.FakeInformerFor(&v1.PodTemplate{})
+		assert.NoError(t, err)
+
+		podTemplate := dummyPodTemplate("ns", "watched-template")
+		informer.Add(podTemplate)
+		assert.Equal(t, podTemplate, DefaultPodTemplateStore.Load("ns", "watched-template"))
+
+		informer.Delete(podTemplate)
+		assert.Nil(t, DefaultPodTemplateStore.Load("ns", "watched-template"))
+	})
+}
+
+func TestMergeWithBasePodTemplate(t *testing.T) {
+	podSpec := &v1.PodSpec{
+		Containers: []v1.Container{
+			{
+				Name:  "primary",
+				Image: "flyte-image",
+				Env:   []v1.EnvVar{{Name: "FROM_FLYTE", Value: "flyte"}},
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+				},
+			},
+		},
+		ServiceAccountName: "service-account",
+		Tolerations:        []v1.Toleration{{Key: "flyte"}},
+	}
+
+	t.Run("nil template", func(t *testing.T) {
+		merged, err := MergeWithBasePodTemplate(podSpec, nil, "primary")
+		assert.NoError(t, err)
+		assert.Equal(t, podSpec, merged)
+	})
+
+	t.Run("default container", func(t *testing.T) {
+		merged, err := MergeWithBasePodTemplate(podSpec, dummyPodTemplate("test-namespace", "flyte-template"), "primary")
+		assert.NoError(t, err)
+
+		assert.Len(t, merged.Containers, 2)
+		primary := merged.Containers[0]
+		assert.Equal(t, "primary", primary.Name)
+		assert.Equal(t, "flyte-image", primary.Image)
+		assert.ElementsMatch(t, []v1.EnvVar{
+			{Name: "FROM_TEMPLATE", Value: "template"},
+			{Name: "FROM_FLYTE", Value: "flyte"},
+		}, primary.Env)
+		assert.Equal(t, []v1.VolumeMount{{Name: "cache", MountPath: "/cache"}}, primary.VolumeMounts)
+		assert.Equal(t, "1", primary.Resources.Limits.Cpu().String())
+		assert.Equal(t, "proxy", merged.Containers[1].Name)
+
+		assert.Equal(t, []v1.Volume{{Name: "cache"}}, merged.Volumes)
+		assert.Equal(t, "flyte-tasks", merged.PriorityClassName)
+		assert.Equal(t, "service-account", merged.ServiceAccountName)
+		// Tolerations have no merge key, the ones generated by Flyte replace the ones of the template.
+		assert.Equal(t, []v1.Toleration{{Key: "flyte"}}, merged.Tolerations)
+
+		// The generated pod spec is left untouched
+		assert.Len(t, podSpec.Containers[0].Env, 1)
+	})
+
+	t.Run("sidecar containers", func(t *testing.T) {
+		withSidecar := podSpec.DeepCopy()
+		withSidecar.Containers = append(withSidecar.Containers, v1.Container{Name: "flyte-copilot-sidecar", Image: "copilot"})
+
+		merged, err := MergeWithBasePodTemplate(withSidecar, dummyPodTemplate("test-namespace", "flyte-template"), "primary")
+		assert.NoError(t, err)
+		assert.Len(t, merged.Containers, 3)
+		assert.Equal(t, []v1.VolumeMount{{Name: "cache", MountPath: "/cache"}}, merged.Containers[0].VolumeMounts)
+		// Only the primary container is merged onto the default container of the template.
+		assert.Equal(t, v1.Container{Name: "flyte-copilot-sidecar", Image: "copilot"}, merged.Containers[1])
+		assert.Equal(t, "proxy", merged.Containers[2].Name)
+	})
+
+	t.Run("container with the same name", func(t *testing.T) {
+		podTemplate := dummyPodTemplate("test-namespace", "flyte-template")
+		podTemplate.Template.Spec.Containers[1].Name = "primary"
+
+		merged, err := MergeWithBasePodTemplate(podSpec, podTemplate, "primary")
+		assert.NoError(t, err)
+		assert.Len(t, merged.Containers, 1)
+		assert.Equal(t, "flyte-image", merged.Containers[0].Image)
+		assert.Empty(t, merged.Containers[0].VolumeMounts)
+	})
+}
+
+func TestToK8sPodSpecWithBasePodTemplate(t *testing.T) {
+	ctx := context.TODO()
+	previous := *config.GetK8sPluginConfig()
+	defer func() { assert.NoError(t, config.SetK8sPluginConfig(&previous)) }()
+
+	inline := dummyPodTemplate("", "")
+	inline.Template.Spec.PriorityClassName = "inline"
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		DefaultPodTemplateName: "flyte-template",
+		DefaultPodTemplate:     inline,
+	}))
+
+	x := dummyExecContext(&v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1024m")},
+	})
+
+	p, err := ToK8sPodSpec(ctx, x)
+	assert.NoError(t, err)
+	assert.Equal(t, "inline", p.PriorityClassName)
+
+	DefaultPodTemplateStore.OnAdd(dummyPodTemplate("test-namespace", "flyte-template"))
+	defer DefaultPodTemplateStore.OnDelete(dummyPodTemplate("test-namespace", "flyte-template"))
+
+	p, err = ToK8sPodSpec(ctx, x)
+	assert.NoError(t, err)
+	assert.Equal(t, "flyte-tasks", p.PriorityClassName)
+	assert.Len(t, p.Containers, 2)
+	assert.Equal(t, "some-acceptable-name", p.Containers[0].Name)
+	assert.Equal(t, []v1.VolumeMount{{Name: "cache", MountPath: "/cache"}}, p.Containers[0].VolumeMounts)
+	assert.Equal(t, "proxy", p.Containers[1].Name)
+}
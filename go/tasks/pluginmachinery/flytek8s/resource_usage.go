@@ -0,0 +1,65 @@
+package flytek8s
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// reservedQuantity returns the quantity of the resource the container reserves, i.e. its request or, if it doesn't
+// request any, its limit.
+func reservedQuantity(resources v1.ResourceRequirements, name v1.ResourceName) float64 {
+	if q, found := resources.Requests[name]; found {
+		return q.AsApproximateFloat64()
+	}
+
+	if q, found := resources.Limits[name]; found {
+		return q.AsApproximateFloat64()
+	}
+
+	return 0
+}
+
+// ContainerResourceUsage returns the usage of a container holding the resources it reserves for the duration.
+func ContainerResourceUsage(resources v1.ResourceRequirements, d time.Duration) pluginsCore.ResourceUsage {
+	return pluginsCore.ResourceUsageOver(d, reservedQuantity(resources, v1.ResourceCPU),
+		reservedQuantity(resources, ResourceNvidiaGPU), int64(reservedQuantity(resources, v1.ResourceMemory)))
+}
+
+// PodResourceUsage returns the resources reserved by the containers of the pod for as long as they've been running,
+// with its cost estimated from the configured resource prices. It returns nil if no container has started yet.
+func PodResourceUsage(pod *v1.Pod) *pluginsCore.ResourceUsage {
+	resources := make(map[string]v1.ResourceRequirements, len(pod.Spec.Containers))
+	for _, c := range pod.Spec.Containers {
+		resources[c.Name] = c.Resources
+	}
+
+	var usage *pluginsCore.ResourceUsage
+	for _, status := range pod.Status.ContainerStatuses {
+		var d time.Duration
+		switch {
+		case status.State.Terminated != nil:
+			d = status.State.Terminated.FinishedAt.Sub(status.State.Terminated.StartedAt.Time)
+		case status.State.Running != nil:
+			d = time.Since(status.State.Running.StartedAt.Time)
+		default:
+			continue
+		}
+
+		if usage == nil {
+			usage = &pluginsCore.ResourceUsage{}
+		}
+
+		usage.AddConcurrent(ContainerResourceUsage(resources[status.Name], d))
+	}
+
+	if usage == nil {
+		return nil
+	}
+
+	*usage = usage.WithEstimatedCost(pluginsConfig.GetConfig().ResourcePrices)
+	return usage
+}
@@ -0,0 +1,91 @@
+package flytek8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+func TestPodResourceUsage(t *testing.T) {
+	previous := *pluginsConfig.GetConfig()
+	defer func() {
+		assert.NoError(t, pluginsConfig.SetConfig(&previous))
+	}()
+
+	cfg := previous
+	cfg.ResourcePrices = pluginsConfig.ResourcePrices{CPUSecond: 0.01}
+	assert.NoError(t, pluginsConfig.SetConfig(&cfg))
+
+	startedAt := metav1.NewTime(time.Now().Add(-time.Hour))
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "primary",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("2"),
+							v1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+						Limits: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("4"),
+							ResourceNvidiaGPU: resource.MustParse("1"),
+						},
+					},
+				},
+				{
+					Name: "sidecar",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("500m"),
+							v1.ResourceMemory: resource.MustParse("512Mi"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Not started", func(t *testing.T) {
+		assert.Nil(t, PodResourceUsage(pod))
+	})
+
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{
+		{
+			Name: "primary",
+			State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+				StartedAt:  startedAt,
+				FinishedAt: metav1.NewTime(startedAt.Add(100 * time.Second)),
+			}},
+		},
+		{
+			Name: "sidecar",
+			State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+				StartedAt:  startedAt,
+				FinishedAt: metav1.NewTime(startedAt.Add(200 * time.Second)),
+			}},
+		},
+	}
+
+	assert.Equal(t, &pluginsCore.ResourceUsage{
+		CPUSeconds:               300,
+		GPUSeconds:               100,
+		MemoryHighWaterMarkBytes: 1536 * 1024 * 1024,
+		EstimatedCost:            3,
+	}, PodResourceUsage(pod))
+
+	t.Run("Running", func(t *testing.T) {
+		pod.Status.ContainerStatuses[1].State = v1.ContainerState{
+			Running: &v1.ContainerStateRunning{StartedAt: startedAt},
+		}
+
+		assert.InDelta(t, 200+0.5*3600, PodResourceUsage(pod).CPUSeconds, 10)
+	})
+}
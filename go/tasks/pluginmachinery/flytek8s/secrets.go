@@ -0,0 +1,198 @@
+package flytek8s
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/secret"
+)
+
+const (
+	// Environment variables telling the task where to find the secrets injected into its pod.
+	SecretsEnvPrefixEnvVar  = "FLYTE_SECRETS_ENV_PREFIX"
+	SecretsDefaultDirEnvVar = "FLYTE_SECRETS_DEFAULT_DIR"
+
+	secretVolumeNamePrefix = "flyte-secrets-"
+)
+
+// secretReference returns the reference of the requested secret, failing if it can't be read from a Kubernetes Secret.
+func secretReference(s *core.Secret) (pluginsCore.SecretReference, error) {
+	ref := pluginsCore.SecretReference{Group: s.GetGroup(), Key: s.GetKey(), Version: s.GetGroupVersion()}
+	if len(ref.Group) == 0 || len(ref.Key) == 0 {
+		return ref, errors.Errorf(errors.BadTaskSpecification,
+			"Secret [%v] is missing a group or a key, both are required to inject it into the pod.", ref)
+	}
+
+	if msgs := validation.IsDNS1123Subdomain(ref.Group); len(msgs) > 0 {
+		return ref, errors.Errorf(errors.BadTaskSpecification, "Secret group [%v] isn't a valid Kubernetes Secret name: %v",
+			ref.Group, msgs)
+	}
+
+	if msgs := validation.IsConfigMapKey(secret.VersionedKey(ref)); len(msgs) > 0 {
+		return ref, errors.Errorf(errors.BadTaskSpecification, "Secret key [%v] isn't a valid Kubernetes Secret key: %v",
+			secret.VersionedKey(ref), msgs)
+	}
+
+	return ref, nil
+}
+
+// mountType returns how the secret is injected.
+func mountType(cfg config.SecretInjectionConfig, s *core.Secret) (string, error) {
+	switch s.GetMountRequirement() {
+	case core.Secret_ENV_VAR:
+		return config.SecretMountTypeEnvVar, nil
+	case core.Secret_FILE:
+		return config.SecretMountTypeFile, nil
+	case core.Secret_ANY:
+		if cfg.DefaultMountType == config.SecretMountTypeEnvVar || cfg.DefaultMountType == config.SecretMountTypeFile {
+			return cfg.DefaultMountType, nil
+		}
+
+		return "", fmt.Errorf("unknown default secret mount type [%v]", cfg.DefaultMountType)
+	default:
+		return "", errors.Errorf(errors.BadTaskSpecification, "Secret [%v/%v] requires an unknown mount type [%v].",
+			s.GetGroup(), s.GetKey(), s.GetMountRequirement())
+	}
+}
+
+// checkSecretExists fails with a BadTaskSpecification error if the Kubernetes Secret the reference is read from, or its
+// key, doesn't exist. Secrets are only read once per group, the ones already read are kept in secrets.
+func checkSecretExists(ctx context.Context, reader client.Reader, namespace string, ref pluginsCore.SecretReference,
+	secrets map[string]*v1.Secret) error {
+
+	s, found := secrets[ref.Group]
+	if !found {
+		s = &v1.Secret{}
+		if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Group}, s); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return errors.Wrapf(errors.DownstreamSystemError, err, "Failed to read Secret [%v/%v].", namespace,
+					ref.Group)
+			}
+
+			s = nil
+		}
+
+		secrets[ref.Group] = s
+	}
+
+	if s == nil {
+		return errors.Errorf(errors.BadTaskSpecification, "Secret [%v] doesn't exist in namespace [%v].", ref.Group,
+			namespace)
+	}
+
+	key := secret.VersionedKey(ref)
+	if _, found := s.Data[key]; found {
+		return nil
+	}
+
+	if _, found := s.StringData[key]; found {
+		return nil
+	}
+
+	return errors.Errorf(errors.BadTaskSpecification, "Secret [%v] in namespace [%v] has no key [%v].", ref.Group,
+		namespace, key)
+}
+
+// InjectSecrets exposes the secrets requested in the security context to every container of the pod, either as files
+// or as environment variables, following the naming convention of the secret package. Secrets are read from the
+// Kubernetes Secrets named after their group, in the namespace of the pod. It's a no-op unless secret injection is
+// enabled. Requests that can't be satisfied, including secrets or keys that don't exist, fail with a
+// BadTaskSpecification error so that the task fails before its pod is created. Existence isn't checked if reader is nil.
+func InjectSecrets(ctx context.Context, cfg config.SecretInjectionConfig, reader client.Reader, namespace string,
+	securityContext core.SecurityContext, podSpec *v1.PodSpec) error {
+	if !cfg.Enabled || len(securityContext.GetSecrets()) == 0 {
+		return nil
+	}
+
+	var envVars []v1.EnvVar
+	var volumes []v1.Volume
+	var mounts []v1.VolumeMount
+	volumeIndices := map[string]int{}
+	type injection struct {
+		ref   pluginsCore.SecretReference
+		mount string
+	}
+
+	seen := map[injection]bool{}
+	secrets := map[string]*v1.Secret{}
+	for _, s := range securityContext.GetSecrets() {
+		ref, err := secretReference(s)
+		if err != nil {
+			return err
+		}
+
+		mount, err := mountType(cfg, s)
+		if err != nil {
+			return err
+		}
+
+		if seen[injection{ref: ref, mount: mount}] {
+			continue
+		}
+
+		seen[injection{ref: ref, mount: mount}] = true
+		if reader != nil {
+			if err := checkSecretExists(ctx, reader, namespace, ref, secrets); err != nil {
+				return err
+			}
+		}
+
+		key := secret.VersionedKey(ref)
+		if mount == config.SecretMountTypeEnvVar {
+			envVars = append(envVars, v1.EnvVar{
+				Name: secret.EnvVarName(cfg.EnvVarPrefix, ref),
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: ref.Group},
+						Key:                  key,
+					},
+				},
+			})
+
+			continue
+		}
+
+		idx, found := volumeIndices[ref.Group]
+		if !found {
+			idx = len(volumes)
+			volumeIndices[ref.Group] = idx
+			name := fmt.Sprintf("%v%v", secretVolumeNamePrefix, idx)
+			volumes = append(volumes, v1.Volume{
+				Name: name,
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{SecretName: ref.Group},
+				},
+			})
+
+			mounts = append(mounts, v1.VolumeMount{
+				Name:      name,
+				ReadOnly:  true,
+				MountPath: filepath.Join(cfg.MountPath, ref.Group),
+			})
+		}
+
+		volumes[idx].Secret.Items = append(volumes[idx].Secret.Items, v1.KeyToPath{Key: key, Path: key})
+	}
+
+	envVars = append(envVars,
+		v1.EnvVar{Name: SecretsEnvPrefixEnvVar, Value: cfg.EnvVarPrefix},
+		v1.EnvVar{Name: SecretsDefaultDirEnvVar, Value: cfg.MountPath})
+
+	podSpec.Volumes = append(podSpec.Volumes, volumes...)
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, envVars...)
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, mounts...)
+	}
+
+	return nil
+}
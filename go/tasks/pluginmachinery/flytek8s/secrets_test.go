@@ -0,0 +1,156 @@
+package flytek8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
+)
+
+func TestInjectSecrets(t *testing.T) {
+	ctx := context.TODO()
+	cfg := config.SecretInjectionConfig{
+		Enabled:          true,
+		DefaultMountType: config.SecretMountTypeFile,
+		MountPath:        "/etc/flyte/secrets",
+		EnvVarPrefix:     "_FSEC_",
+	}
+
+	newPodSpec := func() *v1.PodSpec {
+		return &v1.PodSpec{Containers: []v1.Container{{Name: "primary"}, {Name: "sidecar"}}}
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		podSpec := newPodSpec()
+		assert.NoError(t, InjectSecrets(ctx, config.SecretInjectionConfig{}, nil, "ns", core.SecurityContext{
+			Secrets: []*core.Secret{{Group: "group", Key: "key"}},
+		}, podSpec))
+		assert.Equal(t, newPodSpec(), podSpec)
+	})
+
+	t.Run("No secrets", func(t *testing.T) {
+		podSpec := newPodSpec()
+		assert.NoError(t, InjectSecrets(ctx, cfg, nil, "ns", core.SecurityContext{}, podSpec))
+		assert.Equal(t, newPodSpec(), podSpec)
+	})
+
+	t.Run("Injected", func(t *testing.T) {
+		podSpec := newPodSpec()
+		assert.NoError(t, InjectSecrets(ctx, cfg, nil, "ns", core.SecurityContext{
+			Secrets: []*core.Secret{
+				{Group: "aws", Key: "access-key"},
+				{Group: "aws", Key: "secret-key", GroupVersion: "2", MountRequirement: core.Secret_FILE},
+				{Group: "aws", Key: "access-key"},
+				{Group: "db", Key: "password", MountRequirement: core.Secret_ENV_VAR},
+			},
+		}, podSpec))
+
+		assert.Equal(t, []v1.Volume{{
+			Name: "flyte-secrets-0",
+			VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{
+				SecretName: "aws",
+				Items: []v1.KeyToPath{
+					{Key: "access-key", Path: "access-key"},
+					{Key: "secret-key.2", Path: "secret-key.2"},
+				},
+			}},
+		}}, podSpec.Volumes)
+
+		for _, c := range podSpec.Containers {
+			assert.Equal(t, []v1.VolumeMount{{Name: "flyte-secrets-0", ReadOnly: true, MountPath: "/etc/flyte/secrets/aws"}},
+				c.VolumeMounts)
+			assert.Equal(t, []v1.EnvVar{
+				{
+					Name: "_FSEC_DB_PASSWORD",
+					ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "db"},
+						Key:                  "password",
+					}},
+				},
+				{Name: SecretsEnvPrefixEnvVar, Value: "_FSEC_"},
+				{Name: SecretsDefaultDirEnvVar, Value: "/etc/flyte/secrets"},
+			}, c.Env)
+		}
+	})
+
+	t.Run("Default mount type", func(t *testing.T) {
+		cfg := cfg
+		cfg.DefaultMountType = config.SecretMountTypeEnvVar
+		podSpec := newPodSpec()
+		assert.NoError(t, InjectSecrets(ctx, cfg, nil, "ns", core.SecurityContext{
+			Secrets: []*core.Secret{{Group: "db", Key: "password"}},
+		}, podSpec))
+		assert.Empty(t, podSpec.Volumes)
+		assert.Equal(t, "_FSEC_DB_PASSWORD", podSpec.Containers[0].Env[0].Name)
+	})
+
+	for name, s := range map[string]*core.Secret{
+		"Missing group":      {Key: "key"},
+		"Missing key":        {Group: "group"},
+		"Invalid group":      {Group: "Not_A_Secret", Key: "key"},
+		"Invalid key":        {Group: "group", Key: "not a key"},
+		"Invalid mount type": {Group: "group", Key: "key", MountRequirement: core.Secret_MountType(42)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			podSpec := newPodSpec()
+			err := InjectSecrets(ctx, cfg, nil, "ns", core.SecurityContext{Secrets: []*core.Secret{s}}, podSpec)
+			assert.Error(t, err)
+			code, found := stdErrors.GetErrorCode(err)
+			assert.True(t, found)
+			assert.Equal(t, errors.BadTaskSpecification, code)
+			assert.Equal(t, newPodSpec(), podSpec)
+		})
+	}
+
+	t.Run("Existing secrets", func(t *testing.T) {
+		reader := pluginsCoreMock.NewFakeKubeClient()
+		assert.NoError(t, reader.Create(ctx, &v1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{Namespace: "ns", Name: "aws"},
+			Data:       map[string][]byte{"access-key": []byte("id")},
+			StringData: map[string]string{"secret-key.2": "secret"},
+		}))
+
+		podSpec := newPodSpec()
+		assert.NoError(t, InjectSecrets(ctx, cfg, reader, "ns", core.SecurityContext{
+			Secrets: []*core.Secret{
+				{Group: "aws", Key: "access-key"},
+				{Group: "aws", Key: "secret-key", GroupVersion: "2"},
+			},
+		}, podSpec))
+		assert.Len(t, podSpec.Volumes, 1)
+	})
+
+	for name, s := range map[string]*core.Secret{
+		"Secret not found":          {Group: "db", Key: "password"},
+		"Key not found":             {Group: "aws", Key: "password"},
+		"Secret in other namespace": {Group: "other", Key: "password"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			reader := pluginsCoreMock.NewFakeKubeClient()
+			assert.NoError(t, reader.Create(ctx, &v1.Secret{
+				ObjectMeta: metaV1.ObjectMeta{Namespace: "ns", Name: "aws"},
+				Data:       map[string][]byte{"access-key": []byte("id")},
+			}))
+			assert.NoError(t, reader.Create(ctx, &v1.Secret{
+				ObjectMeta: metaV1.ObjectMeta{Namespace: "other-ns", Name: "other"},
+				Data:       map[string][]byte{"password": []byte("pwd")},
+			}))
+
+			podSpec := newPodSpec()
+			err := InjectSecrets(ctx, cfg, reader, "ns", core.SecurityContext{Secrets: []*core.Secret{s}}, podSpec)
+			assert.Error(t, err)
+			code, found := stdErrors.GetErrorCode(err)
+			assert.True(t, found)
+			assert.Equal(t, errors.BadTaskSpecification, code)
+			assert.Equal(t, newPodSpec(), podSpec)
+		})
+	}
+}
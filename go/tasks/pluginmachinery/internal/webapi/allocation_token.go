@@ -13,6 +13,13 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 )
 
+// resourceRequirer is the subset of webapi.AsyncPlugin and webapi.SyncPlugin needed to allocate tokens.
+type resourceRequirer interface {
+	GetConfig() webapi.PluginConfig
+	ResourceRequirements(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (
+		namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error)
+}
+
 type tokenAllocator struct {
 	clock clock.Clock
 }
@@ -23,7 +30,7 @@ func newTokenAllocator(c clock.Clock) tokenAllocator {
 	}
 }
 
-func (a tokenAllocator) allocateToken(ctx context.Context, p webapi.AsyncPlugin, tCtx core.TaskExecutionContext, state *State, metrics Metrics) (
+func (a tokenAllocator) allocateToken(ctx context.Context, p resourceRequirer, tCtx core.TaskExecutionContext, state *State, metrics Metrics) (
 	newState *State, phaseInfo core.PhaseInfo, err error) {
 	if len(p.GetConfig().ResourceQuotas) == 0 {
 		// No quota, return success
@@ -40,7 +47,8 @@ func (a tokenAllocator) allocateToken(ctx context.Context, p webapi.AsyncPlugin,
 	}
 
 	token := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
-	allocationStatus, err := tCtx.ResourceManager().AllocateResource(ctx, ns, token, constraints)
+	allocationStatus, err := core.AllocateLeasedResource(ctx, tCtx.ResourceManager(), ns, token, constraints,
+		p.GetConfig().ResourceLeaseTTL.Duration)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to allocate resources for task. Error: %v", err)
 		return nil, core.PhaseInfo{}, err
@@ -54,8 +62,7 @@ func (a tokenAllocator) allocateToken(ctx context.Context, p webapi.AsyncPlugin,
 			AllocationTokenRequestStartTime: a.clock.Now(),
 			Phase:                           PhaseAllocationTokenAcquired,
 		}, core.PhaseInfoQueued(a.clock.Now(), 0, "Allocation token required"), nil
-	case core.AllocationStatusNamespaceQuotaExceeded:
-	case core.AllocationStatusExhausted:
+	case core.AllocationStatusNamespaceQuotaExceeded, core.AllocationStatusExhausted:
 		metrics.AllocationNotGranted.Inc(ctx)
 		logger.Infof(ctx, "Couldn't allocate token because allocation status is [%v].", allocationStatus.String())
 		startTime := state.AllocationTokenRequestStartTime
@@ -73,7 +80,29 @@ func (a tokenAllocator) allocateToken(ctx context.Context, p webapi.AsyncPlugin,
 	return nil, core.PhaseInfo{}, fmt.Errorf("allocation status undefined [%v]", allocationStatus)
 }
 
-func (a tokenAllocator) releaseToken(ctx context.Context, p webapi.AsyncPlugin, tCtx core.TaskExecutionContext, metrics Metrics) error {
+// renewToken renews the lease of the allocation token of the task, if leases are enabled. Failures are logged and
+// counted but otherwise ignored; the lease is renewed again in the next round.
+func (a tokenAllocator) renewToken(ctx context.Context, p resourceRequirer, tCtx core.TaskExecutionContext, metrics Metrics) {
+	ttl := p.GetConfig().ResourceLeaseTTL.Duration
+	if len(p.GetConfig().ResourceQuotas) == 0 || ttl <= 0 {
+		return
+	}
+
+	ns, _, err := p.ResourceRequirements(ctx, tCtx)
+	if err != nil {
+		metrics.LeaseRenewFailed.Inc(ctx)
+		logger.Warnf(ctx, "Failed to calculate resource requirements for task. Error: %v", err)
+		return
+	}
+
+	token := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	if err = core.RenewResourceLease(ctx, tCtx.ResourceManager(), ns, token, ttl); err != nil {
+		metrics.LeaseRenewFailed.Inc(ctx)
+		logger.Warnf(ctx, "Failed to renew the lease of allocation token [%v]. Error: %v", token, err)
+	}
+}
+
+func (a tokenAllocator) releaseToken(ctx context.Context, p resourceRequirer, tCtx core.TaskExecutionContext, metrics Metrics) error {
 	ns, _, err := p.ResourceRequirements(ctx, tCtx)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to calculate resource requirements for task. Error: %v", err)
@@ -14,6 +14,7 @@ import (
 
 	testing2 "k8s.io/utils/clock/testing"
 
+	"github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 
@@ -149,3 +150,77 @@ func Test_releaseToken(t *testing.T) {
 	a := newTokenAllocator(clck)
 	assert.NoError(t, a.releaseToken(ctx, p, tCtx, metrics))
 }
+
+func Test_allocateToken_NamespaceQuotaExceeded(t *testing.T) {
+	ctx := context.Background()
+	metrics := newMetrics(promutils.NewTestScope())
+	clck := testing2.NewFakeClock(time.Now())
+
+	rm := core.NewInMemoryResourceManagerWithClock("in-memory", clck)
+	assert.NoError(t, rm.RegisterResourceQuota(ctx, "ns", 10))
+	scopedRM := rm.ForScope(core.ResourceScope{Project: "flytesnacks", Namespace: "flytesnacks-development"})
+
+	constraints := core.ResourceConstraintsSpec{NamespaceScopeResourceConstraint: &core.ResourceConstraint{Value: 1}}
+	p := newPluginWithProperties(webapi.PluginConfig{ResourceQuotas: map[core.ResourceNamespace]int{"ns": 10}})
+	newTaskContext := func(token string) *mocks2.TaskExecutionContext {
+		tID := &mocks2.TaskExecutionID{}
+		tID.OnGetGeneratedName().Return(token)
+
+		tMeta := &mocks2.TaskExecutionMetadata{}
+		tMeta.OnGetTaskExecutionID().Return(tID)
+
+		tCtx := &mocks2.TaskExecutionContext{}
+		tCtx.OnTaskExecutionMetadata().Return(tMeta)
+		tCtx.OnResourceManager().Return(scopedRM)
+		p.OnResourceRequirements(ctx, tCtx).Return("ns", constraints, nil)
+		return tCtx
+	}
+
+	a := newTokenAllocator(clck)
+	newState, _, err := a.allocateToken(ctx, p, newTaskContext("abc"), &State{}, metrics)
+	assert.NoError(t, err)
+	assert.Equal(t, PhaseAllocationTokenAcquired, newState.Phase)
+
+	// The namespace already holds as many tokens as it's allowed, the task waits even though the quota isn't exhausted.
+	newState, phaseInfo, err := a.allocateToken(ctx, p, newTaskContext("def"), &State{}, metrics)
+	assert.NoError(t, err)
+	assert.Equal(t, PhaseNotStarted, newState.Phase)
+	assert.Equal(t, clck.Now(), newState.AllocationTokenRequestStartTime)
+	assert.Equal(t, core.PhaseQueued, phaseInfo.Phase())
+}
+
+func Test_leasedToken(t *testing.T) {
+	ctx := context.Background()
+	metrics := newMetrics(promutils.NewTestScope())
+	clck := testing2.NewFakeClock(time.Now())
+
+	tID := &mocks2.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("abc")
+
+	tMeta := &mocks2.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+
+	rm := &mocks2.LeasedResourceManager{}
+	rm.OnAllocateResourceWithLease(ctx, core.ResourceNamespace("ns"), "abc", core.ResourceConstraintsSpec{},
+		time.Minute).Return(core.AllocationStatusGranted, nil)
+	rm.OnRenewResource(ctx, core.ResourceNamespace("ns"), "abc", time.Minute).Return(nil)
+
+	tCtx := &mocks2.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+	tCtx.OnResourceManager().Return(rm)
+
+	p := newPluginWithProperties(webapi.PluginConfig{
+		ResourceQuotas:   map[core.ResourceNamespace]int{"ns": 1},
+		ResourceLeaseTTL: config.Duration{Duration: time.Minute},
+	})
+	p.OnResourceRequirements(ctx, tCtx).Return("ns", core.ResourceConstraintsSpec{}, nil)
+
+	a := newTokenAllocator(clck)
+	newState, _, err := a.allocateToken(ctx, p, tCtx, &State{}, metrics)
+	assert.NoError(t, err)
+	assert.Equal(t, PhaseAllocationTokenAcquired, newState.Phase)
+	rm.AssertNotCalled(t, "AllocateResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	a.renewToken(ctx, p, tCtx, metrics)
+	rm.AssertCalled(t, "RenewResource", ctx, core.ResourceNamespace("ns"), "abc", time.Minute)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 
 	"github.com/flyteorg/flytestdlib/promutils"
+	"golang.org/x/time/rate"
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 
@@ -39,8 +41,12 @@ type Client interface {
 type ResourceCache struct {
 	// AutoRefresh
 	cache.AutoRefresh
-	client Client
-	cfg    webapi.CachingConfig
+	client      Client
+	cfg         webapi.CachingConfig
+	rateLimiter *rate.Limiter
+	metrics     Metrics
+	watcher     *resourceWatcher
+	breaker     *circuitBreaker
 }
 
 // A wrapper for each item in the cache.
@@ -56,6 +62,7 @@ func (q *ResourceCache) SyncResource(ctx context.Context, batch cache.Batch) (
 	updatedBatch []cache.ItemSyncResponse, err error) {
 
 	resp := make([]cache.ItemSyncResponse, 0, len(batch))
+	toGet := make([]batchItem, 0, len(batch))
 	for _, resource := range batch {
 		// Cast the item back to the thing we want to work with.
 		cacheItem, ok := resource.GetItem().(CacheItem)
@@ -97,16 +104,67 @@ func (q *ResourceCache) SyncResource(ctx context.Context, batch cache.Batch) (
 			continue
 		}
 
+		// Use the latest pushed update, if any, instead of querying the remote service.
+		if pushed, found := q.watcher.consume(resource.GetID()); found {
+			logger.Debugf(ctx, "Sync loop - using pushed update for [%s]", resource.GetID())
+			cacheItem.Resource = pushed
+			resp = append(resp, cache.ItemSyncResponse{
+				ID:     resource.GetID(),
+				Item:   cacheItem,
+				Action: cache.Update,
+			})
+
+			continue
+		}
+
+		toGet = append(toGet, batchItem{id: resource.GetID(), cacheItem: cacheItem})
+	}
+
+	if len(toGet) == 0 {
+		return resp, nil
+	}
+
+	if batchClient, ok := q.client.(webapi.BatchGetPlugin); ok {
+		updated, err := q.batchGet(ctx, batchClient, toGet)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(resp, updated...), nil
+	}
+
+	for _, item := range toGet {
+		cacheItem := item.cacheItem
+
+		// Wait on the rate limiter first, a call the circuit breaker lets through must always be recorded.
+		if err := wait(ctx, q.rateLimiter, q.metrics.ReadThrottled); err != nil {
+			logger.Errorf(ctx, "Failed to wait on read rate limiter. Error: %v", err)
+			return nil, err
+		}
+
+		// Skip the item while the remote service is unhealthy. It'll be retried in the next sync.
+		if !q.breaker.allow(ctx) {
+			resp = append(resp, cache.ItemSyncResponse{
+				ID:     item.id,
+				Item:   cacheItem,
+				Action: cache.Unchanged,
+			})
+
+			continue
+		}
+
 		// Get an updated status
-		logger.Debugf(ctx, "Querying AsyncPlugin for %s", resource.GetID())
+
+		logger.Debugf(ctx, "Querying AsyncPlugin for %s", item.id)
 		newResource, err := q.client.Get(ctx, newPluginContext(cacheItem.ResourceMeta, cacheItem.Resource, "", nil))
+		q.breaker.record(ctx, err)
 		if err != nil {
-			logger.Errorf(ctx, "Error retrieving resource [%s]. Error: %v", resource.GetID(), err)
+			logger.Errorf(ctx, "Error retrieving resource [%s]. Error: %v", item.id, err)
 			cacheItem.SyncFailureCount++
 
 			// Make sure we don't return nil for the first argument, because that deletes it from the cache.
 			resp = append(resp, cache.ItemSyncResponse{
-				ID:     resource.GetID(),
+				ID:     item.id,
 				Item:   cacheItem,
 				Action: cache.Update,
 			})
@@ -117,7 +175,7 @@ func (q *ResourceCache) SyncResource(ctx context.Context, batch cache.Batch) (
 		cacheItem.Resource = newResource
 
 		resp = append(resp, cache.ItemSyncResponse{
-			ID:     resource.GetID(),
+			ID:     item.id,
 			Item:   cacheItem,
 			Action: cache.Update,
 		})
@@ -126,6 +184,94 @@ func (q *ResourceCache) SyncResource(ctx context.Context, batch cache.Batch) (
 	return resp, nil
 }
 
+// An item in a batch that needs to be retrieved from the remote service.
+type batchItem struct {
+	id        cache.ItemID
+	cacheItem CacheItem
+}
+
+// batchGet retrieves the latest version of all items in a single call to the remote service.
+func (q *ResourceCache) batchGet(ctx context.Context, client webapi.BatchGetPlugin, items []batchItem) (
+	[]cache.ItemSyncResponse, error) {
+
+	// Wait on the rate limiter first, a call the circuit breaker lets through must always be recorded.
+	if err := wait(ctx, q.rateLimiter, q.metrics.ReadThrottled); err != nil {
+		logger.Errorf(ctx, "Failed to wait on read rate limiter. Error: %v", err)
+		return nil, err
+	}
+
+	// Skip the batch while the remote service is unhealthy. It'll be retried in the next sync.
+	if !q.breaker.allow(ctx) {
+		resp := make([]cache.ItemSyncResponse, 0, len(items))
+		for _, item := range items {
+			resp = append(resp, cache.ItemSyncResponse{
+				ID:     item.id,
+				Item:   item.cacheItem,
+				Action: cache.Unchanged,
+			})
+		}
+
+		return resp, nil
+	}
+
+	tCtxs := make([]webapi.GetContext, 0, len(items))
+	for _, item := range items {
+		tCtxs = append(tCtxs, newPluginContext(item.cacheItem.ResourceMeta, item.cacheItem.Resource, "", nil))
+	}
+
+	logger.Debugf(ctx, "Querying BatchGetPlugin for [%v] resources", len(items))
+	resources, err := client.BatchGet(ctx, tCtxs)
+	q.breaker.record(ctx, err)
+	if err != nil {
+		logger.Errorf(ctx, "Error retrieving a batch of [%v] resources. Error: %v", len(items), err)
+	}
+
+	resp := make([]cache.ItemSyncResponse, 0, len(items))
+	for _, item := range items {
+		cacheItem := item.cacheItem
+		newResource, found := resources[client.ResourceKey(cacheItem.ResourceMeta)]
+		if err != nil || !found {
+			if err == nil {
+				logger.Errorf(ctx, "Resource [%s] missing from batch response.", item.id)
+			}
+
+			cacheItem.SyncFailureCount++
+		} else {
+			cacheItem.Resource = newResource
+		}
+
+		// Make sure we don't return nil for the first argument, because that deletes it from the cache.
+		resp = append(resp, cache.ItemSyncResponse{
+			ID:     item.id,
+			Item:   cacheItem,
+			Action: cache.Update,
+		})
+	}
+
+	return resp, nil
+}
+
+// createBatches groups items into batches of up to batchSize items.
+func createBatches(batchSize int) cache.CreateBatchesFunc {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	return func(_ context.Context, snapshot []cache.ItemWrapper) (batches []cache.Batch, err error) {
+		batches = make([]cache.Batch, 0, len(snapshot)/batchSize+1)
+		for start := 0; start < len(snapshot); start += batchSize {
+			end := start + batchSize
+			if end > len(snapshot) {
+				end = len(snapshot)
+			}
+
+			batches = append(batches, snapshot[start:end])
+		}
+
+		return batches, nil
+	}
+}
+
 // ToPluginPhase translates the more granular task phase into the webapi plugin phase.
 func ToPluginPhase(s core.Phase) (Phase, error) {
 	switch s {
@@ -153,15 +299,29 @@ func ToPluginPhase(s core.Phase) (Phase, error) {
 	}
 }
 
+// NewResourceCache creates a cache that refreshes the resources with the client. Its syncs are tracked by workers, if
+// not nil, so that stopping the cache can wait for them.
 func NewResourceCache(ctx context.Context, name string, client Client, cfg webapi.CachingConfig,
-	scope promutils.Scope) (ResourceCache, error) {
+	rateLimiter *rate.Limiter, metrics Metrics, watcher *resourceWatcher, breaker *circuitBreaker,
+	workers *utils.RefreshWorkers, scope promutils.Scope) (ResourceCache, error) {
 
 	q := ResourceCache{
-		client: client,
-		cfg:    cfg,
+		client:      client,
+		cfg:         cfg,
+		rateLimiter: rateLimiter,
+		metrics:     metrics,
+		watcher:     watcher,
+		breaker:     breaker,
+	}
+
+	// Only group items if the client can retrieve them in a single call. Otherwise, single item batches maximize the
+	// parallelism of the sync workers.
+	batchesFunc := cache.SingleItemBatches
+	if _, ok := client.(webapi.BatchGetPlugin); ok {
+		batchesFunc = createBatches(cfg.BatchSize)
 	}
 
-	autoRefreshCache, err := cache.NewAutoRefreshCache(name, q.SyncResource,
+	autoRefreshCache, err := cache.NewAutoRefreshBatchedCache(name, batchesFunc, workers.Wrap(q.SyncResource),
 		workqueue.DefaultControllerRateLimiter(), cfg.ResyncInterval.Duration, cfg.Workers, cfg.Size,
 		scope.NewSubScope("cache"))
 
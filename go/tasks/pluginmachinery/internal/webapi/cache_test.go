@@ -4,6 +4,11 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"golang.org/x/time/rate"
+	testing2 "k8s.io/utils/clock/testing"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 	"github.com/flyteorg/flytestdlib/promutils"
@@ -13,6 +18,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/internal/webapi/mocks"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
 	"github.com/flyteorg/flytestdlib/cache"
 	cacheMocks "github.com/flyteorg/flytestdlib/cache/mocks"
 	"github.com/stretchr/testify/assert"
@@ -22,14 +28,16 @@ func TestNewResourceCache(t *testing.T) {
 	t.Run("Simple", func(t *testing.T) {
 		c, err := NewResourceCache(context.Background(), "Cache1", &mocks.Client{}, webapi.CachingConfig{
 			Size: 10,
-		}, promutils.NewTestScope())
+		}, newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}), newMetrics(promutils.NewTestScope()),
+			nil, nil, nil, promutils.NewTestScope())
 		assert.NoError(t, err)
 		assert.NotNil(t, c)
 	})
 
 	t.Run("Error", func(t *testing.T) {
 		_, err := NewResourceCache(context.Background(), "Cache1", &mocks.Client{}, webapi.CachingConfig{},
-			promutils.NewTestScope())
+			newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}), newMetrics(promutils.NewTestScope()),
+			nil, nil, nil, promutils.NewTestScope())
 		assert.Error(t, err)
 	})
 }
@@ -47,6 +55,8 @@ func TestResourceCache_SyncResource(t *testing.T) {
 			cfg: webapi.CachingConfig{
 				MaxSystemFailures: 5,
 			},
+			rateLimiter: newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}),
+			metrics:     newMetrics(promutils.NewTestScope()),
 		}
 
 		state := State{
@@ -76,6 +86,8 @@ func TestResourceCache_SyncResource(t *testing.T) {
 			cfg: webapi.CachingConfig{
 				MaxSystemFailures: 5,
 			},
+			rateLimiter: newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}),
+			metrics:     newMetrics(promutils.NewTestScope()),
 		}
 
 		state := State{
@@ -111,6 +123,8 @@ func TestResourceCache_SyncResource(t *testing.T) {
 			cfg: webapi.CachingConfig{
 				MaxSystemFailures: 5,
 			},
+			rateLimiter: newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}),
+			metrics:     newMetrics(promutils.NewTestScope()),
 		}
 
 		state := State{
@@ -134,6 +148,192 @@ func TestResourceCache_SyncResource(t *testing.T) {
 		assert.Equal(t, cache.Update, newCacheItem[0].Action)
 		assert.Equal(t, PhaseResourcesCreated, newExecutionState.Phase)
 	})
+
+	t.Run("Pushed update", func(t *testing.T) {
+		mockCache := &cacheMocks.AutoRefresh{}
+		mockClient := &mocks.Client{}
+
+		watchable := &webapiMocks.WatchablePlugin{}
+		watchable.OnResourceKey("123456").Return("123456")
+		watcher := newResourceWatcher(watchable, newMetrics(promutils.NewTestScope()))
+		watcher.track("123456", "some-id", nil)
+		assert.True(t, watcher.Push(ctx, "123456", "pushed"))
+
+		q := ResourceCache{
+			AutoRefresh: mockCache,
+			client:      mockClient,
+			cfg: webapi.CachingConfig{
+				MaxSystemFailures: 5,
+			},
+			rateLimiter: newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}),
+			metrics:     newMetrics(promutils.NewTestScope()),
+			watcher:     watcher,
+		}
+
+		cacheItem := CacheItem{
+			State: State{
+				ResourceMeta: "123456",
+				Phase:        PhaseResourcesCreated,
+			},
+		}
+
+		iw := &cacheMocks.ItemWrapper{}
+		iw.OnGetItem().Return(cacheItem)
+		iw.OnGetID().Return("some-id")
+
+		newCacheItem, err := q.SyncResource(ctx, []cache.ItemWrapper{iw})
+		assert.NoError(t, err)
+		assert.Equal(t, cache.Update, newCacheItem[0].Action)
+		assert.Equal(t, "pushed", newCacheItem[0].Item.(CacheItem).Resource)
+		mockClient.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+
+		_, found := watcher.peek("some-id")
+		assert.False(t, found)
+	})
+}
+
+type batchClient struct {
+	*mocks.Client
+	*webapiMocks.BatchGetPlugin
+}
+
+func TestResourceCache_SyncResource_Batched(t *testing.T) {
+	ctx := context.Background()
+
+	newItem := func(id, resourceMeta string) *cacheMocks.ItemWrapper {
+		iw := &cacheMocks.ItemWrapper{}
+		iw.OnGetItem().Return(CacheItem{
+			State: State{
+				ResourceMeta: resourceMeta,
+				Phase:        PhaseResourcesCreated,
+			},
+		})
+		iw.OnGetID().Return(id)
+		return iw
+	}
+
+	newCache := func(client batchClient) ResourceCache {
+		client.OnResourceKey("q1").Return("q1")
+		client.OnResourceKey("q2").Return("q2")
+
+		return ResourceCache{
+			AutoRefresh: &cacheMocks.AutoRefresh{},
+			client:      client,
+			cfg: webapi.CachingConfig{
+				MaxSystemFailures: 5,
+			},
+			rateLimiter: newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}),
+			metrics:     newMetrics(promutils.NewTestScope()),
+		}
+	}
+
+	t.Run("Partial response", func(t *testing.T) {
+		client := batchClient{Client: &mocks.Client{}, BatchGetPlugin: &webapiMocks.BatchGetPlugin{}}
+		client.OnBatchGetMatch(ctx, mock.Anything).Return(map[string]webapi.Resource{"q1": "SUCCEEDED"}, nil)
+
+		q := newCache(client)
+		resp, err := q.SyncResource(ctx, []cache.ItemWrapper{newItem("t1", "q1"), newItem("t2", "q2")})
+		assert.NoError(t, err)
+		assert.Len(t, resp, 2)
+		assert.Equal(t, "SUCCEEDED", resp[0].Item.(CacheItem).Resource)
+		assert.Equal(t, 0, resp[0].Item.(CacheItem).SyncFailureCount)
+		assert.Nil(t, resp[1].Item.(CacheItem).Resource)
+		assert.Equal(t, 1, resp[1].Item.(CacheItem).SyncFailureCount)
+		client.BatchGetPlugin.AssertNumberOfCalls(t, "BatchGet", 1)
+		client.Client.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed batch", func(t *testing.T) {
+		client := batchClient{Client: &mocks.Client{}, BatchGetPlugin: &webapiMocks.BatchGetPlugin{}}
+		client.OnBatchGetMatch(ctx, mock.Anything).Return(nil, fmt.Errorf("throttled"))
+
+		q := newCache(client)
+		resp, err := q.SyncResource(ctx, []cache.ItemWrapper{newItem("t1", "q1"), newItem("t2", "q2")})
+		assert.NoError(t, err)
+		assert.Len(t, resp, 2)
+		for _, r := range resp {
+			assert.Equal(t, cache.Update, r.Action)
+			assert.Equal(t, 1, r.Item.(CacheItem).SyncFailureCount)
+		}
+	})
+}
+
+func TestResourceCache_SyncResource_HalfOpenBreaker(t *testing.T) {
+	cfg := webapi.CircuitBreakerConfig{
+		Enabled:           true,
+		FailurePercentage: 50,
+		MinRequests:       1,
+		Window:            config.Duration{Duration: time.Minute},
+		OpenDuration:      config.Duration{Duration: 30 * time.Second},
+	}
+
+	newHalfOpenBreaker := func() *circuitBreaker {
+		clck := testing2.NewFakeClock(time.Now())
+		b := newCircuitBreaker(cfg, clck, newMetrics(promutils.NewTestScope()))
+		b.record(context.Background(), fmt.Errorf("failed"))
+		clck.Step(31 * time.Second)
+		return b
+	}
+
+	// The rate limiter has no token left and the context is cancelled, so waiting on it fails.
+	newExhaustedLimiter := func() *rate.Limiter {
+		limiter := rate.NewLimiter(rate.Limit(0.001), 1)
+		assert.True(t, limiter.Allow())
+		return limiter
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	item := &cacheMocks.ItemWrapper{}
+	item.OnGetItem().Return(CacheItem{State: State{ResourceMeta: "q1", Phase: PhaseResourcesCreated}})
+	item.OnGetID().Return("t1")
+
+	t.Run("Get", func(t *testing.T) {
+		b := newHalfOpenBreaker()
+		q := ResourceCache{
+			client:      &mocks.Client{},
+			rateLimiter: newExhaustedLimiter(),
+			breaker:     b,
+			metrics:     newMetrics(promutils.NewTestScope()),
+		}
+
+		_, err := q.SyncResource(ctx, []cache.ItemWrapper{item})
+		assert.Error(t, err)
+		assert.True(t, b.allow(context.Background()))
+	})
+
+	t.Run("BatchGet", func(t *testing.T) {
+		b := newHalfOpenBreaker()
+		q := ResourceCache{
+			client:      batchClient{Client: &mocks.Client{}, BatchGetPlugin: &webapiMocks.BatchGetPlugin{}},
+			rateLimiter: newExhaustedLimiter(),
+			breaker:     b,
+			metrics:     newMetrics(promutils.NewTestScope()),
+		}
+
+		_, err := q.SyncResource(ctx, []cache.ItemWrapper{item})
+		assert.Error(t, err)
+		assert.True(t, b.allow(context.Background()))
+	})
+}
+
+func Test_createBatches(t *testing.T) {
+	ctx := context.Background()
+	snapshot := make([]cache.ItemWrapper, 0, 5)
+	for i := 0; i < 5; i++ {
+		snapshot = append(snapshot, &cacheMocks.ItemWrapper{})
+	}
+
+	batches, err := createBatches(2)(ctx, snapshot)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 3)
+	assert.Len(t, batches[0], 2)
+	assert.Len(t, batches[2], 1)
+
+	batches, err = createBatches(0)(ctx, snapshot)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 5)
 }
 
 func TestToPluginPhase(t *testing.T) {
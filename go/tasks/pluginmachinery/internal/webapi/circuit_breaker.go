@@ -0,0 +1,182 @@
+package webapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+// circuitState is exported through the circuit_breaker_state gauge.
+type circuitState int
+
+const (
+	// circuitClosed lets all calls through.
+	circuitClosed circuitState = iota
+
+	// circuitHalfOpen lets a single probe call through to check whether the remote service recovered.
+	circuitHalfOpen
+
+	// circuitOpen rejects all calls until OpenDuration elapses.
+	circuitOpen
+)
+
+// circuitBreaker tracks the outcome of calls to the remote service and stops further calls while it's unhealthy. A
+// single instance is shared by all calls a plugin makes (e.g. Create and Get). A nil circuitBreaker lets all calls
+// through.
+type circuitBreaker struct {
+	cfg     webapi.CircuitBreakerConfig
+	clock   clock.Clock
+	metrics Metrics
+
+	m             sync.Mutex
+	state         circuitState
+	windowStart   time.Time
+	successes     int
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+// allow reports whether a call to the remote service may happen now. Callers that are allowed through must report
+// the outcome through record.
+func (b *circuitBreaker) allow(ctx context.Context) bool {
+	if b == nil {
+		return true
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.clock.Since(b.openedAt) < b.cfg.OpenDuration.Duration {
+			b.metrics.CircuitBreakerRejected.Inc(ctx)
+			return false
+		}
+
+		b.setState(ctx, circuitHalfOpen)
+		fallthrough
+	case circuitHalfOpen:
+		if b.probeInFlight {
+			b.metrics.CircuitBreakerRejected.Inc(ctx)
+			return false
+		}
+
+		b.probeInFlight = true
+	}
+
+	return true
+}
+
+// record reports the outcome of a call that was allowed through. Errors caused by a bad task specification say nothing
+// about the health of the remote service and are counted as successful calls.
+func (b *circuitBreaker) record(ctx context.Context, err error) {
+	if b == nil {
+		return
+	}
+
+	if err != nil && stdErrors.IsCausedBy(err, errors.BadTaskSpecification) {
+		err = nil
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.probeInFlight = false
+		if err != nil {
+			b.open(ctx)
+		} else {
+			b.setState(ctx, circuitClosed)
+			b.resetWindow()
+		}
+
+		return
+	}
+
+	if b.clock.Since(b.windowStart) > b.cfg.Window.Duration {
+		b.resetWindow()
+	}
+
+	if err != nil {
+		b.failures++
+	} else {
+		b.successes++
+	}
+
+	total := b.successes + b.failures
+	if b.state == circuitClosed && total >= b.cfg.MinRequests &&
+		b.failures*100 >= b.cfg.FailurePercentage*total {
+		b.open(ctx)
+	}
+}
+
+// checkHealth returns an error while the circuit is open, i.e. while calls to the remote service are suspended.
+func (b *circuitBreaker) checkHealth() error {
+	if b == nil {
+		return nil
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if b.state == circuitOpen {
+		return errors.Errorf(errors.DownstreamSystemError,
+			"The remote service is unhealthy, calls to it are suspended since [%v].", b.openedAt)
+	}
+
+	return nil
+}
+
+// circuitOpenPhaseInfo is reported when a call to the remote service is rejected by the circuit breaker. The state is
+// left unchanged so the call is retried in the next round.
+func circuitOpenPhaseInfo() core.PhaseInfo {
+	return core.PhaseInfoWaitingForResources(time.Now(), core.DefaultPhaseVersion,
+		"The remote service is unhealthy and calls to it are temporarily suspended. The request will be retried.")
+}
+
+func (b *circuitBreaker) open(ctx context.Context) {
+	logger.Warnf(ctx, "Opening circuit breaker after [%v] failed out of [%v] calls.", b.failures,
+		b.successes+b.failures)
+	b.openedAt = b.clock.Now()
+	b.setState(ctx, circuitOpen)
+	b.resetWindow()
+}
+
+func (b *circuitBreaker) setState(ctx context.Context, state circuitState) {
+	if b.state != state {
+		logger.Infof(ctx, "Circuit breaker moving from state [%v] to [%v].", b.state, state)
+	}
+
+	b.state = state
+	b.metrics.CircuitBreakerState.Set(float64(state))
+}
+
+func (b *circuitBreaker) resetWindow() {
+	b.windowStart = b.clock.Now()
+	b.successes = 0
+	b.failures = 0
+}
+
+// newCircuitBreaker creates a circuit breaker if it's enabled in the config. It returns nil otherwise.
+func newCircuitBreaker(cfg webapi.CircuitBreakerConfig, c clock.Clock, metrics Metrics) *circuitBreaker {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	metrics.CircuitBreakerState.Set(float64(circuitClosed))
+	return &circuitBreaker{
+		cfg:         cfg,
+		clock:       c,
+		metrics:     metrics,
+		windowStart: c.Now(),
+	}
+}
@@ -0,0 +1,123 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	testing2 "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	cfg := webapi.CircuitBreakerConfig{
+		Enabled:           true,
+		FailurePercentage: 50,
+		MinRequests:       4,
+		Window:            config.Duration{Duration: time.Minute},
+		OpenDuration:      config.Duration{Duration: 30 * time.Second},
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		b := newCircuitBreaker(webapi.CircuitBreakerConfig{}, testing2.NewFakeClock(time.Now()),
+			newMetrics(promutils.NewTestScope()))
+		assert.Nil(t, b)
+		assert.True(t, b.allow(ctx))
+		b.record(ctx, fmt.Errorf("failed"))
+	})
+
+	t.Run("Opens and recovers", func(t *testing.T) {
+		clck := testing2.NewFakeClock(time.Now())
+		b := newCircuitBreaker(cfg, clck, newMetrics(promutils.NewTestScope()))
+
+		b.record(ctx, nil)
+		b.record(ctx, nil)
+		b.record(ctx, fmt.Errorf("failed"))
+		assert.True(t, b.allow(ctx))
+		assert.Equal(t, circuitClosed, b.state)
+
+		b.record(ctx, nil)
+		b.record(ctx, fmt.Errorf("failed"))
+		assert.Equal(t, circuitClosed, b.state)
+		b.record(ctx, fmt.Errorf("failed"))
+		assert.Equal(t, circuitOpen, b.state)
+		assert.False(t, b.allow(ctx))
+
+		// Only a single probe is let through once the open duration elapses.
+		clck.Step(31 * time.Second)
+		assert.True(t, b.allow(ctx))
+		assert.Equal(t, circuitHalfOpen, b.state)
+		assert.False(t, b.allow(ctx))
+
+		b.record(ctx, nil)
+		assert.Equal(t, circuitClosed, b.state)
+		assert.True(t, b.allow(ctx))
+	})
+
+	t.Run("Failed probe", func(t *testing.T) {
+		clck := testing2.NewFakeClock(time.Now())
+		b := newCircuitBreaker(cfg, clck, newMetrics(promutils.NewTestScope()))
+		for i := 0; i < 4; i++ {
+			b.record(ctx, fmt.Errorf("failed"))
+		}
+
+		assert.Equal(t, circuitOpen, b.state)
+		clck.Step(31 * time.Second)
+		assert.True(t, b.allow(ctx))
+		b.record(ctx, fmt.Errorf("failed"))
+		assert.Equal(t, circuitOpen, b.state)
+		assert.False(t, b.allow(ctx))
+	})
+
+	t.Run("Window expires", func(t *testing.T) {
+		clck := testing2.NewFakeClock(time.Now())
+		b := newCircuitBreaker(cfg, clck, newMetrics(promutils.NewTestScope()))
+		for i := 0; i < 3; i++ {
+			b.record(ctx, fmt.Errorf("failed"))
+		}
+
+		clck.Step(2 * time.Minute)
+		b.record(ctx, fmt.Errorf("failed"))
+		assert.Equal(t, circuitClosed, b.state)
+	})
+
+	t.Run("Bad task specification", func(t *testing.T) {
+		clck := testing2.NewFakeClock(time.Now())
+		b := newCircuitBreaker(cfg, clck, newMetrics(promutils.NewTestScope()))
+		for i := 0; i < 4; i++ {
+			b.record(ctx, errors.Errorf(errors.BadTaskSpecification, "missing database"))
+		}
+
+		assert.Equal(t, circuitClosed, b.state)
+	})
+}
+
+func Test_validateCircuitBreakerConfig(t *testing.T) {
+	cfg := webapi.PluginConfig{
+		ReadRateLimiter:  webapi.RateLimiterConfig{QPS: 10, Burst: 100},
+		WriteRateLimiter: webapi.RateLimiterConfig{QPS: 10, Burst: 100},
+		Caching: webapi.CachingConfig{
+			Size:           10,
+			ResyncInterval: config.Duration{Duration: 10 * time.Second},
+			Workers:        10,
+		},
+		CircuitBreaker: webapi.CircuitBreakerConfig{
+			Enabled: true,
+		},
+	}
+
+	err := validateConfig(cfg)
+	assert.Error(t, err)
+	assert.Equal(t, "\ncircuit breaker failure percentage is expected to be between 1 and 100. Provided value is 0\ncircuit breaker min requests is expected to be between 1 and 100000. Provided value is 0\ncircuit breaker window is expected to be between 1 and 3600. Provided value is 0\ncircuit breaker open duration is expected to be between 1 and 3600. Provided value is 0", err.Error())
+
+	cfg.CircuitBreaker = webapi.DefaultPluginConfig.CircuitBreaker
+	cfg.CircuitBreaker.Enabled = true
+	assert.NoError(t, validateConfig(cfg))
+}
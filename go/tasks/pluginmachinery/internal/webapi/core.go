@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"golang.org/x/time/rate"
 	"k8s.io/utils/clock"
 
 	stdErrs "github.com/flyteorg/flytestdlib/errors"
@@ -16,29 +18,47 @@ import (
 	"github.com/flyteorg/flytestdlib/logger"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 )
 
 const (
-	pluginStateVersion = 1
-	minCacheSize       = 10
-	maxCacheSize       = 500000
-	minWorkers         = 1
-	maxWorkers         = 100
-	minSyncDuration    = 5 * time.Second
-	maxSyncDuration    = time.Hour
-	minBurst           = 5
-	maxBurst           = 10000
-	minQPS             = 1
-	maxQPS             = 100000
+	pluginStateVersion   = 1
+	minCacheSize         = 10
+	maxCacheSize         = 500000
+	minWorkers           = 1
+	maxWorkers           = 100
+	minSyncDuration      = 5 * time.Second
+	maxSyncDuration      = time.Hour
+	minBurst             = 5
+	maxBurst             = 10000
+	minQPS               = 1
+	maxQPS               = 100000
+	minFailurePercentage = 1
+	maxFailurePercentage = 100
+	minRequests          = 1
+	maxRequests          = 100000
+	minCircuitDuration   = time.Second
+	maxCircuitDuration   = time.Hour
 )
 
+// pluginStateMigrator reads the persisted State. Register migrations here when the State changes in incompatible
+// ways.
+var pluginStateMigrator = core.NewPluginStateMigrator(pluginStateVersion)
+
 type CorePlugin struct {
-	id             string
-	p              webapi.AsyncPlugin
-	cache          cache.AutoRefresh
-	tokenAllocator tokenAllocator
-	metrics        Metrics
+	id               string
+	p                webapi.AsyncPlugin
+	cache            cache.AutoRefresh
+	tokenAllocator   tokenAllocator
+	writeRateLimiter *rate.Limiter
+	watcher          *resourceWatcher
+	breaker          *circuitBreaker
+	clock            clock.Clock
+	metrics          Metrics
+	// Stops watching the remote service and refreshing the cache.
+	stop           context.CancelFunc
+	refreshWorkers *utils.RefreshWorkers
 }
 
 func (c CorePlugin) unmarshalState(ctx context.Context, stateReader core.PluginStateReader) (State, error) {
@@ -47,13 +67,12 @@ func (c CorePlugin) unmarshalState(ctx context.Context, stateReader core.PluginS
 
 	// We assume here that the first time this function is called, the custom state we get back is whatever we passed in,
 	// namely the zero-value of our struct.
-	if _, err := stateReader.Get(&existingState); err != nil {
+	if err := pluginStateMigrator.Get(stateReader, &existingState); err != nil {
 		c.metrics.FailedUnmarshalState.Inc(ctx)
 		logger.Errorf(ctx, "AsyncPlugin [%v] failed to unmarshal custom state. Error: %v",
 			c.GetID(), err)
 
-		return State{}, errors.Wrapf(errors.CorruptedPluginState, err,
-			"Failed to unmarshal custom state in Handle")
+		return State{}, err
 	}
 
 	t.Stop()
@@ -68,6 +87,50 @@ func (c CorePlugin) GetProperties() core.PluginProperties {
 	return core.PluginProperties{}
 }
 
+// ValidateTask validates the task template with the AsyncPlugin if it implements core.TaskValidator.
+func (c CorePlugin) ValidateTask(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	if validator, ok := c.p.(core.TaskValidator); ok {
+		return validator.ValidateTask(ctx, taskTemplate)
+	}
+
+	return nil
+}
+
+// CheckHealth reports the plugin as unhealthy while the circuit breaker suspends calls to the remote service, or if the
+// AsyncPlugin implements core.HealthChecker and reports itself as unhealthy.
+func (c CorePlugin) CheckHealth(ctx context.Context) error {
+	if err := c.breaker.checkHealth(); err != nil {
+		return err
+	}
+
+	if checker, ok := c.p.(core.HealthChecker); ok {
+		return checker.CheckHealth(ctx)
+	}
+
+	return nil
+}
+
+// Stop stops watching the remote service and refreshing the cache, waits for the running syncs to return, then stops
+// the AsyncPlugin if it implements core.Stopper.
+func (c CorePlugin) Stop(ctx context.Context) error {
+	if c.stop != nil {
+		c.stop()
+	}
+
+	if err := c.refreshWorkers.Stop(ctx); err != nil {
+		return err
+	}
+
+	if stopper, ok := c.p.(core.Stopper); ok {
+		if err := stopper.Stop(ctx); err != nil {
+			return err
+		}
+	}
+
+	logger.Infof(ctx, "Stopped plugin [%v].", c.id)
+	return nil
+}
+
 func (c CorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
 	incomingState, err := c.unmarshalState(ctx, tCtx.PluginStateReader())
 	if err != nil {
@@ -81,12 +144,21 @@ func (c CorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext)
 		if len(c.p.GetConfig().ResourceQuotas) > 0 {
 			nextState, phaseInfo, err = c.tokenAllocator.allocateToken(ctx, c.p, tCtx, &incomingState, c.metrics)
 		} else {
-			nextState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, &incomingState)
+			nextState, phaseInfo, err = c.throttledLaunch(ctx, tCtx, &incomingState)
 		}
 	case PhaseAllocationTokenAcquired:
-		nextState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, &incomingState)
+		c.tokenAllocator.renewToken(ctx, c.p, tCtx, c.metrics)
+		nextState, phaseInfo, err = c.throttledLaunch(ctx, tCtx, &incomingState)
 	case PhaseResourcesCreated:
-		nextState, phaseInfo, err = monitor(ctx, tCtx, c.p, c.cache, &incomingState)
+		c.tokenAllocator.renewToken(ctx, c.p, tCtx, c.metrics)
+		nextState, phaseInfo, err = c.monitorWithTimeout(ctx, tCtx, &incomingState)
+	case PhaseUserFailure:
+		if abortedOnTimeout(incomingState) {
+			// The resource has already been deleted, report the timeout again.
+			nextState, phaseInfo = &incomingState, terminalPhaseInfo(incomingState)
+		} else {
+			nextState, phaseInfo, err = monitor(ctx, tCtx, c.p, c.cache, c.watcher, &incomingState)
+		}
 	}
 
 	if err != nil {
@@ -100,14 +172,129 @@ func (c CorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext)
 	return core.DoTransitionType(core.TransitionTypeBarrier, phaseInfo), nil
 }
 
+// throttledLaunch creates the resource in the remote service unless the write rate limiter requires backing off or the
+// circuit breaker is open.
+func (c CorePlugin) throttledLaunch(ctx context.Context, tCtx core.TaskExecutionContext, state *State) (
+	newState *State, phaseInfo core.PhaseInfo, err error) {
+	if !allow(ctx, c.writeRateLimiter, c.metrics.WriteThrottled) {
+		return state, writeThrottledPhaseInfo(), nil
+	}
+
+	if !c.breaker.allow(ctx) {
+		return state, circuitOpenPhaseInfo(), nil
+	}
+
+	state.LaunchTime = c.clock.Now()
+	newState, phaseInfo, err = launch(ctx, c.p, tCtx, c.cache, state)
+	c.breaker.record(ctx, err)
+	if err == nil && c.watcher != nil && newState.Phase == PhaseResourcesCreated {
+		c.watcher.track(newState.ResourceMeta, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(),
+			tCtx.TaskRefreshIndicator())
+	}
+
+	return newState, phaseInfo, err
+}
+
+// monitorWithTimeout checks the status of the resource and, if it's still running past the task timeout, deletes it.
+func (c CorePlugin) monitorWithTimeout(ctx context.Context, tCtx core.TaskExecutionContext, state *State) (
+	newState *State, phaseInfo core.PhaseInfo, err error) {
+	if c.watcher != nil {
+		// Tracking is idempotent and ensures pushed updates are matched after the process restarts.
+		c.watcher.track(state.ResourceMeta, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(),
+			tCtx.TaskRefreshIndicator())
+	}
+
+	newState, phaseInfo, err = monitor(ctx, tCtx, c.p, c.cache, c.watcher, state)
+	if err != nil || phaseInfo.Phase().IsTerminal() {
+		return newState, phaseInfo, err
+	}
+
+	timedOut, err := c.hasTimedOut(ctx, tCtx, *newState)
+	if err != nil {
+		return nil, core.PhaseInfo{}, err
+	}
+
+	if timedOut {
+		return c.abortOnTimeout(ctx, tCtx, newState)
+	}
+
+	return newState, phaseInfo, nil
+}
+
+// hasTimedOut checks whether the resource has been running in the remote service for longer than the timeout defined
+// in the task template metadata.
+func (c CorePlugin) hasTimedOut(ctx context.Context, tCtx core.TaskExecutionContext, state State) (bool, error) {
+	if state.LaunchTime.IsZero() {
+		return false, nil
+	}
+
+	taskTemplate, err := tCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	timeout := taskTemplate.GetMetadata().GetTimeout()
+	if timeout == nil || (timeout.GetSeconds() <= 0 && timeout.GetNanos() <= 0) {
+		return false, nil
+	}
+
+	deadline := state.LaunchTime.Add(time.Duration(timeout.GetSeconds())*time.Second +
+		time.Duration(timeout.GetNanos()))
+	return c.clock.Now().After(deadline), nil
+}
+
+// abortOnTimeout deletes the resource in the remote service and fails the task with a retryable error.
+func (c CorePlugin) abortOnTimeout(ctx context.Context, tCtx core.TaskExecutionContext, state *State) (
+	newState *State, phaseInfo core.PhaseInfo, err error) {
+	logger.Infof(ctx, "Resource [%v] launched at [%v] exceeded its timeout. Attempting to delete it.",
+		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), state.LaunchTime)
+
+	if !allow(ctx, c.writeRateLimiter, c.metrics.WriteThrottled) {
+		return state, writeThrottledPhaseInfo(), nil
+	}
+
+	err = c.p.Delete(ctx, newPluginContext(state.ResourceMeta, nil, "Timeout", tCtx))
+	if err != nil {
+		logger.Errorf(ctx, "Failed to delete timed out resource [%v]. Error: %v",
+			tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
+		return nil, core.PhaseInfo{}, err
+	}
+
+	c.metrics.ResourceTimedOut.Inc(ctx)
+	phaseInfo = core.PhaseInfoRetryableFailure(string(errors.TaskTimedOut),
+		fmt.Sprintf("Task exceeded its timeout. It was launched at [%v].", state.LaunchTime), nil)
+
+	// Move to a terminal phase so that the resource is neither polled nor deleted again.
+	state.Phase = PhaseUserFailure
+	state.TerminalPhase = phaseInfo.Phase()
+	state.ExecutionError = phaseInfo.Err()
+	return state, phaseInfo, nil
+}
+
+// abortedOnTimeout returns whether the resource was deleted because the task exceeded its timeout.
+func abortedOnTimeout(state State) bool {
+	return state.Phase == PhaseUserFailure && state.TerminalPhase != core.PhaseUndefined
+}
+
 func (c CorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
 	incomingState, err := c.unmarshalState(ctx, tCtx.PluginStateReader())
 	if err != nil {
 		return err
 	}
 
+	if abortedOnTimeout(incomingState) {
+		logger.Infof(ctx, "Resource [%v] has already been deleted after it timed out.",
+			tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+		return nil
+	}
+
 	logger.Infof(ctx, "Attempting to abort resource [%v].", tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID())
 
+	if err = wait(ctx, c.writeRateLimiter, c.metrics.WriteThrottled); err != nil {
+		logger.Errorf(ctx, "Failed to wait on write rate limiter. Error: %v", err)
+		return err
+	}
+
 	err = c.p.Delete(ctx, newPluginContext(incomingState.ResourceMeta, nil, "Aborted", tCtx))
 	if err != nil {
 		logger.Errorf(ctx, "Failed to abort some resources [%v]. Error: %v",
@@ -118,15 +305,62 @@ func (c CorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) e
 	return nil
 }
 
+// writeThrottledPhaseInfo is reported when a write to the remote service is throttled. The state is left unchanged so
+// the write is retried in the next round.
+func writeThrottledPhaseInfo() core.PhaseInfo {
+	return core.PhaseInfoWaitingForResources(time.Now(), core.DefaultPhaseVersion,
+		"Throttled by the write rate limiter. The request will be retried.")
+}
+
 func (c CorePlugin) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	if c.watcher != nil {
+		c.watcher.untrack(tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	}
+
+	var finalizeErr error
+	if finalizer, ok := c.p.(webapi.Finalizer); ok {
+		incomingState, err := c.unmarshalState(ctx, tCtx.PluginStateReader())
+		if err != nil {
+			return err
+		}
+
+		if incomingState.Phase == PhaseSucceeded && incomingState.ResourceMeta != nil {
+			finalizeErr = c.finalizeResource(ctx, finalizer, tCtx, incomingState)
+		}
+	}
+
 	if len(c.p.GetConfig().ResourceQuotas) == 0 {
 		// If there are no defined quotas, there is nothing to cleanup.
-		return nil
+		return finalizeErr
 	}
 
 	logger.Infof(ctx, "Attempting to finalize resource [%v].",
 		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
-	return c.tokenAllocator.releaseToken(ctx, c.p, tCtx, c.metrics)
+	if err := c.tokenAllocator.releaseToken(ctx, c.p, tCtx, c.metrics); err != nil {
+		return err
+	}
+
+	return finalizeErr
+}
+
+// finalizeResource lets the plugin clean up after the resource. A failed cleanup is returned so that Finalize is called
+// again.
+func (c CorePlugin) finalizeResource(ctx context.Context, finalizer webapi.Finalizer, tCtx core.TaskExecutionContext,
+	state State) error {
+	generatedName := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	if err := wait(ctx, c.writeRateLimiter, c.metrics.WriteThrottled); err != nil {
+		return err
+	}
+
+	if err := finalizer.Finalize(ctx, newPluginContext(state.ResourceMeta, nil, "", tCtx)); err != nil {
+		logger.Warnf(ctx, "Failed to finalize resource [%v]. Error: %v", generatedName, err)
+		c.metrics.ResourceFinalizeFailed.Inc(ctx)
+		return errors.Wrapf(errors.DownstreamSystemError, err, "Failed to finalize resource [%v]", generatedName)
+	}
+
+	logger.Infof(ctx, "Finalized resource [%v].", generatedName)
+	c.metrics.ResourceFinalized.Inc(ctx)
+	return nil
 }
 
 func validateRangeInt(fieldName string, min, max, provided int) error {
@@ -155,10 +389,25 @@ func validateConfig(cfg webapi.PluginConfig) error {
 	errs.Append(validateRangeInt("read qps", minQPS, maxQPS, cfg.ReadRateLimiter.QPS))
 	errs.Append(validateRangeInt("write burst", minBurst, maxBurst, cfg.WriteRateLimiter.Burst))
 	errs.Append(validateRangeInt("write qps", minQPS, maxQPS, cfg.WriteRateLimiter.QPS))
+	validateCircuitBreakerConfig(&errs, cfg.CircuitBreaker)
 
 	return errs.ErrorOrDefault()
 }
 
+func validateCircuitBreakerConfig(errs *stdErrs.ErrorCollection, cfg webapi.CircuitBreakerConfig) {
+	if !cfg.Enabled {
+		return
+	}
+
+	errs.Append(validateRangeInt("circuit breaker failure percentage", minFailurePercentage, maxFailurePercentage,
+		cfg.FailurePercentage))
+	errs.Append(validateRangeInt("circuit breaker min requests", minRequests, maxRequests, cfg.MinRequests))
+	errs.Append(validateRangeFloat64("circuit breaker window", minCircuitDuration.Seconds(),
+		maxCircuitDuration.Seconds(), cfg.Window.Seconds()))
+	errs.Append(validateRangeFloat64("circuit breaker open duration", minCircuitDuration.Seconds(),
+		maxCircuitDuration.Seconds(), cfg.OpenDuration.Seconds()))
+}
+
 func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.PluginEntry {
 	return core.PluginEntry{
 		ID:                  pluginEntry.ID,
@@ -190,24 +439,50 @@ func createRemotePlugin(pluginEntry webapi.PluginEntry, c clock.Clock) core.Plug
 				}
 			}
 
+			metrics := newMetrics(iCtx.MetricsScope())
+			breaker := newCircuitBreaker(p.GetConfig().CircuitBreaker, c, metrics)
+
+			// The watcher and the cache run until the plugin is stopped.
+			pluginCtx, stop := context.WithCancel(ctx)
+
+			// If the plugin can push updates, start watching before any resource is created.
+			var watcher *resourceWatcher
+			if watchable, ok := p.(webapi.WatchablePlugin); ok {
+				watcher = newResourceWatcher(watchable, metrics)
+				if err = watchable.Watch(pluginCtx, watcher); err != nil {
+					stop()
+					return nil, err
+				}
+			}
+
+			refreshWorkers := &utils.RefreshWorkers{}
 			resourceCache, err := NewResourceCache(ctx, pluginEntry.ID, p, p.GetConfig().Caching,
+				newRateLimiter(p.GetConfig().ReadRateLimiter), metrics, watcher, breaker, refreshWorkers,
 				iCtx.MetricsScope().NewSubScope("cache"))
 
 			if err != nil {
+				stop()
 				return nil, err
 			}
 
-			err = resourceCache.Start(ctx)
+			err = resourceCache.Start(pluginCtx)
 			if err != nil {
+				stop()
 				return nil, err
 			}
 
 			return CorePlugin{
-				id:             pluginEntry.ID,
-				p:              p,
-				cache:          resourceCache,
-				metrics:        newMetrics(iCtx.MetricsScope()),
-				tokenAllocator: newTokenAllocator(c),
+				id:               pluginEntry.ID,
+				p:                p,
+				cache:            resourceCache,
+				metrics:          metrics,
+				tokenAllocator:   newTokenAllocator(c),
+				writeRateLimiter: newRateLimiter(p.GetConfig().WriteRateLimiter),
+				watcher:          watcher,
+				breaker:          breaker,
+				clock:            c,
+				stop:             stop,
+				refreshWorkers:   refreshWorkers,
 			}, nil
 		},
 	}
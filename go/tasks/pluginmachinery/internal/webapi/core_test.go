@@ -2,15 +2,28 @@ package webapi
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/ptypes/duration"
+	testing2 "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 
+	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	webapiMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+	"github.com/flyteorg/flytestdlib/cache"
 	"github.com/flyteorg/flytestdlib/config"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
 )
 
 func Test_validateConfig(t *testing.T) {
@@ -94,3 +107,278 @@ func TestCreateRemotePlugin(t *testing.T) {
 		DefaultForTaskTypes: []core.TaskType{"test-task"},
 	})
 }
+
+func TestCorePlugin_ValidateTask(t *testing.T) {
+	ctx := context.Background()
+	taskTemplate := &idlCore.TaskTemplate{Type: "test-task"}
+	c := CorePlugin{id: "test-async", p: newPluginWithProperties(webapi.PluginConfig{})}
+	assert.NoError(t, core.ValidateTask(ctx, c, taskTemplate))
+
+	validator := &mocks.TaskValidator{}
+	validator.OnValidateTask(ctx, taskTemplate).Return(errors.Errorf(errors.BadTaskSpecification, "bad task"))
+	c.p = struct {
+		webapi.AsyncPlugin
+		core.TaskValidator
+	}{AsyncPlugin: newPluginWithProperties(webapi.PluginConfig{}), TaskValidator: validator}
+	assert.Error(t, core.ValidateTask(ctx, c, taskTemplate))
+}
+
+func TestCorePlugin_CheckHealth(t *testing.T) {
+	ctx := context.Background()
+	c := CorePlugin{id: "test-async", p: newPluginWithProperties(webapi.PluginConfig{})}
+	assert.NoError(t, core.CheckPluginHealth(ctx, c))
+
+	t.Run("Circuit open", func(t *testing.T) {
+		c := c
+		c.breaker = newCircuitBreaker(webapi.CircuitBreakerConfig{
+			Enabled:           true,
+			FailurePercentage: 50,
+			MinRequests:       1,
+			Window:            config.Duration{Duration: time.Minute},
+			OpenDuration:      config.Duration{Duration: time.Minute},
+		}, testing2.NewFakeClock(time.Now()), newMetrics(promutils.NewTestScope()))
+		c.breaker.record(ctx, fmt.Errorf("service unavailable"))
+		assert.Error(t, core.CheckPluginHealth(ctx, c))
+	})
+
+	t.Run("Unhealthy plugin", func(t *testing.T) {
+		checker := &mocks.HealthChecker{}
+		checker.OnCheckHealth(ctx).Return(fmt.Errorf("unreachable"))
+		c := c
+		c.p = struct {
+			webapi.AsyncPlugin
+			core.HealthChecker
+		}{AsyncPlugin: newPluginWithProperties(webapi.PluginConfig{}), HealthChecker: checker}
+		assert.Error(t, core.CheckPluginHealth(ctx, c))
+	})
+}
+
+func TestCorePlugin_Stop(t *testing.T) {
+	ctx := context.Background()
+	pluginCtx, stop := context.WithCancel(ctx)
+	refreshWorkers := &utils.RefreshWorkers{}
+
+	// A sync that only returns once the cache is stopped.
+	syncing := make(chan struct{})
+	synced := false
+	syncCb := refreshWorkers.Wrap(func(ctx context.Context, batch cache.Batch) ([]cache.ItemSyncResponse, error) {
+		close(syncing)
+		<-ctx.Done()
+		synced = true
+		return nil, ctx.Err()
+	})
+
+	go func() {
+		_, err := syncCb(pluginCtx, nil)
+		assert.Error(t, err)
+	}()
+
+	<-syncing
+	stopper := &mocks.Stopper{}
+	stopper.OnStop(ctx).Return(nil)
+	c := CorePlugin{
+		id: "test-async",
+		p: struct {
+			webapi.AsyncPlugin
+			core.Stopper
+		}{AsyncPlugin: newPluginWithProperties(webapi.PluginConfig{}), Stopper: stopper},
+		stop:           stop,
+		refreshWorkers: refreshWorkers,
+	}
+
+	assert.NoError(t, core.StopPlugin(ctx, c))
+	assert.True(t, synced)
+	stopper.AssertNumberOfCalls(t, "Stop", 1)
+}
+
+func TestCorePlugin_Handle(t *testing.T) {
+	t.Run("Create throttled", func(t *testing.T) {
+		ctx := context.Background()
+		tCtx, outputState := newTaskExecutionContextWithState(State{Phase: PhaseAllocationTokenAcquired})
+
+		p := newPluginWithProperties(webapi.PluginConfig{})
+		limiter := newRateLimiter(webapi.RateLimiterConfig{QPS: 1, Burst: 1})
+		assert.True(t, limiter.Allow())
+
+		c := CorePlugin{
+			id:               "test-async",
+			p:                p,
+			writeRateLimiter: limiter,
+			metrics:          newMetrics(promutils.NewTestScope()),
+		}
+
+		trns, err := c.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseWaitingForResources, trns.Info().Phase())
+		assert.Equal(t, PhaseAllocationTokenAcquired, outputState.Phase)
+		p.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCorePlugin_Handle_CircuitOpen(t *testing.T) {
+	ctx := context.Background()
+	tCtx, outputState := newTaskExecutionContextWithState(State{Phase: PhaseAllocationTokenAcquired})
+
+	clck := testing2.NewFakeClock(time.Now())
+	breaker := newCircuitBreaker(webapi.CircuitBreakerConfig{
+		Enabled:           true,
+		FailurePercentage: 50,
+		MinRequests:       1,
+		Window:            config.Duration{Duration: time.Minute},
+		OpenDuration:      config.Duration{Duration: time.Minute},
+	}, clck, newMetrics(promutils.NewTestScope()))
+	breaker.record(ctx, fmt.Errorf("service unavailable"))
+
+	p := newPluginWithProperties(webapi.PluginConfig{})
+	c := CorePlugin{
+		id:               "test-async",
+		p:                p,
+		writeRateLimiter: newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}),
+		breaker:          breaker,
+		clock:            clck,
+		metrics:          newMetrics(promutils.NewTestScope()),
+	}
+
+	trns, err := c.Handle(ctx, tCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.PhaseWaitingForResources, trns.Info().Phase())
+	assert.Equal(t, PhaseAllocationTokenAcquired, outputState.Phase)
+	p.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCorePlugin_hasTimedOut(t *testing.T) {
+	ctx := context.Background()
+	tNow := time.Now()
+	c := CorePlugin{clock: testing2.NewFakeClock(tNow)}
+
+	newTaskCtx := func(timeout *duration.Duration) *mocks.TaskExecutionContext {
+		tr := &mocks.TaskReader{}
+		tr.OnReadMatch(mock.Anything).Return(&idlCore.TaskTemplate{
+			Metadata: &idlCore.TaskMetadata{Timeout: timeout},
+		}, nil)
+
+		tCtx := &mocks.TaskExecutionContext{}
+		tCtx.OnTaskReader().Return(tr)
+		return tCtx
+	}
+
+	t.Run("Not launched", func(t *testing.T) {
+		timedOut, err := c.hasTimedOut(ctx, newTaskCtx(&duration.Duration{Seconds: 1}), State{})
+		assert.NoError(t, err)
+		assert.False(t, timedOut)
+	})
+
+	t.Run("No timeout", func(t *testing.T) {
+		timedOut, err := c.hasTimedOut(ctx, newTaskCtx(nil), State{LaunchTime: tNow.Add(-time.Hour)})
+		assert.NoError(t, err)
+		assert.False(t, timedOut)
+	})
+
+	t.Run("Within timeout", func(t *testing.T) {
+		timedOut, err := c.hasTimedOut(ctx, newTaskCtx(&duration.Duration{Seconds: 3600}),
+			State{LaunchTime: tNow.Add(-time.Minute)})
+		assert.NoError(t, err)
+		assert.False(t, timedOut)
+	})
+
+	t.Run("Exceeded timeout", func(t *testing.T) {
+		timedOut, err := c.hasTimedOut(ctx, newTaskCtx(&duration.Duration{Seconds: 30}),
+			State{LaunchTime: tNow.Add(-time.Minute)})
+		assert.NoError(t, err)
+		assert.True(t, timedOut)
+	})
+}
+
+func TestCorePlugin_abortOnTimeout(t *testing.T) {
+	ctx := context.Background()
+	tCtx, _ := newTaskExecutionContextWithState(State{})
+	state := &State{
+		Phase:        PhaseResourcesCreated,
+		ResourceMeta: "abc",
+		LaunchTime:   time.Now().Add(-time.Hour),
+	}
+
+	p := newPluginWithProperties(webapi.PluginConfig{})
+	p.OnDelete(ctx, newPluginContext("abc", nil, "Timeout", tCtx)).Return(nil)
+
+	c := CorePlugin{
+		p:                p,
+		writeRateLimiter: newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}),
+		metrics:          newMetrics(promutils.NewTestScope()),
+	}
+
+	newState, phaseInfo, err := c.abortOnTimeout(ctx, tCtx, state)
+	assert.NoError(t, err)
+	assert.Equal(t, PhaseUserFailure, newState.Phase)
+	assert.Equal(t, core.PhaseRetryableFailure, phaseInfo.Phase())
+	assert.Equal(t, string(errors.TaskTimedOut), phaseInfo.Err().GetCode())
+	p.AssertNumberOfCalls(t, "Delete", 1)
+
+	t.Run("Re-entry", func(t *testing.T) {
+		tCtx, outputState := newTaskExecutionContextWithState(*newState)
+		trns, err := c.Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRetryableFailure, trns.Info().Phase())
+		assert.Equal(t, string(errors.TaskTimedOut), trns.Info().Err().GetCode())
+		assert.Equal(t, *newState, *outputState)
+
+		assert.NoError(t, c.Abort(ctx, tCtx))
+		p.AssertNumberOfCalls(t, "Delete", 1)
+	})
+}
+
+type finalizablePlugin struct {
+	*webapiMocks.AsyncPlugin
+	*webapiMocks.Finalizer
+}
+
+func TestCorePlugin_Finalize(t *testing.T) {
+	ctx := context.Background()
+	newCorePlugin := func(finalizer *webapiMocks.Finalizer) CorePlugin {
+		p := newPluginWithProperties(webapi.PluginConfig{})
+
+		return CorePlugin{
+			p:                finalizablePlugin{AsyncPlugin: p, Finalizer: finalizer},
+			writeRateLimiter: newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}),
+			metrics:          newMetrics(promutils.NewTestScope()),
+		}
+	}
+
+	t.Run("Succeeded", func(t *testing.T) {
+		tCtx, _ := newTaskExecutionContextWithState(State{Phase: PhaseSucceeded, ResourceMeta: "abc"})
+		finalizer := &webapiMocks.Finalizer{}
+		finalizer.OnFinalize(ctx, newPluginContext("abc", nil, "", tCtx)).Return(nil)
+
+		c := newCorePlugin(finalizer)
+		assert.NoError(t, c.Finalize(ctx, tCtx))
+		finalizer.AssertNumberOfCalls(t, "Finalize", 1)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		tCtx, _ := newTaskExecutionContextWithState(State{Phase: PhaseSucceeded, ResourceMeta: "abc"})
+		finalizer := &webapiMocks.Finalizer{}
+		finalizer.OnFinalize(ctx, newPluginContext("abc", nil, "", tCtx)).Return(fmt.Errorf("unavailable")).Once()
+		finalizer.OnFinalize(ctx, newPluginContext("abc", nil, "", tCtx)).Return(nil).Once()
+
+		// The failure is returned so that the task is finalized again, without retrying in the meantime.
+		c := newCorePlugin(finalizer)
+		err := c.Finalize(ctx, tCtx)
+		assert.Error(t, err)
+		code, found := stdErrors.GetErrorCode(err)
+		assert.True(t, found)
+		assert.Equal(t, errors.DownstreamSystemError, code)
+		finalizer.AssertNumberOfCalls(t, "Finalize", 1)
+
+		assert.NoError(t, c.Finalize(ctx, tCtx))
+		finalizer.AssertNumberOfCalls(t, "Finalize", 2)
+	})
+
+	t.Run("Not succeeded", func(t *testing.T) {
+		tCtx, _ := newTaskExecutionContextWithState(State{Phase: PhaseUserFailure, ResourceMeta: "abc"})
+		finalizer := &webapiMocks.Finalizer{}
+
+		c := newCorePlugin(finalizer)
+		assert.NoError(t, c.Finalize(ctx, tCtx))
+		finalizer.AssertNotCalled(t, "Finalize", mock.Anything, mock.Anything)
+	})
+}
@@ -12,11 +12,21 @@ type Metrics struct {
 	Scope                   promutils.Scope
 	ResourceReleased        labeled.Counter
 	ResourceReleaseFailed   labeled.Counter
+	LeaseRenewFailed        labeled.Counter
 	AllocationGranted       labeled.Counter
 	AllocationNotGranted    labeled.Counter
 	ResourceWaitTime        prometheus.Summary
 	SucceededUnmarshalState labeled.StopWatch
 	FailedUnmarshalState    labeled.Counter
+	ReadThrottled           labeled.Counter
+	WriteThrottled          labeled.Counter
+	ResourceTimedOut        labeled.Counter
+	PushedUpdateReceived    labeled.Counter
+	PushedUpdateDropped     labeled.Counter
+	CircuitBreakerState     prometheus.Gauge
+	CircuitBreakerRejected  labeled.Counter
+	ResourceFinalized       labeled.Counter
+	ResourceFinalizeFailed  labeled.Counter
 }
 
 var (
@@ -30,6 +40,8 @@ func newMetrics(scope promutils.Scope) Metrics {
 			"Resource allocation token released", scope, labeled.EmitUnlabeledMetric),
 		ResourceReleaseFailed: labeled.NewCounter("resource_release_failed",
 			"Error releasing allocation token", scope, labeled.EmitUnlabeledMetric),
+		LeaseRenewFailed: labeled.NewCounter("resource_lease_renew_failed",
+			"Error renewing the lease of an allocation token", scope, labeled.EmitUnlabeledMetric),
 		AllocationGranted: labeled.NewCounter("allocation_grant_success",
 			"Allocation request granted", scope, labeled.EmitUnlabeledMetric),
 		AllocationNotGranted: labeled.NewCounter("allocation_grant_failed",
@@ -40,5 +52,23 @@ func newMetrics(scope promutils.Scope) Metrics {
 			time.Millisecond, scope),
 		FailedUnmarshalState: labeled.NewCounter("unmarshal_state_failed",
 			"Failed to unmarshal state", scope, labeled.EmitUnlabeledMetric),
+		ReadThrottled: labeled.NewCounter("read_throttled",
+			"Read from the remote service delayed by the read rate limiter", scope, labeled.EmitUnlabeledMetric),
+		WriteThrottled: labeled.NewCounter("write_throttled",
+			"Write to the remote service delayed by the write rate limiter", scope, labeled.EmitUnlabeledMetric),
+		ResourceTimedOut: labeled.NewCounter("resource_timed_out",
+			"Resource deleted because it exceeded the task timeout", scope, labeled.EmitUnlabeledMetric),
+		PushedUpdateReceived: labeled.NewCounter("pushed_update_received",
+			"Resource update pushed by the plugin for a tracked resource", scope, labeled.EmitUnlabeledMetric),
+		PushedUpdateDropped: labeled.NewCounter("pushed_update_dropped",
+			"Resource update pushed by the plugin for an untracked resource", scope, labeled.EmitUnlabeledMetric),
+		CircuitBreakerState: scope.MustNewGauge("circuit_breaker_state",
+			"State of the circuit breaker for calls to the remote service (0: closed, 1: half-open, 2: open)"),
+		CircuitBreakerRejected: labeled.NewCounter("circuit_breaker_rejected",
+			"Call to the remote service rejected by the circuit breaker", scope, labeled.EmitUnlabeledMetric),
+		ResourceFinalized: labeled.NewCounter("resource_finalize_success",
+			"Resource cleaned up by the plugin after it succeeded", scope, labeled.EmitUnlabeledMetric),
+		ResourceFinalizeFailed: labeled.NewCounter("resource_finalize_failed",
+			"Failed cleanup of a resource by the plugin", scope, labeled.EmitUnlabeledMetric),
 	}
 }
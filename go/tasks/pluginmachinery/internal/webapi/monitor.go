@@ -10,14 +10,14 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 )
 
-func monitor(ctx context.Context, tCtx core.TaskExecutionContext, p Client, cache cache.AutoRefresh, state *State) (
-	newState *State, phaseInfo core.PhaseInfo, err error) {
+func monitor(ctx context.Context, tCtx core.TaskExecutionContext, p Client, cache cache.AutoRefresh,
+	watcher *resourceWatcher, state *State) (newState *State, phaseInfo core.PhaseInfo, err error) {
 	newCacheItem := CacheItem{
 		State: *state,
 	}
 
-	item, err := cache.GetOrCreate(
-		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), newCacheItem)
+	cacheID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	item, err := cache.GetOrCreate(cacheID, newCacheItem)
 	if err != nil {
 		return nil, core.PhaseInfo{}, err
 	}
@@ -29,6 +29,11 @@ func monitor(ctx context.Context, tCtx core.TaskExecutionContext, p Client, cach
 			errors.CacheFailed, "Failed to cast [%v]", cacheItem)
 	}
 
+	// A pushed update is at least as recent as the last sync.
+	if pushed, found := watcher.peek(cacheID); found {
+		cacheItem.Resource = pushed
+	}
+
 	// If the cache has not syncd yet, just return
 	if cacheItem.Resource == nil {
 		return state, core.PhaseInfoRunning(0, nil), nil
@@ -0,0 +1,55 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"golang.org/x/time/rate"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+// newRateLimiter creates a token-bucket rate limiter that allows up to QPS calls per second with bursts of up to Burst
+// calls.
+func newRateLimiter(cfg webapi.RateLimiterConfig) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(cfg.QPS), cfg.Burst)
+}
+
+// allow reports whether a call may happen now without blocking. Calls that are not allowed are counted as throttled
+// and the caller is expected to back off and retry in a later round.
+func allow(ctx context.Context, limiter *rate.Limiter, throttled labeled.Counter) bool {
+	if limiter.Allow() {
+		return true
+	}
+
+	throttled.Inc(ctx)
+	return false
+}
+
+// wait blocks until the limiter permits a call or the context is done. Calls that had to wait are counted as
+// throttled.
+func wait(ctx context.Context, limiter *rate.Limiter, throttled labeled.Counter) error {
+	r := limiter.Reserve()
+	if !r.OK() {
+		return fmt.Errorf("rate limiter burst [%v] does not allow any calls", limiter.Burst())
+	}
+
+	delay := r.Delay()
+	if delay == 0 {
+		return nil
+	}
+
+	throttled.Inc(ctx)
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,44 @@
+package webapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+func Test_allow(t *testing.T) {
+	ctx := context.Background()
+	metrics := newMetrics(promutils.NewTestScope())
+	limiter := newRateLimiter(webapi.RateLimiterConfig{QPS: 1, Burst: 2})
+
+	assert.True(t, allow(ctx, limiter, metrics.WriteThrottled))
+	assert.True(t, allow(ctx, limiter, metrics.WriteThrottled))
+	assert.False(t, allow(ctx, limiter, metrics.WriteThrottled))
+}
+
+func Test_wait(t *testing.T) {
+	metrics := newMetrics(promutils.NewTestScope())
+
+	t.Run("Within burst", func(t *testing.T) {
+		limiter := newRateLimiter(webapi.RateLimiterConfig{QPS: 1, Burst: 1})
+		assert.NoError(t, wait(context.Background(), limiter, metrics.ReadThrottled))
+	})
+
+	t.Run("Context cancelled while throttled", func(t *testing.T) {
+		limiter := newRateLimiter(webapi.RateLimiterConfig{QPS: 1, Burst: 1})
+		assert.True(t, limiter.Allow())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Error(t, wait(ctx, limiter, metrics.ReadThrottled))
+	})
+
+	t.Run("Zero burst", func(t *testing.T) {
+		limiter := newRateLimiter(webapi.RateLimiterConfig{QPS: 1, Burst: 0})
+		assert.Error(t, wait(context.Background(), limiter, metrics.ReadThrottled))
+	})
+}
@@ -3,6 +3,9 @@ package webapi
 import (
 	"time"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 )
 
@@ -56,4 +59,14 @@ type State struct {
 
 	// The time the execution first requests for an allocation token
 	AllocationTokenRequestStartTime time.Time `json:"allocationTokenRequestStartTime,omitempty"`
+
+	// The time the resource was created in the remote service. It's used to enforce the task timeout.
+	LaunchTime time.Time `json:"launchTime,omitempty"`
+
+	// The phase a SyncPlugin returned when Do completed. It's persisted so that re-evaluating the task doesn't invoke
+	// Do more than once.
+	TerminalPhase core.Phase `json:"terminalPhase,omitempty"`
+
+	// The error a SyncPlugin returned alongside a failed TerminalPhase.
+	ExecutionError *idlCore.ExecutionError `json:"executionError,omitempty"`
 }
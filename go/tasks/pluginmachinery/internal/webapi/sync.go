@@ -0,0 +1,234 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"golang.org/x/time/rate"
+	"k8s.io/utils/clock"
+
+	stdErrs "github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+// SyncCorePlugin adapts a webapi.SyncPlugin into a core.Plugin. It allocates tokens (if the plugin defines resource
+// quotas), throttles calls to Do according to the write rate limiter and circuit breaker and persists the terminal phase returned by Do.
+type SyncCorePlugin struct {
+	id             string
+	p              webapi.SyncPlugin
+	rateLimiter    *rate.Limiter
+	breaker        *circuitBreaker
+	tokenAllocator tokenAllocator
+	metrics        Metrics
+}
+
+func (c SyncCorePlugin) unmarshalState(ctx context.Context, stateReader core.PluginStateReader) (State, error) {
+	t := c.metrics.SucceededUnmarshalState.Start(ctx)
+	existingState := State{}
+
+	if err := pluginStateMigrator.Get(stateReader, &existingState); err != nil {
+		c.metrics.FailedUnmarshalState.Inc(ctx)
+		logger.Errorf(ctx, "SyncPlugin [%v] failed to unmarshal custom state. Error: %v",
+			c.GetID(), err)
+
+		return State{}, err
+	}
+
+	t.Stop()
+	return existingState, nil
+}
+
+func (c SyncCorePlugin) GetID() string {
+	return c.id
+}
+
+func (c SyncCorePlugin) GetProperties() core.PluginProperties {
+	return core.PluginProperties{}
+}
+
+// ValidateTask validates the task template with the SyncPlugin if it implements core.TaskValidator.
+func (c SyncCorePlugin) ValidateTask(ctx context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	if validator, ok := c.p.(core.TaskValidator); ok {
+		return validator.ValidateTask(ctx, taskTemplate)
+	}
+
+	return nil
+}
+
+// CheckHealth reports the plugin as unhealthy while the circuit breaker suspends calls to the remote service, or if the
+// SyncPlugin implements core.HealthChecker and reports itself as unhealthy.
+func (c SyncCorePlugin) CheckHealth(ctx context.Context) error {
+	if err := c.breaker.checkHealth(); err != nil {
+		return err
+	}
+
+	if checker, ok := c.p.(core.HealthChecker); ok {
+		return checker.CheckHealth(ctx)
+	}
+
+	return nil
+}
+
+// Stop stops the SyncPlugin if it implements core.Stopper. Calls to Do are made from Handle, there's no background
+// processing to stop otherwise.
+func (c SyncCorePlugin) Stop(ctx context.Context) error {
+	if stopper, ok := c.p.(core.Stopper); ok {
+		if err := stopper.Stop(ctx); err != nil {
+			return err
+		}
+	}
+
+	logger.Infof(ctx, "Stopped plugin [%v].", c.id)
+	return nil
+}
+
+func (c SyncCorePlugin) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (core.Transition, error) {
+	incomingState, err := c.unmarshalState(ctx, tCtx.PluginStateReader())
+	if err != nil {
+		return core.UnknownTransition, err
+	}
+
+	var nextState *State
+	var phaseInfo core.PhaseInfo
+	switch incomingState.Phase {
+	case PhaseNotStarted:
+		if len(c.p.GetConfig().ResourceQuotas) > 0 {
+			nextState, phaseInfo, err = c.tokenAllocator.allocateToken(ctx, c.p, tCtx, &incomingState, c.metrics)
+		} else {
+			nextState, phaseInfo, err = c.do(ctx, tCtx, &incomingState)
+		}
+	case PhaseAllocationTokenAcquired:
+		nextState, phaseInfo, err = c.do(ctx, tCtx, &incomingState)
+	case PhaseSucceeded, PhaseUserFailure, PhaseSystemFailure:
+		nextState, phaseInfo = &incomingState, terminalPhaseInfo(incomingState)
+	default:
+		return core.UnknownTransition, errors.Errorf(errors.CorruptedPluginState,
+			"SyncPlugin [%v] found unexpected phase [%v] in state", c.GetID(), incomingState.Phase)
+	}
+
+	if err != nil {
+		return core.UnknownTransition, err
+	}
+
+	if err := tCtx.PluginStateWriter().Put(pluginStateVersion, nextState); err != nil {
+		return core.UnknownTransition, err
+	}
+
+	return core.DoTransitionType(core.TransitionTypeBarrier, phaseInfo), nil
+}
+
+func (c SyncCorePlugin) do(ctx context.Context, tCtx core.TaskExecutionContext, state *State) (
+	newState *State, phaseInfo core.PhaseInfo, err error) {
+	if !allow(ctx, c.rateLimiter, c.metrics.WriteThrottled) {
+		return state, writeThrottledPhaseInfo(), nil
+	}
+
+	if !c.breaker.allow(ctx) {
+		return state, circuitOpenPhaseInfo(), nil
+	}
+
+	phaseInfo, err = c.p.Do(ctx, tCtx)
+	c.breaker.record(ctx, err)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to execute [%v]. Error: %v",
+			tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
+		return nil, core.PhaseInfo{}, err
+	}
+
+	if !phaseInfo.Phase().IsTerminal() {
+		return state, phaseInfo, nil
+	}
+
+	newPhase, err := ToPluginPhase(phaseInfo.Phase())
+	if err != nil {
+		return nil, core.PhaseInfoUndefined, err
+	}
+
+	state.Phase = newPhase
+	state.TerminalPhase = phaseInfo.Phase()
+	state.ExecutionError = phaseInfo.Err()
+	return state, phaseInfo, nil
+}
+
+// terminalPhaseInfo reconstructs the phase returned by a previous invocation of Do from the persisted state.
+func terminalPhaseInfo(state State) core.PhaseInfo {
+	if state.TerminalPhase == core.PhaseSuccess {
+		return core.PhaseInfoSuccess(nil)
+	}
+
+	return core.PhaseInfoFailed(state.TerminalPhase, state.ExecutionError, nil)
+}
+
+func (c SyncCorePlugin) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	// Do is synchronous, there is no remote resource left to abort.
+	return nil
+}
+
+func (c SyncCorePlugin) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
+	if len(c.p.GetConfig().ResourceQuotas) == 0 {
+		// If there are no defined quotas, there is nothing to cleanup.
+		return nil
+	}
+
+	logger.Infof(ctx, "Attempting to finalize resource [%v].",
+		tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName())
+	return c.tokenAllocator.releaseToken(ctx, c.p, tCtx, c.metrics)
+}
+
+func validateSyncConfig(cfg webapi.PluginConfig) error {
+	errs := stdErrs.ErrorCollection{}
+	errs.Append(validateRangeInt("write burst", minBurst, maxBurst, cfg.WriteRateLimiter.Burst))
+	errs.Append(validateRangeInt("write qps", minQPS, maxQPS, cfg.WriteRateLimiter.QPS))
+	validateCircuitBreakerConfig(&errs, cfg.CircuitBreaker)
+
+	return errs.ErrorOrDefault()
+}
+
+func createSyncPlugin(pluginEntry webapi.SyncPluginEntry, c clock.Clock) core.PluginEntry {
+	return core.PluginEntry{
+		ID:                  pluginEntry.ID,
+		RegisteredTaskTypes: pluginEntry.SupportedTaskTypes,
+		IsDefault:           pluginEntry.IsDefault,
+		DefaultForTaskTypes: pluginEntry.DefaultForTaskTypes,
+		LoadPlugin: func(ctx context.Context, iCtx core.SetupContext) (
+			core.Plugin, error) {
+			p, err := pluginEntry.PluginLoader(ctx, iCtx)
+			if err != nil {
+				return nil, err
+			}
+
+			err = validateSyncConfig(p.GetConfig())
+			if err != nil {
+				return nil, fmt.Errorf("config validation failed. Error: %w", err)
+			}
+
+			if quotas := p.GetConfig().ResourceQuotas; len(quotas) > 0 {
+				for ns, quota := range quotas {
+					err := iCtx.ResourceRegistrar().RegisterResourceQuota(ctx, ns, quota)
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			metrics := newMetrics(iCtx.MetricsScope())
+			return SyncCorePlugin{
+				id:             pluginEntry.ID,
+				p:              p,
+				rateLimiter:    newRateLimiter(p.GetConfig().WriteRateLimiter),
+				breaker:        newCircuitBreaker(p.GetConfig().CircuitBreaker, c, metrics),
+				metrics:        metrics,
+				tokenAllocator: newTokenAllocator(c),
+			}, nil
+		},
+	}
+}
+
+func CreateSyncPlugin(pluginEntry webapi.SyncPluginEntry) core.PluginEntry {
+	return createSyncPlugin(pluginEntry, clock.RealClock{})
+}
@@ -0,0 +1,177 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+func newSyncPluginWithProperties(properties webapi.PluginConfig) *mocks.SyncPlugin {
+	m := &mocks.SyncPlugin{}
+	m.OnGetConfig().Return(properties)
+	return m
+}
+
+func newTaskExecutionContextWithState(incomingState State) (*coreMocks.TaskExecutionContext, *State) {
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("my-id")
+
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+
+	stateReader := &coreMocks.PluginStateReader{}
+	stateReader.OnGetStateVersion().Return(pluginStateVersion)
+	stateReader.OnGetMatch(mock.Anything).Return(0, nil).Run(func(args mock.Arguments) {
+		*(args.Get(0).(*State)) = incomingState
+	})
+
+	outputState := &State{}
+	stateWriter := &coreMocks.PluginStateWriter{}
+	stateWriter.OnPutMatch(mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*outputState = *(args.Get(1).(*State))
+	})
+
+	tCtx := &coreMocks.TaskExecutionContext{}
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+	tCtx.OnPluginStateReader().Return(stateReader)
+	tCtx.OnPluginStateWriter().Return(stateWriter)
+	return tCtx, outputState
+}
+
+func newSyncCorePlugin(p webapi.SyncPlugin) SyncCorePlugin {
+	return SyncCorePlugin{
+		id:             "test-sync",
+		p:              p,
+		rateLimiter:    newRateLimiter(webapi.RateLimiterConfig{QPS: 10, Burst: 10}),
+		tokenAllocator: newTokenAllocator(clock.RealClock{}),
+		metrics:        newMetrics(promutils.NewTestScope()),
+	}
+}
+
+func TestSyncCorePlugin_ValidateTask(t *testing.T) {
+	ctx := context.Background()
+	taskTemplate := &idlCore.TaskTemplate{Type: "test-task"}
+	assert.NoError(t, core.ValidateTask(ctx, newSyncCorePlugin(newSyncPluginWithProperties(webapi.PluginConfig{})),
+		taskTemplate))
+
+	validator := &coreMocks.TaskValidator{}
+	validator.OnValidateTask(ctx, taskTemplate).Return(fmt.Errorf("bad task"))
+	assert.Error(t, core.ValidateTask(ctx, newSyncCorePlugin(struct {
+		webapi.SyncPlugin
+		core.TaskValidator
+	}{SyncPlugin: newSyncPluginWithProperties(webapi.PluginConfig{}), TaskValidator: validator}), taskTemplate))
+}
+
+func TestSyncCorePlugin_CheckHealth(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, core.CheckPluginHealth(ctx, newSyncCorePlugin(newSyncPluginWithProperties(webapi.PluginConfig{}))))
+
+	checker := &coreMocks.HealthChecker{}
+	checker.OnCheckHealth(ctx).Return(fmt.Errorf("unreachable"))
+	assert.Error(t, core.CheckPluginHealth(ctx, newSyncCorePlugin(struct {
+		webapi.SyncPlugin
+		core.HealthChecker
+	}{SyncPlugin: newSyncPluginWithProperties(webapi.PluginConfig{}), HealthChecker: checker})))
+}
+
+func TestSyncCorePlugin_Stop(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, core.StopPlugin(ctx, newSyncCorePlugin(newSyncPluginWithProperties(webapi.PluginConfig{}))))
+
+	stopper := &coreMocks.Stopper{}
+	stopper.OnStop(ctx).Return(nil)
+	assert.NoError(t, core.StopPlugin(ctx, newSyncCorePlugin(struct {
+		webapi.SyncPlugin
+		core.Stopper
+	}{SyncPlugin: newSyncPluginWithProperties(webapi.PluginConfig{}), Stopper: stopper})))
+	stopper.AssertNumberOfCalls(t, "Stop", 1)
+}
+
+func TestSyncCorePlugin_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Succeeded", func(t *testing.T) {
+		tCtx, outputState := newTaskExecutionContextWithState(State{})
+		p := newSyncPluginWithProperties(webapi.PluginConfig{})
+		p.OnDo(ctx, tCtx).Return(core.PhaseInfoSuccess(nil), nil)
+
+		trns, err := newSyncCorePlugin(p).Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseSuccess, trns.Info().Phase())
+		assert.Equal(t, PhaseSucceeded, outputState.Phase)
+		assert.Equal(t, core.PhaseSuccess, outputState.TerminalPhase)
+		p.AssertNumberOfCalls(t, "Do", 1)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		tCtx, outputState := newTaskExecutionContextWithState(State{})
+		p := newSyncPluginWithProperties(webapi.PluginConfig{})
+		p.OnDo(ctx, tCtx).Return(core.PhaseInfoFailure("BadInput", "bad input", nil), nil)
+
+		trns, err := newSyncCorePlugin(p).Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhasePermanentFailure, trns.Info().Phase())
+		assert.Equal(t, PhaseUserFailure, outputState.Phase)
+		assert.Equal(t, "BadInput", outputState.ExecutionError.Code)
+	})
+
+	t.Run("System error", func(t *testing.T) {
+		tCtx, _ := newTaskExecutionContextWithState(State{})
+		p := newSyncPluginWithProperties(webapi.PluginConfig{})
+		p.OnDo(ctx, tCtx).Return(core.PhaseInfo{}, fmt.Errorf("connection refused"))
+
+		_, err := newSyncCorePlugin(p).Handle(ctx, tCtx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Already terminal", func(t *testing.T) {
+		tCtx, _ := newTaskExecutionContextWithState(State{
+			Phase:         PhaseUserFailure,
+			TerminalPhase: core.PhaseRetryableFailure,
+		})
+		p := newSyncPluginWithProperties(webapi.PluginConfig{})
+
+		trns, err := newSyncCorePlugin(p).Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRetryableFailure, trns.Info().Phase())
+		p.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Waiting for quota", func(t *testing.T) {
+		tCtx, outputState := newTaskExecutionContextWithState(State{})
+		rm := &coreMocks.ResourceManager{}
+		rm.OnAllocateResourceMatch(ctx, core.ResourceNamespace("ns"), "my-id", mock.Anything).
+			Return(core.AllocationStatusExhausted, nil)
+		tCtx.OnResourceManager().Return(rm)
+
+		p := newSyncPluginWithProperties(webapi.PluginConfig{
+			ResourceQuotas: map[core.ResourceNamespace]int{"ns": 1},
+		})
+		p.OnResourceRequirementsMatch(ctx, mock.Anything).Return("ns", core.ResourceConstraintsSpec{}, nil)
+
+		trns, err := newSyncCorePlugin(p).Handle(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseQueued, trns.Info().Phase())
+		assert.Equal(t, PhaseNotStarted, outputState.Phase)
+		p.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_validateSyncConfig(t *testing.T) {
+	assert.NoError(t, validateSyncConfig(webapi.PluginConfig{
+		WriteRateLimiter: webapi.RateLimiterConfig{QPS: 10, Burst: 100},
+	}))
+
+	assert.Error(t, validateSyncConfig(webapi.PluginConfig{}))
+}
@@ -0,0 +1,122 @@
+package webapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+type trackedResource struct {
+	cacheID string
+	signal  core.SignalAsync
+}
+
+// resourceWatcher implements webapi.ResourceUpdateSink. It keeps track of in-flight resources so that updates pushed by
+// a WatchablePlugin can be matched to the cache item of the owning task. All methods are safe to call on a nil
+// receiver, in which case nothing is tracked and no pushed resources are returned.
+type resourceWatcher struct {
+	plugin  webapi.WatchablePlugin
+	metrics Metrics
+
+	m sync.Mutex
+	// Tracked resources indexed by the key returned by WatchablePlugin.ResourceKey.
+	tracked map[string]trackedResource
+	// Resource keys indexed by cache item ID.
+	keys map[string]string
+	// Pushed resources that haven't been synced into the cache yet, indexed by cache item ID.
+	pushed map[string]webapi.Resource
+}
+
+// track starts matching pushed updates for resourceMeta to the cache item identified by cacheID.
+func (w *resourceWatcher) track(resourceMeta webapi.ResourceMeta, cacheID string, signal core.SignalAsync) {
+	if w == nil {
+		return
+	}
+
+	key := w.plugin.ResourceKey(resourceMeta)
+
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.tracked[key] = trackedResource{cacheID: cacheID, signal: signal}
+	w.keys[cacheID] = key
+}
+
+// untrack stops matching pushed updates to the cache item identified by cacheID.
+func (w *resourceWatcher) untrack(cacheID string) {
+	if w == nil {
+		return
+	}
+
+	w.m.Lock()
+	defer w.m.Unlock()
+	if key, found := w.keys[cacheID]; found {
+		delete(w.tracked, key)
+	}
+
+	delete(w.keys, cacheID)
+	delete(w.pushed, cacheID)
+}
+
+// peek returns the latest pushed resource for the cache item identified by cacheID, if any.
+func (w *resourceWatcher) peek(cacheID string) (webapi.Resource, bool) {
+	if w == nil {
+		return nil, false
+	}
+
+	w.m.Lock()
+	defer w.m.Unlock()
+	r, found := w.pushed[cacheID]
+	return r, found
+}
+
+// consume returns and forgets the latest pushed resource for the cache item identified by cacheID, if any.
+func (w *resourceWatcher) consume(cacheID string) (webapi.Resource, bool) {
+	if w == nil {
+		return nil, false
+	}
+
+	w.m.Lock()
+	defer w.m.Unlock()
+	r, found := w.pushed[cacheID]
+	delete(w.pushed, cacheID)
+	return r, found
+}
+
+func (w *resourceWatcher) Push(ctx context.Context, resourceMeta webapi.ResourceMeta, resource webapi.Resource) bool {
+	key := w.plugin.ResourceKey(resourceMeta)
+
+	w.m.Lock()
+	t, found := w.tracked[key]
+	if found {
+		w.pushed[t.cacheID] = resource
+	}
+	w.m.Unlock()
+
+	if !found {
+		logger.Debugf(ctx, "Dropping pushed update for untracked resource [%v].", key)
+		w.metrics.PushedUpdateDropped.Inc(ctx)
+		return false
+	}
+
+	logger.Debugf(ctx, "Received pushed update for resource [%v] of [%v].", key, t.cacheID)
+	w.metrics.PushedUpdateReceived.Inc(ctx)
+	if t.signal != nil {
+		t.signal(ctx)
+	}
+
+	return true
+}
+
+func newResourceWatcher(plugin webapi.WatchablePlugin, metrics Metrics) *resourceWatcher {
+	return &resourceWatcher{
+		plugin:  plugin,
+		metrics: metrics,
+		tracked: map[string]trackedResource{},
+		keys:    map[string]string{},
+		pushed:  map[string]webapi.Resource{},
+	}
+}
@@ -0,0 +1,136 @@
+package webapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cacheMocks "github.com/flyteorg/flytestdlib/cache/mocks"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+type webhookEvent struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// webhookPlugin is a WatchablePlugin that receives status updates through an in-process HTTP webhook.
+type webhookPlugin struct {
+	*mocks.AsyncPlugin
+	server *httptest.Server
+}
+
+func (p *webhookPlugin) ResourceKey(resourceMeta webapi.ResourceMeta) string {
+	return resourceMeta.(string)
+}
+
+func (p *webhookPlugin) Watch(ctx context.Context, sink webapi.ResourceUpdateSink) error {
+	p.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := webhookEvent{}
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if !sink.Push(r.Context(), event.ID, event.Status) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	go func() {
+		<-ctx.Done()
+		p.server.Close()
+	}()
+
+	return nil
+}
+
+func postEvent(t *testing.T, url string, event webhookEvent) int {
+	raw, err := json.Marshal(event)
+	assert.NoError(t, err)
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(string(raw)))
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	return resp.StatusCode
+}
+
+func TestResourceWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := &webhookPlugin{AsyncPlugin: &mocks.AsyncPlugin{}}
+	watcher := newResourceWatcher(p, newMetrics(promutils.NewTestScope()))
+	assert.NoError(t, p.Watch(ctx, watcher))
+
+	signaled := make(chan struct{}, 1)
+	watcher.track("query-1", "task-1", func(ctx context.Context) {
+		signaled <- struct{}{}
+	})
+
+	t.Run("Untracked resource", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, postEvent(t, p.server.URL, webhookEvent{ID: "query-2", Status: "RUNNING"}))
+		_, found := watcher.peek("task-2")
+		assert.False(t, found)
+	})
+
+	t.Run("Tracked resource", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, postEvent(t, p.server.URL, webhookEvent{ID: "query-1", Status: "SUCCEEDED"}))
+		assert.Len(t, signaled, 1)
+
+		r, found := watcher.peek("task-1")
+		assert.True(t, found)
+		assert.Equal(t, "SUCCEEDED", r)
+	})
+
+	t.Run("Monitor uses pushed update", func(t *testing.T) {
+		tID := &coreMocks.TaskExecutionID{}
+		tID.OnGetGeneratedName().Return("task-1")
+		tMeta := &coreMocks.TaskExecutionMetadata{}
+		tMeta.OnGetTaskExecutionID().Return(tID)
+		tCtx := &coreMocks.TaskExecutionContext{}
+		tCtx.OnTaskExecutionMetadata().Return(tMeta)
+
+		state := State{Phase: PhaseResourcesCreated, ResourceMeta: "query-1"}
+		c := &cacheMocks.AutoRefresh{}
+		c.OnGetOrCreate("task-1", CacheItem{State: state}).Return(CacheItem{State: state}, nil)
+
+		p.OnStatus(ctx, newPluginContext("query-1", "SUCCEEDED", "", tCtx)).Return(core.PhaseInfoSuccess(nil), nil)
+
+		newState, phaseInfo, err := monitor(ctx, tCtx, p, c, watcher, &state)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseSuccess, phaseInfo.Phase())
+		assert.Equal(t, PhaseSucceeded, newState.Phase)
+	})
+
+	t.Run("Untrack", func(t *testing.T) {
+		watcher.untrack("task-1")
+		_, found := watcher.peek("task-1")
+		assert.False(t, found)
+		assert.Equal(t, http.StatusNotFound, postEvent(t, p.server.URL, webhookEvent{ID: "query-1", Status: "FAILED"}))
+	})
+}
+
+func TestResourceWatcher_Nil(t *testing.T) {
+	var watcher *resourceWatcher
+	watcher.track("query-1", "task-1", nil)
+	watcher.untrack("task-1")
+
+	_, found := watcher.peek("task-1")
+	assert.False(t, found)
+
+	_, found = watcher.consume("task-1")
+	assert.False(t, found)
+}
@@ -0,0 +1,170 @@
+package ioutils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+)
+
+// DatasetFormat is the file format of a dataset.
+type DatasetFormat string
+
+const (
+	DatasetFormatParquet DatasetFormat = "parquet"
+	DatasetFormatCSV     DatasetFormat = "csv"
+)
+
+// DatasetColumn describes a column of a dataset.
+type DatasetColumn struct {
+	Name string
+	Type core.SchemaType_SchemaColumn_SchemaColumnType
+}
+
+// Dataset describes a tabular dataset written by a remote system at an external location (e.g. the results of a
+// query).
+type Dataset struct {
+	Location string
+	Format   DatasetFormat
+	// The columns of the dataset, in order. If empty, the columns declared in the task interface are reported.
+	Columns []DatasetColumn
+}
+
+// MakeDatasetLiteral builds the literal for a dataset given the declared type of the output. Schemas and blobs are
+// supported. Blobs are used for structured datasets in formats other than the Parquet files schemas are made of; their
+// declared format, if any, must match the format of the dataset. If the declared schema has columns, the dataset must
+// include all of them with the same types.
+func MakeDatasetLiteral(t *core.LiteralType, dataset Dataset) (*core.Literal, error) {
+	switch t.GetType().(type) {
+	case *core.LiteralType_Schema:
+		schemaType, err := makeSchemaType(t.GetSchema(), dataset.Columns)
+		if err != nil {
+			return nil, err
+		}
+
+		return &core.Literal{
+			Value: &core.Literal_Scalar{
+				Scalar: &core.Scalar{
+					Value: &core.Scalar_Schema{
+						Schema: &core.Schema{
+							Uri:  dataset.Location,
+							Type: schemaType,
+						},
+					},
+				},
+			},
+		}, nil
+	case *core.LiteralType_Blob:
+		blobType := t.GetBlob()
+		if len(blobType.GetFormat()) > 0 && !strings.EqualFold(blobType.GetFormat(), string(dataset.Format)) {
+			return nil, fmt.Errorf("dataset format [%v] doesn't match the declared format [%v]", dataset.Format,
+				blobType.GetFormat())
+		}
+
+		return &core.Literal{
+			Value: &core.Literal_Scalar{
+				Scalar: &core.Scalar{
+					Value: &core.Scalar_Blob{
+						Blob: &core.Blob{
+							Uri: dataset.Location,
+							Metadata: &core.BlobMetadata{
+								Type: &core.BlobType{
+									Format:         string(dataset.Format),
+									Dimensionality: blobType.GetDimensionality(),
+								},
+							},
+						},
+					},
+				},
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("type [%v] can't hold a dataset, expected a schema or a blob", t)
+}
+
+// makeSchemaType builds the schema type of a dataset from its columns, checking that it includes the declared ones.
+// Column names are matched case-insensitively, as some systems (e.g. Athena) lowercase them, and matched columns are
+// reported with their declared names.
+func makeSchemaType(declared *core.SchemaType, columns []DatasetColumn) (*core.SchemaType, error) {
+	if len(columns) == 0 {
+		return declared, nil
+	}
+
+	declaredColumns := make(map[string]*core.SchemaType_SchemaColumn, len(declared.GetColumns()))
+	for _, column := range declared.GetColumns() {
+		declaredColumns[strings.ToLower(column.Name)] = column
+	}
+
+	schemaType := &core.SchemaType{
+		Columns: make([]*core.SchemaType_SchemaColumn, 0, len(columns)),
+	}
+
+	for _, column := range columns {
+		schemaColumn := &core.SchemaType_SchemaColumn{
+			Name: column.Name,
+			Type: column.Type,
+		}
+
+		if declaredColumn, found := declaredColumns[strings.ToLower(column.Name)]; found {
+			if !isCompatibleColumnType(declaredColumn.Type, column.Type) {
+				return nil, fmt.Errorf("column [%v] is of type [%v] but was declared as [%v]", declaredColumn.Name,
+					column.Type, declaredColumn.Type)
+			}
+
+			schemaColumn = declaredColumn
+			delete(declaredColumns, strings.ToLower(column.Name))
+		}
+
+		schemaType.Columns = append(schemaType.Columns, schemaColumn)
+	}
+
+	for _, column := range declared.GetColumns() {
+		if _, missing := declaredColumns[strings.ToLower(column.Name)]; missing {
+			return nil, fmt.Errorf("declared column [%v] is missing from the dataset", column.Name)
+		}
+	}
+
+	return schemaType, nil
+}
+
+// isCompatibleColumnType returns whether a column of the given type can be read as the declared type. Integers can be
+// read as floats.
+func isCompatibleColumnType(declared, actual core.SchemaType_SchemaColumn_SchemaColumnType) bool {
+	return declared == actual ||
+		(declared == core.SchemaType_SchemaColumn_FLOAT && actual == core.SchemaType_SchemaColumn_INTEGER)
+}
+
+// WriteDatasetOutput validates the dataset against the output variable declared in the task interface and writes it as
+// the output of the task. Nothing is written if the task doesn't declare the output.
+func WriteDatasetOutput(ctx context.Context, taskReader pluginsCore.TaskReader, outputWriter io.OutputWriter,
+	outputName string, dataset Dataset) error {
+
+	taskTemplate, err := taskReader.Read(ctx)
+	if err != nil {
+		return err
+	}
+
+	variable, found := taskTemplate.GetInterface().GetOutputs().GetVariables()[outputName]
+	if !found {
+		logger.Infof(ctx, "The task declares no output named [%v]. Skipping writing the outputs.", outputName)
+		return nil
+	}
+
+	literal, err := MakeDatasetLiteral(variable.GetType(), dataset)
+	if err != nil {
+		return errors.Wrapf(errors.BadTaskSpecification, err, "Invalid dataset for output [%v].", outputName)
+	}
+
+	return outputWriter.Put(ctx, NewInMemoryOutputReader(&core.LiteralMap{
+		Literals: map[string]*core.Literal{
+			outputName: literal,
+		},
+	}, nil))
+}
@@ -0,0 +1,150 @@
+package ioutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+)
+
+func schemaType(columns ...*core.SchemaType_SchemaColumn) *core.LiteralType {
+	return &core.LiteralType{Type: &core.LiteralType_Schema{Schema: &core.SchemaType{Columns: columns}}}
+}
+
+func blobType(format string) *core.LiteralType {
+	return &core.LiteralType{Type: &core.LiteralType_Blob{Blob: &core.BlobType{
+		Format:         format,
+		Dimensionality: core.BlobType_MULTIPART,
+	}}}
+}
+
+func TestMakeDatasetLiteral(t *testing.T) {
+	idColumn := &core.SchemaType_SchemaColumn{Name: "id", Type: core.SchemaType_SchemaColumn_INTEGER}
+
+	t.Run("Schema without reported columns", func(t *testing.T) {
+		l, err := MakeDatasetLiteral(schemaType(idColumn), Dataset{Location: "s3://bucket/key"})
+		assert.NoError(t, err)
+		assert.Equal(t, "s3://bucket/key", l.GetScalar().GetSchema().GetUri())
+		assert.Equal(t, []*core.SchemaType_SchemaColumn{idColumn}, l.GetScalar().GetSchema().GetType().GetColumns())
+	})
+
+	t.Run("Schema with reported columns", func(t *testing.T) {
+		l, err := MakeDatasetLiteral(schemaType(idColumn), Dataset{
+			Location: "s3://bucket/key",
+			Columns: []DatasetColumn{
+				{Name: "id", Type: core.SchemaType_SchemaColumn_INTEGER},
+				{Name: "name", Type: core.SchemaType_SchemaColumn_STRING},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, l.GetScalar().GetSchema().GetType().GetColumns(), 2)
+		assert.Equal(t, "name", l.GetScalar().GetSchema().GetType().GetColumns()[1].Name)
+	})
+
+	t.Run("Schema with differently cased columns", func(t *testing.T) {
+		createdAt := &core.SchemaType_SchemaColumn{Name: "createdAt", Type: core.SchemaType_SchemaColumn_DATETIME}
+		score := &core.SchemaType_SchemaColumn{Name: "Score", Type: core.SchemaType_SchemaColumn_FLOAT}
+		l, err := MakeDatasetLiteral(schemaType(createdAt, score), Dataset{
+			Columns: []DatasetColumn{
+				{Name: "score", Type: core.SchemaType_SchemaColumn_INTEGER},
+				{Name: "createdat", Type: core.SchemaType_SchemaColumn_DATETIME},
+				{Name: "name", Type: core.SchemaType_SchemaColumn_STRING},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*core.SchemaType_SchemaColumn{
+			score,
+			createdAt,
+			{Name: "name", Type: core.SchemaType_SchemaColumn_STRING},
+		}, l.GetScalar().GetSchema().GetType().GetColumns())
+	})
+
+	t.Run("Schema with missing column", func(t *testing.T) {
+		_, err := MakeDatasetLiteral(schemaType(idColumn), Dataset{
+			Columns: []DatasetColumn{{Name: "name", Type: core.SchemaType_SchemaColumn_STRING}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Schema with mismatched column type", func(t *testing.T) {
+		_, err := MakeDatasetLiteral(schemaType(idColumn), Dataset{
+			Columns: []DatasetColumn{{Name: "id", Type: core.SchemaType_SchemaColumn_STRING}},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Blob", func(t *testing.T) {
+		l, err := MakeDatasetLiteral(blobType("CSV"), Dataset{Location: "s3://bucket/key", Format: DatasetFormatCSV})
+		assert.NoError(t, err)
+		assert.Equal(t, "s3://bucket/key", l.GetScalar().GetBlob().GetUri())
+		assert.Equal(t, "csv", l.GetScalar().GetBlob().GetMetadata().GetType().GetFormat())
+		assert.Equal(t, core.BlobType_MULTIPART, l.GetScalar().GetBlob().GetMetadata().GetType().GetDimensionality())
+	})
+
+	t.Run("Blob without format", func(t *testing.T) {
+		l, err := MakeDatasetLiteral(blobType(""), Dataset{Format: DatasetFormatParquet})
+		assert.NoError(t, err)
+		assert.Equal(t, "parquet", l.GetScalar().GetBlob().GetMetadata().GetType().GetFormat())
+	})
+
+	t.Run("Blob with mismatched format", func(t *testing.T) {
+		_, err := MakeDatasetLiteral(blobType("parquet"), Dataset{Format: DatasetFormatCSV})
+		assert.Error(t, err)
+	})
+
+	t.Run("Unsupported type", func(t *testing.T) {
+		_, err := MakeDatasetLiteral(&core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_STRING}},
+			Dataset{})
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteDatasetOutput(t *testing.T) {
+	ctx := context.TODO()
+	newTaskReader := func(outputs map[string]*core.Variable) *mocks.TaskReader {
+		tr := &mocks.TaskReader{}
+		tr.OnRead(ctx).Return(&core.TaskTemplate{
+			Interface: &core.TypedInterface{Outputs: &core.VariableMap{Variables: outputs}},
+		}, nil)
+		return tr
+	}
+
+	t.Run("Undeclared output", func(t *testing.T) {
+		ow := &ioMocks.OutputWriter{}
+		err := WriteDatasetOutput(ctx, newTaskReader(nil), ow, "results", Dataset{})
+		assert.NoError(t, err)
+		ow.AssertNotCalled(t, "Put", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Written", func(t *testing.T) {
+		ow := &ioMocks.OutputWriter{}
+		ow.OnPutMatch(ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			outputs, _, err := args.Get(1).(io.OutputReader).Read(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, "s3://bucket/key", outputs.Literals["results"].GetScalar().GetBlob().GetUri())
+		})
+
+		err := WriteDatasetOutput(ctx, newTaskReader(map[string]*core.Variable{"results": {Type: blobType("csv")}}),
+			ow, "results", Dataset{Location: "s3://bucket/key", Format: DatasetFormatCSV})
+		assert.NoError(t, err)
+		ow.AssertNumberOfCalls(t, "Put", 1)
+	})
+
+	t.Run("Invalid dataset", func(t *testing.T) {
+		ow := &ioMocks.OutputWriter{}
+		err := WriteDatasetOutput(ctx, newTaskReader(map[string]*core.Variable{"results": {Type: blobType("csv")}}),
+			ow, "results", Dataset{Format: DatasetFormatParquet})
+		assert.Error(t, err)
+		code, found := stdErrors.GetErrorCode(err)
+		assert.True(t, found)
+		assert.Equal(t, errors.BadTaskSpecification, code)
+	})
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// EventsReader is an autogenerated mock type for the EventsReader type
+type EventsReader struct {
+	mock.Mock
+}
+
+type EventsReader_RecentEvents struct {
+	*mock.Call
+}
+
+func (_m EventsReader_RecentEvents) Return(_a0 []v1.Event, _a1 error) *EventsReader_RecentEvents {
+	return &EventsReader_RecentEvents{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *EventsReader) OnRecentEvents(ctx context.Context, resource client.Object) *EventsReader_RecentEvents {
+	c := _m.On("RecentEvents", ctx, resource)
+	return &EventsReader_RecentEvents{Call: c}
+}
+
+func (_m *EventsReader) OnRecentEventsMatch(matchers ...interface{}) *EventsReader_RecentEvents {
+	c := _m.On("RecentEvents", matchers...)
+	return &EventsReader_RecentEvents{Call: c}
+}
+
+// RecentEvents provides a mock function with given fields: ctx, resource
+func (_m *EventsReader) RecentEvents(ctx context.Context, resource client.Object) ([]v1.Event, error) {
+	ret := _m.Called(ctx, resource)
+
+	var r0 []v1.Event
+	if rf, ok := ret.Get(0).(func(context.Context, client.Object) []v1.Event); ok {
+		r0 = rf(ctx, resource)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v1.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, client.Object) error); ok {
+		r1 = rf(ctx, resource)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
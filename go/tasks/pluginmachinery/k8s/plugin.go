@@ -2,7 +2,11 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/flyteorg/flytestdlib/storage"
@@ -69,7 +73,25 @@ type PluginContext interface {
 	TaskExecutionMetadata() pluginsCore.TaskExecutionMetadata
 }
 
-// Defines a simplified interface to author plugins for k8s resources.
+// EventsReader is an optional interface a PluginContext can implement to provide the Kubernetes events recently
+// recorded for the watched resource and the pods it owns, e.g. failures to mount a volume.
+type EventsReader interface {
+	// RecentEvents returns the events recently recorded for the resource and its pods.
+	RecentEvents(ctx context.Context, resource client.Object) ([]v1.Event, error)
+}
+
+// GetRecentEvents returns the events recently recorded for the resource and its pods. There are none if the
+// PluginContext doesn't implement EventsReader.
+func GetRecentEvents(ctx context.Context, pluginContext PluginContext, resource client.Object) ([]v1.Event, error) {
+	if reader, ok := pluginContext.(EventsReader); ok {
+		return reader.RecentEvents(ctx, resource)
+	}
+
+	return nil, nil
+}
+
+// Defines a simplified interface to author plugins for k8s resources. Plugins can optionally implement
+// pluginsCore.TaskValidator to validate task templates ahead of their execution.
 type Plugin interface {
 	// Defines a func to create a query object (typically just object and type meta portions) that's used to query k8s
 	// resources.
@@ -86,3 +108,103 @@ type Plugin interface {
 	// Properties desired by the plugin
 	GetProperties() PluginProperties
 }
+
+// ValidateTask validates the task template with the plugin. Plugins that don't implement pluginsCore.TaskValidator
+// accept all the task templates.
+func ValidateTask(ctx context.Context, plugin Plugin, taskTemplate *core.TaskTemplate) error {
+	if validator, ok := plugin.(pluginsCore.TaskValidator); ok {
+		return validator.ValidateTask(ctx, taskTemplate)
+	}
+
+	return nil
+}
+
+// AbortBehaviorKind is the action taken on the resource of an aborted task.
+type AbortBehaviorKind int
+
+const (
+	// AbortBehaviorKindDelete deletes the resource, or another object the resource depends on. This is the default
+	// behavior.
+	AbortBehaviorKindDelete AbortBehaviorKind = iota
+	// AbortBehaviorKindPatch patches the resource, e.g. to move it into a state in which its operator cleans up.
+	AbortBehaviorKindPatch
+	// AbortBehaviorKindNoOp leaves the resource untouched, e.g. because aborting the task doesn't require any action.
+	AbortBehaviorKindNoOp
+)
+
+// AbortBehavior describes what the framework does to the resource of a task when the task is aborted.
+type AbortBehavior struct {
+	Kind AbortBehaviorKind
+	// Options of the delete call, used by AbortBehaviorKindDelete.
+	DeleteOptions []client.DeleteOption
+	// The object to patch, or to delete instead of the resource of the task.
+	Resource client.Object
+	// The patch to apply to Resource, used by AbortBehaviorKindPatch.
+	Patch client.Patch
+}
+
+// AbortBehaviorDelete returns the behavior that deletes the resource with the given options.
+func AbortBehaviorDelete(opts ...client.DeleteOption) AbortBehavior {
+	return AbortBehavior{Kind: AbortBehaviorKindDelete, DeleteOptions: opts}
+}
+
+// AbortBehaviorDeleteResource returns the behavior that deletes the given object, instead of the resource of the task,
+// with the given options. The resource of the task is left for the framework to clean up along with the workflow, e.g.
+// to keep the status of an application whose driver is deleted to stop it.
+func AbortBehaviorDeleteResource(resource client.Object, opts ...client.DeleteOption) AbortBehavior {
+	return AbortBehavior{Kind: AbortBehaviorKindDelete, DeleteOptions: opts, Resource: resource}
+}
+
+// AbortBehaviorPatch returns the behavior that applies the patch to the given object.
+func AbortBehaviorPatch(resource client.Object, patch client.Patch) AbortBehavior {
+	return AbortBehavior{Kind: AbortBehaviorKindPatch, Resource: resource, Patch: patch}
+}
+
+// AbortBehaviorNoOp returns the behavior that leaves the resource untouched.
+func AbortBehaviorNoOp() AbortBehavior {
+	return AbortBehavior{Kind: AbortBehaviorKindNoOp}
+}
+
+// PluginAbortOverride is an optional interface a Plugin can implement to customize what happens to the resource of a
+// task when the task is aborted. Plugins that don't implement it get their resources deleted.
+type PluginAbortOverride interface {
+	// OnAbort returns the behavior to apply to the resource, as last observed, of the aborted task.
+	OnAbort(ctx context.Context, tCtx pluginsCore.TaskExecutionContext, resource client.Object) (AbortBehavior, error)
+}
+
+// GetAbortBehavior returns the behavior to apply to the resource of the aborted task, the plugin's if it implements
+// PluginAbortOverride and AbortBehaviorDelete otherwise.
+func GetAbortBehavior(ctx context.Context, plugin Plugin, tCtx pluginsCore.TaskExecutionContext,
+	resource client.Object) (AbortBehavior, error) {
+	if override, ok := plugin.(PluginAbortOverride); ok {
+		return override.OnAbort(ctx, tCtx, resource)
+	}
+
+	return AbortBehaviorDelete(), nil
+}
+
+// ApplyAbortBehavior applies the behavior to the resource. Deleting an object that no longer exists isn't an error.
+func ApplyAbortBehavior(ctx context.Context, c client.Client, resource client.Object, behavior AbortBehavior) error {
+	switch behavior.Kind {
+	case AbortBehaviorKindDelete:
+		if behavior.Resource != nil {
+			resource = behavior.Resource
+		}
+
+		if err := c.Delete(ctx, resource, behavior.DeleteOptions...); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		return nil
+	case AbortBehaviorKindPatch:
+		if behavior.Resource == nil || behavior.Patch == nil {
+			return fmt.Errorf("abort behavior patch requires both a resource and a patch")
+		}
+
+		return c.Patch(ctx, behavior.Resource, behavior.Patch)
+	case AbortBehaviorKindNoOp:
+		return nil
+	default:
+		return fmt.Errorf("unknown abort behavior kind [%v]", behavior.Kind)
+	}
+}
@@ -0,0 +1,101 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+)
+
+type pluginWithAbortOverride struct {
+	*mocks.Plugin
+	*mocks.PluginAbortOverride
+}
+
+type patchRecordingClient struct {
+	*coreMocks.FakeKubeClient
+	patched []client.Object
+}
+
+func (c *patchRecordingClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	c.patched = append(c.patched, obj)
+	return nil
+}
+
+func newPod() *v1.Pod {
+	return &v1.Pod{
+		TypeMeta:   metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"},
+	}
+}
+
+func TestGetAbortBehavior(t *testing.T) {
+	ctx := context.TODO()
+	tCtx := &coreMocks.TaskExecutionContext{}
+	pod := newPod()
+
+	t.Run("default", func(t *testing.T) {
+		behavior, err := k8s.GetAbortBehavior(ctx, &mocks.Plugin{}, tCtx, pod)
+		assert.NoError(t, err)
+		assert.Equal(t, k8s.AbortBehaviorKindDelete, behavior.Kind)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		override := &mocks.PluginAbortOverride{}
+		override.OnOnAbort(ctx, tCtx, pod).Return(k8s.AbortBehaviorNoOp(), nil)
+		behavior, err := k8s.GetAbortBehavior(ctx, pluginWithAbortOverride{&mocks.Plugin{}, override}, tCtx, pod)
+		assert.NoError(t, err)
+		assert.Equal(t, k8s.AbortBehaviorKindNoOp, behavior.Kind)
+	})
+}
+
+func TestApplyAbortBehavior(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("delete", func(t *testing.T) {
+		c := coreMocks.NewFakeKubeClient()
+		pod := newPod()
+		assert.NoError(t, c.Create(ctx, pod))
+		assert.NoError(t, k8s.ApplyAbortBehavior(ctx, c, pod, k8s.AbortBehaviorDelete()))
+		assert.Error(t, c.Get(ctx, client.ObjectKeyFromObject(pod), newPod()))
+	})
+
+	t.Run("delete other resource", func(t *testing.T) {
+		c := coreMocks.NewFakeKubeClient()
+		pod := newPod()
+		other := newPod()
+		other.Name = "other"
+		assert.NoError(t, c.Create(ctx, pod))
+		assert.NoError(t, c.Create(ctx, other))
+		assert.NoError(t, k8s.ApplyAbortBehavior(ctx, c, pod, k8s.AbortBehaviorDeleteResource(other)))
+		assert.Error(t, c.Get(ctx, client.ObjectKeyFromObject(other), newPod()))
+		assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pod), newPod()))
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		c := &patchRecordingClient{FakeKubeClient: coreMocks.NewFakeKubeClient()}
+		pod := newPod()
+		patched := pod.DeepCopy()
+		patched.Labels = map[string]string{"aborted": "true"}
+		behavior := k8s.AbortBehaviorPatch(patched, client.MergeFrom(pod))
+		assert.NoError(t, k8s.ApplyAbortBehavior(ctx, c, pod, behavior))
+		assert.Equal(t, []client.Object{patched}, c.patched)
+
+		assert.Error(t, k8s.ApplyAbortBehavior(ctx, c, pod, k8s.AbortBehavior{Kind: k8s.AbortBehaviorKindPatch}))
+	})
+
+	t.Run("no-op", func(t *testing.T) {
+		c := coreMocks.NewFakeKubeClient()
+		pod := newPod()
+		assert.NoError(t, c.Create(ctx, pod))
+		assert.NoError(t, k8s.ApplyAbortBehavior(ctx, c, pod, k8s.AbortBehaviorNoOp()))
+		assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pod), newPod()))
+	})
+}
@@ -2,8 +2,11 @@ package pluginmachinery
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 	internalRemote "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/internal/webapi"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 
@@ -13,10 +16,22 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 )
 
+// K8sPluginLoader returns k8s plugin entries that depend on config, e.g. because the resources they watch are defined in
+// config.
+type K8sPluginLoader func(ctx context.Context) ([]k8s.PluginEntry, error)
+
+// RemotePluginLoader returns webapi plugin entries that depend on config, e.g. because the task types they handle are
+// defined in config.
+type RemotePluginLoader func(ctx context.Context) ([]webapi.PluginEntry, error)
+
 type taskPluginRegistry struct {
-	m          sync.Mutex
-	k8sPlugin  []k8s.PluginEntry
-	corePlugin []core.PluginEntry
+	m                  sync.Mutex
+	k8sPlugin          []k8s.PluginEntry
+	k8sPluginLoader    []K8sPluginLoader
+	loadedK8sPlugin    []k8s.PluginEntry
+	corePlugin         []core.PluginEntry
+	remotePluginLoader []RemotePluginLoader
+	loadedCorePlugin   []core.PluginEntry
 }
 
 // A singleton variable that maintains a registry of all plugins. The framework uses this to access all plugins
@@ -26,14 +41,54 @@ func PluginRegistry() TaskPluginRegistry {
 	return pluginRegistry
 }
 
+func validateRemotePluginEntry(info webapi.PluginEntry) error {
+	if info.ID == "" {
+		return fmt.Errorf("ID is required attribute for remote plugin")
+	}
+
+	if len(info.SupportedTaskTypes) == 0 {
+		return fmt.Errorf("AsyncPlugin [%v] should be registered to handle at least one task type", info.ID)
+	}
+
+	if info.PluginLoader == nil {
+		return fmt.Errorf("PluginLoader of [%v] cannot be nil", info.ID)
+	}
+
+	return nil
+}
+
 func (p *taskPluginRegistry) RegisterRemotePlugin(info webapi.PluginEntry) {
+	if err := validateRemotePluginEntry(info); err != nil {
+		logger.Panicf(context.Background(), "Invalid remote plugin: %v", err)
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.corePlugin = append(p.corePlugin, internalRemote.CreateRemotePlugin(info))
+}
+
+// Use this method to register webapi plugins that can only be defined once the config is loaded, e.g. because the task
+// types they handle are configured. Plugins are registered at init time, before the config is loaded, so the loader is
+// instead called by LoadPlugins.
+func (p *taskPluginRegistry) RegisterRemotePluginLoader(loader RemotePluginLoader) {
+	if loader == nil {
+		logger.Panicf(context.TODO(), "Remote PluginLoader cannot be nil")
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.remotePluginLoader = append(p.remotePluginLoader, loader)
+}
+
+// Use this method to register SyncPlugins
+func (p *taskPluginRegistry) RegisterSyncPlugin(info webapi.SyncPluginEntry) {
 	ctx := context.Background()
 	if info.ID == "" {
-		logger.Panicf(ctx, "ID is required attribute for k8s plugin")
+		logger.Panicf(ctx, "ID is required attribute for sync plugin")
 	}
 
 	if len(info.SupportedTaskTypes) == 0 {
-		logger.Panicf(ctx, "AsyncPlugin should be registered to handle at least one task type")
+		logger.Panicf(ctx, "SyncPlugin should be registered to handle at least one task type")
 	}
 
 	if info.PluginLoader == nil {
@@ -42,25 +97,33 @@ func (p *taskPluginRegistry) RegisterRemotePlugin(info webapi.PluginEntry) {
 
 	p.m.Lock()
 	defer p.m.Unlock()
-	p.corePlugin = append(p.corePlugin, internalRemote.CreateRemotePlugin(info))
+	p.corePlugin = append(p.corePlugin, internalRemote.CreateSyncPlugin(info))
 }
 
-// Use this method to register Kubernetes Plugins
-func (p *taskPluginRegistry) RegisterK8sPlugin(info k8s.PluginEntry) {
+func validateK8sPluginEntry(info k8s.PluginEntry) error {
 	if info.ID == "" {
-		logger.Panicf(context.TODO(), "ID is required attribute for k8s plugin")
+		return fmt.Errorf("ID is required attribute for k8s plugin")
 	}
 
 	if len(info.RegisteredTaskTypes) == 0 {
-		logger.Panicf(context.TODO(), "K8s AsyncPlugin should be registered to handle atleast one task type")
+		return fmt.Errorf("k8s AsyncPlugin [%v] should be registered to handle atleast one task type", info.ID)
 	}
 
 	if info.Plugin == nil {
-		logger.Panicf(context.TODO(), "K8s AsyncPlugin cannot be nil")
+		return fmt.Errorf("k8s AsyncPlugin [%v] cannot be nil", info.ID)
 	}
 
 	if info.ResourceToWatch == nil {
-		logger.Panicf(context.TODO(), "The framework requires a K8s resource to watch, for valid plugin registration")
+		return fmt.Errorf("the framework requires a K8s resource to watch, for valid plugin registration of [%v]", info.ID)
+	}
+
+	return nil
+}
+
+// Use this method to register Kubernetes Plugins
+func (p *taskPluginRegistry) RegisterK8sPlugin(info k8s.PluginEntry) {
+	if err := validateK8sPluginEntry(info); err != nil {
+		logger.Panicf(context.TODO(), "Invalid k8s plugin: %v", err)
 	}
 
 	p.m.Lock()
@@ -68,6 +131,18 @@ func (p *taskPluginRegistry) RegisterK8sPlugin(info k8s.PluginEntry) {
 	p.k8sPlugin = append(p.k8sPlugin, info)
 }
 
+// Use this method to register Kubernetes Plugins that can only be defined once the config is loaded. Plugins are
+// registered at init time, before the config is loaded, so the loader is instead called by LoadPlugins.
+func (p *taskPluginRegistry) RegisterK8sPluginLoader(loader K8sPluginLoader) {
+	if loader == nil {
+		logger.Panicf(context.TODO(), "K8s PluginLoader cannot be nil")
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.k8sPluginLoader = append(p.k8sPluginLoader, loader)
+}
+
 // Use this method to register core plugins
 func (p *taskPluginRegistry) RegisterCorePlugin(info core.PluginEntry) {
 	if info.ID == "" {
@@ -85,24 +160,146 @@ func (p *taskPluginRegistry) RegisterCorePlugin(info core.PluginEntry) {
 	p.corePlugin = append(p.corePlugin, info)
 }
 
-// Returns a snapshot of all the registered core plugins.
+// Returns a snapshot of all the registered core plugins, including the webapi plugins returned by the registered loaders
+// once LoadPlugins has been called.
 func (p *taskPluginRegistry) GetCorePlugins() []core.PluginEntry {
 	p.m.Lock()
 	defer p.m.Unlock()
-	return append(p.corePlugin[:0:0], p.corePlugin...)
+	plugins := append(p.corePlugin[:0:0], p.corePlugin...)
+	return append(plugins, p.loadedCorePlugin...)
 }
 
-// Returns a snapshot of all registered K8s plugins
+// Returns a snapshot of all registered K8s plugins, including the ones returned by the registered loaders once
+// LoadPlugins has been called.
 func (p *taskPluginRegistry) GetK8sPlugins() []k8s.PluginEntry {
 	p.m.Lock()
 	defer p.m.Unlock()
-	return append(p.k8sPlugin[:0:0], p.k8sPlugin...)
+	plugins := append(p.k8sPlugin[:0:0], p.k8sPlugin...)
+	return append(plugins, p.loadedK8sPlugin...)
+}
+
+// LoadPlugins calls the registered K8s and webapi plugin loaders and validates the plugins they return, replacing the
+// plugins previously loaded. Loaded plugins can't reuse the ID of another plugin. The framework is expected to call it
+// once the config is loaded, before reading the registered plugins.
+func (p *taskPluginRegistry) LoadPlugins(ctx context.Context) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	ids := map[string]bool{}
+	for _, info := range p.corePlugin {
+		ids[info.ID] = true
+	}
+
+	for _, info := range p.k8sPlugin {
+		ids[info.ID] = true
+	}
+
+	checkID := func(id string) error {
+		if ids[id] {
+			return fmt.Errorf("plugin ID [%v] is already registered", id)
+		}
+
+		ids[id] = true
+		return nil
+	}
+
+	var loadedK8s []k8s.PluginEntry
+	for _, loader := range p.k8sPluginLoader {
+		plugins, err := loader(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load k8s plugins: %w", err)
+		}
+
+		for _, info := range plugins {
+			if err := validateK8sPluginEntry(info); err != nil {
+				return err
+			}
+
+			if err := checkID(info.ID); err != nil {
+				return err
+			}
+
+			loadedK8s = append(loadedK8s, info)
+		}
+	}
+
+	var loadedCore []core.PluginEntry
+	for _, loader := range p.remotePluginLoader {
+		plugins, err := loader(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load remote plugins: %w", err)
+		}
+
+		for _, info := range plugins {
+			if err := validateRemotePluginEntry(info); err != nil {
+				return err
+			}
+
+			if err := checkID(info.ID); err != nil {
+				return err
+			}
+
+			loadedCore = append(loadedCore, internalRemote.CreateRemotePlugin(info))
+		}
+	}
+
+	p.loadedK8sPlugin = loadedK8s
+	p.loadedCorePlugin = loadedCore
+	return nil
+}
+
+// ValidateRoutes checks that the plugin of each route exists and is registered for the route's task type. Plugins
+// register the task types they handle at init time or through their loaders, so routes can only select among the
+// plugins registered for a task type. In particular, routes without a plugin need the task type to be registered with
+// the fail-fast plugin. The framework is expected to call it once the config is loaded, after LoadPlugins.
+func (p *taskPluginRegistry) ValidateRoutes(routes []pluginsConfig.PluginRoute) error {
+	taskTypes := map[string]map[string]bool{}
+	register := func(id string, registeredTaskTypes []core.TaskType) {
+		if taskTypes[id] == nil {
+			taskTypes[id] = map[string]bool{}
+		}
+
+		for _, taskType := range registeredTaskTypes {
+			taskTypes[id][taskType] = true
+		}
+	}
+
+	for _, info := range p.GetCorePlugins() {
+		register(info.ID, info.RegisteredTaskTypes)
+	}
+
+	for _, info := range p.GetK8sPlugins() {
+		register(info.ID, info.RegisteredTaskTypes)
+	}
+
+	var invalid []string
+	for i, route := range routes {
+		registered, found := taskTypes[route.GetPlugin()]
+		switch {
+		case !found:
+			invalid = append(invalid, fmt.Sprintf("route [%v] selects the unknown plugin [%v]", i, route.GetPlugin()))
+		case !registered[route.TaskType]:
+			invalid = append(invalid, fmt.Sprintf("route [%v] selects the plugin [%v], which isn't registered for the task type [%v]",
+				i, route.GetPlugin(), route.TaskType))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid plugin routes: %v", strings.Join(invalid, "; "))
+	}
+
+	return nil
 }
 
 type TaskPluginRegistry interface {
 	RegisterK8sPlugin(info k8s.PluginEntry)
+	RegisterK8sPluginLoader(loader K8sPluginLoader)
 	RegisterCorePlugin(info core.PluginEntry)
 	RegisterRemotePlugin(info webapi.PluginEntry)
+	RegisterSyncPlugin(info webapi.SyncPluginEntry)
+	RegisterRemotePluginLoader(loader RemotePluginLoader)
 	GetCorePlugins() []core.PluginEntry
 	GetK8sPlugins() []k8s.PluginEntry
+	LoadPlugins(ctx context.Context) error
+	ValidateRoutes(routes []pluginsConfig.PluginRoute) error
 }
@@ -0,0 +1,157 @@
+package pluginmachinery
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+func TestTaskPluginRegistry_ValidateRoutes(t *testing.T) {
+	loadPlugin := func(context.Context, core.SetupContext) (core.Plugin, error) {
+		return nil, nil
+	}
+
+	registry := &taskPluginRegistry{}
+	registry.RegisterCorePlugin(core.PluginEntry{
+		ID: "athena", RegisteredTaskTypes: []core.TaskType{"hive", "presto"}, LoadPlugin: loadPlugin,
+	})
+	registry.RegisterCorePlugin(core.PluginEntry{
+		ID: pluginsConfig.FailFastPluginID, RegisteredTaskTypes: []core.TaskType{"hive", "spark"}, LoadPlugin: loadPlugin,
+	})
+
+	assert.NoError(t, registry.ValidateRoutes(nil))
+	assert.NoError(t, registry.ValidateRoutes([]pluginsConfig.PluginRoute{
+		{TaskType: "hive", Domain: "development", Plugin: "athena"},
+		{TaskType: "spark", FailFastMessage: "Spark isn't available."},
+	}))
+
+	t.Run("Unknown plugin", func(t *testing.T) {
+		assert.Error(t, registry.ValidateRoutes([]pluginsConfig.PluginRoute{{TaskType: "hive", Plugin: "qubole"}}))
+	})
+
+	t.Run("Task type not registered", func(t *testing.T) {
+		assert.Error(t, registry.ValidateRoutes([]pluginsConfig.PluginRoute{{TaskType: "spark", Plugin: "athena"}}))
+	})
+
+	t.Run("Task type not registered with fail-fast", func(t *testing.T) {
+		assert.Error(t, registry.ValidateRoutes([]pluginsConfig.PluginRoute{{TaskType: "presto"}}))
+	})
+}
+
+func TestTaskPluginRegistry_LoadPlugins(t *testing.T) {
+	ctx := context.TODO()
+	newEntry := func(id string) k8s.PluginEntry {
+		return k8s.PluginEntry{
+			ID:                  id,
+			RegisteredTaskTypes: []core.TaskType{id},
+			ResourceToWatch:     &v1.Pod{},
+			Plugin:              &k8sMocks.Plugin{},
+		}
+	}
+
+	newRegistry := func(loaded ...k8s.PluginEntry) (*taskPluginRegistry, *int) {
+		calls := 0
+		registry := &taskPluginRegistry{}
+		registry.RegisterCorePlugin(core.PluginEntry{
+			ID: "athena", RegisteredTaskTypes: []core.TaskType{"hive"},
+			LoadPlugin: func(context.Context, core.SetupContext) (core.Plugin, error) {
+				return nil, nil
+			},
+		})
+		registry.RegisterK8sPlugin(newEntry("container"))
+		registry.RegisterK8sPluginLoader(func(context.Context) ([]k8s.PluginEntry, error) {
+			calls++
+			return loaded, nil
+		})
+
+		return registry, &calls
+	}
+
+	t.Run("Loaded once", func(t *testing.T) {
+		registry, calls := newRegistry(newEntry("ray"))
+		assert.Len(t, registry.GetK8sPlugins(), 1)
+
+		assert.NoError(t, registry.LoadPlugins(ctx))
+		plugins := registry.GetK8sPlugins()
+		assert.Len(t, plugins, 2)
+		assert.Equal(t, "ray", plugins[1].ID)
+		assert.Len(t, registry.GetK8sPlugins(), 2)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("Duplicate IDs", func(t *testing.T) {
+		for _, id := range []string{"athena", "container"} {
+			registry, _ := newRegistry(newEntry(id))
+			assert.Error(t, registry.LoadPlugins(ctx), id)
+		}
+
+		registry, _ := newRegistry(newEntry("ray"), newEntry("ray"))
+		assert.Error(t, registry.LoadPlugins(ctx))
+		assert.Len(t, registry.GetK8sPlugins(), 1)
+	})
+
+	t.Run("Invalid plugin", func(t *testing.T) {
+		invalid := newEntry("ray")
+		invalid.ResourceToWatch = nil
+		registry, _ := newRegistry(invalid)
+		assert.Error(t, registry.LoadPlugins(ctx))
+	})
+
+	t.Run("Loader error", func(t *testing.T) {
+		registry := &taskPluginRegistry{}
+		registry.RegisterK8sPluginLoader(func(context.Context) ([]k8s.PluginEntry, error) {
+			return nil, fmt.Errorf("invalid config")
+		})
+		assert.Error(t, registry.LoadPlugins(ctx))
+	})
+
+	newRemoteEntry := func(id string, taskTypes ...core.TaskType) webapi.PluginEntry {
+		return webapi.PluginEntry{
+			ID:                 id,
+			SupportedTaskTypes: taskTypes,
+			PluginLoader: func(context.Context, webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+				return nil, nil
+			},
+		}
+	}
+
+	t.Run("Remote plugins", func(t *testing.T) {
+		registry, _ := newRegistry()
+		registry.RegisterRemotePluginLoader(func(context.Context) ([]webapi.PluginEntry, error) {
+			return []webapi.PluginEntry{newRemoteEntry("rest", "my-service", "other-service")}, nil
+		})
+		assert.Len(t, registry.GetCorePlugins(), 1)
+
+		assert.NoError(t, registry.LoadPlugins(ctx))
+		plugins := registry.GetCorePlugins()
+		assert.Len(t, plugins, 2)
+		assert.Equal(t, "rest", plugins[1].ID)
+		assert.Equal(t, []core.TaskType{"my-service", "other-service"}, plugins[1].RegisteredTaskTypes)
+		assert.NoError(t, registry.ValidateRoutes([]pluginsConfig.PluginRoute{{TaskType: "my-service", Plugin: "rest"}}))
+	})
+
+	t.Run("Invalid remote plugins", func(t *testing.T) {
+		for name, entry := range map[string]webapi.PluginEntry{
+			"Duplicate ID":  newRemoteEntry("container", "my-service"),
+			"No task types": newRemoteEntry("rest"),
+		} {
+			t.Run(name, func(t *testing.T) {
+				registry, _ := newRegistry()
+				registry.RegisterRemotePluginLoader(func(context.Context) ([]webapi.PluginEntry, error) {
+					return []webapi.PluginEntry{entry}, nil
+				})
+				assert.Error(t, registry.LoadPlugins(ctx))
+				assert.Len(t, registry.GetCorePlugins(), 1)
+			})
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+func assertNotFound(t *testing.T, err error) {
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrSecretNotFound), "expected a not found error, got [%v]", err)
+}
+
+func TestEnvSecretManager(t *testing.T) {
+	ctx := context.TODO()
+	assert.NoError(t, os.Setenv("TEST_SECRET_QUBOLE_API_TOKEN", "latest"))
+	assert.NoError(t, os.Setenv("TEST_SECRET_QUBOLE_API_TOKEN_2", "v2"))
+	assert.NoError(t, os.Setenv("TEST_SECRET_TOKEN", "legacy"))
+	defer func() {
+		for _, name := range []string{"TEST_SECRET_QUBOLE_API_TOKEN", "TEST_SECRET_QUBOLE_API_TOKEN_2", "TEST_SECRET_TOKEN"} {
+			assert.NoError(t, os.Unsetenv(name))
+		}
+	}()
+
+	sm := NewEnvSecretManager("TEST_SECRET_")
+
+	value, err := sm.GetSecret(ctx, core.SecretReference{Group: "qubole", Key: "api-token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "latest", value)
+
+	value, err = sm.GetSecret(ctx, core.SecretReference{Group: "qubole", Key: "api-token", Version: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", value)
+
+	value, err = sm.Get(ctx, "TOKEN")
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy", value)
+
+	_, err = sm.GetSecret(ctx, core.SecretReference{Group: "qubole", Key: "api-token", Version: "3"})
+	assertNotFound(t, err)
+}
+
+func TestFileSecretManager(t *testing.T) {
+	ctx := context.TODO()
+	root, err := ioutil.TempDir("", "secrets")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(root)) }()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "qubole"), 0700))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "qubole", "token"), []byte("latest\n"), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "qubole", "token.2"), []byte("v2"), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(root, "token"), []byte("legacy"), 0600))
+
+	sm := NewFileSecretManager(root)
+
+	value, err := sm.GetSecret(ctx, core.SecretReference{Group: "qubole", Key: "token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "latest", value)
+
+	value, err = sm.GetSecret(ctx, core.SecretReference{Group: "qubole", Key: "token", Version: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", value)
+
+	value, err = sm.Get(ctx, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy", value)
+
+	_, err = sm.GetSecret(ctx, core.SecretReference{Group: "presto", Key: "token"})
+	assertNotFound(t, err)
+
+	_, err = sm.GetSecret(ctx, core.SecretReference{Group: "..", Key: "token"})
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrSecretNotFound))
+}
+
+func TestK8sSecretManager(t *testing.T) {
+	ctx := context.TODO()
+	kubeClient := mocks.NewFakeKubeClient()
+	assert.NoError(t, kubeClient.Create(ctx, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flyte", Name: "qubole"},
+		Data: map[string][]byte{
+			"token":   []byte("latest"),
+			"token.2": []byte("v2"),
+		},
+	}))
+
+	sm := NewK8sSecretManager(kubeClient, "flyte")
+
+	value, err := sm.GetSecret(ctx, core.SecretReference{Group: "qubole", Key: "token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "latest", value)
+
+	value, err = sm.GetSecret(ctx, core.SecretReference{Group: "qubole", Key: "token", Version: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", value)
+
+	_, err = sm.GetSecret(ctx, core.SecretReference{Group: "qubole", Key: "password"})
+	assertNotFound(t, err)
+
+	_, err = sm.GetSecret(ctx, core.SecretReference{Group: "presto", Key: "token"})
+	assertNotFound(t, err)
+
+	_, err = sm.Get(ctx, "token")
+	assertNotFound(t, err)
+
+	_, err = NewK8sSecretManager(kubeClient, "other").GetSecret(ctx, core.SecretReference{Group: "qubole", Key: "token"})
+	assertNotFound(t, err)
+}
@@ -0,0 +1,69 @@
+package secret
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+type cacheEntry struct {
+	value string
+	// The time the entry must be fetched again. It's zero for versioned secrets.
+	expiresAt time.Time
+}
+
+type cachedSecretManager struct {
+	backend core.StructuredSecretManager
+	ttl     time.Duration
+	clock   clock.PassiveClock
+	lock    sync.Mutex
+	entries map[core.SecretReference]cacheEntry
+}
+
+// NewCachedSecretManager caches the secrets read from the backend. A given version of a secret never changes, so
+// versioned secrets are cached for good. The latest version of a secret may be rotated at any time, so it's only cached
+// for ttl and isn't cached at all if ttl isn't positive. Errors, including missing secrets, aren't cached.
+func NewCachedSecretManager(backend core.StructuredSecretManager, ttl time.Duration,
+	c clock.PassiveClock) core.StructuredSecretManager {
+	m := &cachedSecretManager{
+		backend: backend,
+		ttl:     ttl,
+		clock:   c,
+		entries: map[core.SecretReference]cacheEntry{},
+	}
+
+	return getFunc(m.get)
+}
+
+func (m *cachedSecretManager) get(ctx context.Context, ref core.SecretReference) (string, error) {
+	versioned := len(ref.Version) > 0
+	if !versioned && m.ttl <= 0 {
+		return m.backend.GetSecret(ctx, ref)
+	}
+
+	m.lock.Lock()
+	entry, found := m.entries[ref]
+	m.lock.Unlock()
+	if found && (entry.expiresAt.IsZero() || m.clock.Now().Before(entry.expiresAt)) {
+		return entry.value, nil
+	}
+
+	value, err := m.backend.GetSecret(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	entry = cacheEntry{value: value}
+	if !versioned {
+		entry.expiresAt = m.clock.Now().Add(m.ttl)
+	}
+
+	m.lock.Lock()
+	m.entries[ref] = entry
+	m.lock.Unlock()
+	return value, nil
+}
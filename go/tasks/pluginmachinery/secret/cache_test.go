@@ -0,0 +1,80 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	testing2 "k8s.io/utils/clock/testing"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+func TestCachedSecretManager(t *testing.T) {
+	ctx := context.TODO()
+	latest := core.SecretReference{Group: "qubole", Key: "token"}
+	versioned := core.SecretReference{Group: "qubole", Key: "token", Version: "2"}
+
+	newBackend := func() *mocks.StructuredSecretManager {
+		backend := &mocks.StructuredSecretManager{}
+		backend.OnGetSecret(ctx, latest).Return("v1", nil).Once()
+		backend.OnGetSecret(ctx, latest).Return("v2", nil).Once()
+		backend.OnGetSecret(ctx, versioned).Return("v2", nil).Once()
+		return backend
+	}
+
+	get := func(sm core.SecretManager, ref core.SecretReference) string {
+		value, err := core.GetSecret(ctx, sm, ref)
+		assert.NoError(t, err)
+		return value
+	}
+
+	t.Run("Latest version is cached until it expires", func(t *testing.T) {
+		clck := testing2.NewFakeClock(time.Now())
+		backend := newBackend()
+		sm := NewCachedSecretManager(backend, time.Minute, clck)
+
+		assert.Equal(t, "v1", get(sm, latest))
+		clck.Step(30 * time.Second)
+		assert.Equal(t, "v1", get(sm, latest))
+
+		// The secret was rotated in the meantime.
+		clck.Step(30 * time.Second)
+		assert.Equal(t, "v2", get(sm, latest))
+		backend.AssertNumberOfCalls(t, "GetSecret", 2)
+	})
+
+	t.Run("Versions are cached for good", func(t *testing.T) {
+		clck := testing2.NewFakeClock(time.Now())
+		backend := newBackend()
+		sm := NewCachedSecretManager(backend, 0, clck)
+
+		assert.Equal(t, "v2", get(sm, versioned))
+		clck.Step(24 * time.Hour)
+		assert.Equal(t, "v2", get(sm, versioned))
+		backend.AssertNumberOfCalls(t, "GetSecret", 1)
+	})
+
+	t.Run("Latest version isn't cached without a ttl", func(t *testing.T) {
+		backend := newBackend()
+		sm := NewCachedSecretManager(backend, 0, testing2.NewFakeClock(time.Now()))
+
+		assert.Equal(t, "v1", get(sm, latest))
+		assert.Equal(t, "v2", get(sm, latest))
+	})
+
+	t.Run("Errors aren't cached", func(t *testing.T) {
+		backend := &mocks.StructuredSecretManager{}
+		backend.OnGetSecretMatch(ctx, mock.Anything).Return("", fmt.Errorf("unavailable")).Once()
+		backend.OnGetSecretMatch(ctx, mock.Anything).Return("v1", nil).Once()
+		sm := NewCachedSecretManager(backend, time.Minute, testing2.NewFakeClock(time.Now()))
+
+		_, err := sm.GetSecret(ctx, latest)
+		assert.Error(t, err)
+		assert.Equal(t, "v1", get(sm, latest))
+	})
+}
@@ -0,0 +1,26 @@
+package secret
+
+import (
+	"context"
+	"errors"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// NewChainedSecretManager creates a SecretManager looking secrets up in each of the given SecretManagers in order. A
+// secret missing from one is looked up in the next one, any other error is returned right away so that a failing
+// backend doesn't silently shadow the secret with an older copy from another one.
+func NewChainedSecretManager(managers ...core.StructuredSecretManager) core.StructuredSecretManager {
+	return getFunc(func(ctx context.Context, ref core.SecretReference) (string, error) {
+		err := notFound(ref, "any backend")
+		for _, m := range managers {
+			var value string
+			value, err = m.GetSecret(ctx, ref)
+			if err == nil || !errors.Is(err, ErrSecretNotFound) {
+				return value, err
+			}
+		}
+
+		return "", err
+	})
+}
@@ -0,0 +1,63 @@
+package secret
+
+import (
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+const (
+	BackendEnv  = "env"
+	BackendFile = "file"
+	BackendK8s  = "k8s"
+)
+
+var (
+	defaultConfig = &Config{
+		Backends: []string{BackendEnv, BackendFile},
+		Env: EnvConfig{
+			Prefix: "FLYTE_SECRET_",
+		},
+		File: FileConfig{
+			Root:     "/etc/secrets",
+			CacheTTL: config.Duration{Duration: time.Minute},
+		},
+		K8s: K8sConfig{
+			Namespace: "flyte",
+			CacheTTL:  config.Duration{Duration: 5 * time.Minute},
+		},
+	}
+
+	configSection = pluginsConfig.MustRegisterSubSection("secrets", defaultConfig)
+)
+
+// Config selects the backends secrets are looked up in.
+type Config struct {
+	Backends []string   `json:"backends" pflag:",Ordered list of the backends secrets are looked up in (env, file or k8s). A secret missing from a backend is looked up in the next one."`
+	Env      EnvConfig  `json:"env" pflag:",Config of the backend reading secrets from environment variables."`
+	File     FileConfig `json:"file" pflag:",Config of the backend reading secrets from mounted files."`
+	K8s      K8sConfig  `json:"k8s" pflag:",Config of the backend reading secrets from Kubernetes Secrets."`
+}
+
+type EnvConfig struct {
+	Prefix   string          `json:"prefix" pflag:",Prefix of the environment variables holding secrets."`
+	CacheTTL config.Duration `json:"cacheTTL" pflag:",How long the latest version of a secret is cached for. It's not cached if zero."`
+}
+
+type FileConfig struct {
+	Root     string          `json:"root" pflag:",Directory secrets are mounted in."`
+	CacheTTL config.Duration `json:"cacheTTL" pflag:",How long the latest version of a secret is cached for. It's not cached if zero."`
+}
+
+type K8sConfig struct {
+	Namespace string          `json:"namespace" pflag:",Namespace of the Kubernetes Secrets."`
+	CacheTTL  config.Duration `json:"cacheTTL" pflag:",How long the latest version of a secret is cached for. It's not cached if zero."`
+}
+
+func GetConfig() *Config {
+	return configSection.GetConfig().(*Config)
+}
@@ -0,0 +1,52 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package secret
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (Config) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (Config) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in Config and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("Config", pflag.ExitOnError)
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "backends"), []string{}, "Ordered list of the backends secrets are looked up in (env,  file or k8s). A secret missing from a backend is looked up in the next one.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "env.prefix"), defaultConfig.Env.Prefix, "Prefix of the environment variables holding secrets.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "env.cacheTTL"), defaultConfig.Env.CacheTTL.String(), "How long the latest version of a secret is cached for. It's not cached if zero.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "file.root"), defaultConfig.File.Root, "Directory secrets are mounted in.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "file.cacheTTL"), defaultConfig.File.CacheTTL.String(), "How long the latest version of a secret is cached for. It's not cached if zero.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "k8s.namespace"), defaultConfig.K8s.Namespace, "Namespace of the Kubernetes Secrets.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "k8s.cacheTTL"), defaultConfig.K8s.CacheTTL.String(), "How long the latest version of a secret is cached for. It's not cached if zero.")
+	return cmdFlags
+}
@@ -0,0 +1,256 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/assert"
+)
+
+var dereferencableKindsConfig = map[reflect.Kind]struct{}{
+	reflect.Array: {}, reflect.Chan: {}, reflect.Map: {}, reflect.Ptr: {}, reflect.Slice: {},
+}
+
+// Checks if t is a kind that can be dereferenced to get its underlying type.
+func canGetElementConfig(t reflect.Kind) bool {
+	_, exists := dereferencableKindsConfig[t]
+	return exists
+}
+
+// This decoder hook tests types for json unmarshaling capability. If implemented, it uses json unmarshal to build the
+// object. Otherwise, it'll just pass on the original data.
+func jsonUnmarshalerHookConfig(_, to reflect.Type, data interface{}) (interface{}, error) {
+	unmarshalerType := reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	if to.Implements(unmarshalerType) || reflect.PtrTo(to).Implements(unmarshalerType) ||
+		(canGetElementConfig(to.Kind()) && to.Elem().Implements(unmarshalerType)) {
+
+		raw, err := json.Marshal(data)
+		if err != nil {
+			fmt.Printf("Failed to marshal Data: %v. Error: %v. Skipping jsonUnmarshalHook", data, err)
+			return data, nil
+		}
+
+		res := reflect.New(to).Interface()
+		err = json.Unmarshal(raw, &res)
+		if err != nil {
+			fmt.Printf("Failed to umarshal Data: %v. Error: %v. Skipping jsonUnmarshalHook", data, err)
+			return data, nil
+		}
+
+		return res, nil
+	}
+
+	return data, nil
+}
+
+func decode_Config(input, result interface{}) error {
+	config := &mapstructure.DecoderConfig{
+		TagName:          "json",
+		WeaklyTypedInput: true,
+		Result:           result,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+			jsonUnmarshalerHookConfig,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(config)
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(input)
+}
+
+func join_Config(arr interface{}, sep string) string {
+	listValue := reflect.ValueOf(arr)
+	strs := make([]string, 0, listValue.Len())
+	for i := 0; i < listValue.Len(); i++ {
+		strs = append(strs, fmt.Sprintf("%v", listValue.Index(i)))
+	}
+
+	return strings.Join(strs, sep)
+}
+
+func testDecodeJson_Config(t *testing.T, val, result interface{}) {
+	assert.NoError(t, decode_Config(val, result))
+}
+
+func testDecodeSlice_Config(t *testing.T, vStringSlice, result interface{}) {
+	assert.NoError(t, decode_Config(vStringSlice, result))
+}
+
+func TestConfig_GetPFlagSet(t *testing.T) {
+	val := Config{}
+	cmdFlags := val.GetPFlagSet("")
+	assert.True(t, cmdFlags.HasFlags())
+}
+
+func TestConfig_SetFlags(t *testing.T) {
+	actual := Config{}
+	cmdFlags := actual.GetPFlagSet("")
+	assert.True(t, cmdFlags.HasFlags())
+
+	t.Run("Test_backends", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vStringSlice, err := cmdFlags.GetStringSlice("backends"); err == nil {
+				assert.Equal(t, []string([]string{}), vStringSlice)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_Config("1,1", ",")
+
+			cmdFlags.Set("backends", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("backends"); err == nil {
+				testDecodeSlice_Config(t, join_Config(vStringSlice, ","), &actual.Backends)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_env.prefix", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("env.prefix"); err == nil {
+				assert.Equal(t, string(defaultConfig.Env.Prefix), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("env.prefix", testValue)
+			if vString, err := cmdFlags.GetString("env.prefix"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Env.Prefix)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_env.cacheTTL", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("env.cacheTTL"); err == nil {
+				assert.Equal(t, string(defaultConfig.Env.CacheTTL.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.Env.CacheTTL.String()
+
+			cmdFlags.Set("env.cacheTTL", testValue)
+			if vString, err := cmdFlags.GetString("env.cacheTTL"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Env.CacheTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_file.root", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("file.root"); err == nil {
+				assert.Equal(t, string(defaultConfig.File.Root), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("file.root", testValue)
+			if vString, err := cmdFlags.GetString("file.root"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.File.Root)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_file.cacheTTL", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("file.cacheTTL"); err == nil {
+				assert.Equal(t, string(defaultConfig.File.CacheTTL.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.File.CacheTTL.String()
+
+			cmdFlags.Set("file.cacheTTL", testValue)
+			if vString, err := cmdFlags.GetString("file.cacheTTL"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.File.CacheTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_k8s.namespace", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("k8s.namespace"); err == nil {
+				assert.Equal(t, string(defaultConfig.K8s.Namespace), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("k8s.namespace", testValue)
+			if vString, err := cmdFlags.GetString("k8s.namespace"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.K8s.Namespace)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_k8s.cacheTTL", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("k8s.cacheTTL"); err == nil {
+				assert.Equal(t, string(defaultConfig.K8s.CacheTTL.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.K8s.CacheTTL.String()
+
+			cmdFlags.Set("k8s.cacheTTL", testValue)
+			if vString, err := cmdFlags.GetString("k8s.cacheTTL"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.K8s.CacheTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+}
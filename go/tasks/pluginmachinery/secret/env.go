@@ -0,0 +1,45 @@
+package secret
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// NewEnvSecretManager creates a SecretManager reading secrets from environment variables. The variable of a secret is
+// named after the prefix followed by its group, key and version, separated by underscores. Characters that aren't
+// letters or digits are replaced by underscores and letters are upper-cased, e.g. the version 2 of the key api-token
+// in the group qubole is read from <prefix>QUBOLE_API_TOKEN_2.
+func NewEnvSecretManager(prefix string) core.StructuredSecretManager {
+	return getFunc(func(_ context.Context, ref core.SecretReference) (string, error) {
+		value, found := os.LookupEnv(EnvVarName(prefix, ref))
+		if !found {
+			return "", notFound(ref, "env")
+		}
+
+		return value, nil
+	})
+}
+
+// EnvVarName returns the name of the environment variable the secret is read from.
+func EnvVarName(prefix string, ref core.SecretReference) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{ref.Group, ref.Key, ref.Version} {
+		if len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+
+	return prefix + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, strings.Join(parts, "_"))
+}
@@ -0,0 +1,47 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// NewFileSecretManager creates a SecretManager reading secrets from files under root, which is how Kubernetes mounts
+// Secrets in a pod. A secret is read from <root>/<group>/<key>, or <root>/<group>/<key>.<version> if versioned. The
+// group directory is omitted for secrets without a group. A trailing newline is trimmed from the content of the file.
+func NewFileSecretManager(root string) core.StructuredSecretManager {
+	return getFunc(func(_ context.Context, ref core.SecretReference) (string, error) {
+		if !validPathElement(ref.Group) || !validPathElement(VersionedKey(ref)) {
+			return "", fmt.Errorf("invalid secret reference [%v]", ref)
+		}
+
+		value, err := ioutil.ReadFile(filepath.Join(root, ref.Group, VersionedKey(ref)))
+		if os.IsNotExist(err) {
+			return "", notFound(ref, "files")
+		} else if err != nil {
+			return "", fmt.Errorf("failed to read secret [%v]: %w", ref, err)
+		}
+
+		return strings.TrimSuffix(strings.TrimSuffix(string(value), "\n"), "\r"), nil
+	})
+}
+
+// VersionedKey returns the name of the key a version of a secret is stored under, in mounted directories as well as in
+// Kubernetes Secrets.
+func VersionedKey(ref core.SecretReference) string {
+	if len(ref.Version) == 0 {
+		return ref.Key
+	}
+
+	return ref.Key + "." + ref.Version
+}
+
+// validPathElement prevents secret references from escaping the root directory.
+func validPathElement(s string) bool {
+	return !strings.ContainsAny(s, `/\`) && s != "." && s != ".."
+}
@@ -0,0 +1,38 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// NewK8sSecretManager creates a SecretManager reading secrets from Kubernetes Secrets in the given namespace. The group
+// of a secret is the name of the Secret and its key, suffixed with .<version> if versioned, is the key in the data of
+// the Secret. Secrets without a group can't be read from Kubernetes and are reported as not found.
+func NewK8sSecretManager(kubeClient client.Reader, namespace string) core.StructuredSecretManager {
+	return getFunc(func(ctx context.Context, ref core.SecretReference) (string, error) {
+		if len(ref.Group) == 0 {
+			return "", notFound(ref, "kubernetes")
+		}
+
+		s := &v1.Secret{}
+		err := kubeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Group}, s)
+		if k8serrors.IsNotFound(err) {
+			return "", notFound(ref, "kubernetes")
+		} else if err != nil {
+			return "", fmt.Errorf("failed to get kubernetes secret [%v/%v]: %w", namespace, ref.Group, err)
+		}
+
+		if value, found := s.Data[VersionedKey(ref)]; found {
+			return string(value), nil
+		}
+
+		return "", notFound(ref, "kubernetes")
+	})
+}
@@ -0,0 +1,79 @@
+// Package secret provides SecretManager backends reading secrets from environment variables, mounted files and
+// Kubernetes Secrets, as well as a cache and a chain to combine them.
+//
+// Each backend maps a core.SecretReference to its own naming scheme, see the constructors. A versioned secret is looked
+// up under its key suffixed with the version.
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/logger"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// ErrSecretNotFound is wrapped by the errors backends return when a secret doesn't exist. Any other error means the
+// backend failed to look the secret up.
+var ErrSecretNotFound = errors.New("secret not found")
+
+func notFound(ref core.SecretReference, backend string) error {
+	return fmt.Errorf("%w: [%v] in %v", ErrSecretNotFound, ref, backend)
+}
+
+// getFunc adapts a lookup function to core.StructuredSecretManager.
+type getFunc func(ctx context.Context, ref core.SecretReference) (string, error)
+
+func (f getFunc) Get(ctx context.Context, key string) (string, error) {
+	return f(ctx, core.SecretReference{Key: key})
+}
+
+func (f getFunc) GetSecret(ctx context.Context, ref core.SecretReference) (string, error) {
+	return f(ctx, ref)
+}
+
+// NewSecretManager creates the SecretManager described by the config: each backend is wrapped in a cache with its TTL
+// and the backends are chained in the configured order. kubeClient is only used by the k8s backend.
+func NewSecretManager(ctx context.Context, cfg *Config, kubeClient client.Reader) (core.StructuredSecretManager, error) {
+	if len(cfg.Backends) == 0 {
+		return nil, fmt.Errorf("no secret backend configured")
+	}
+
+	backends := make([]core.StructuredSecretManager, 0, len(cfg.Backends))
+	for _, name := range cfg.Backends {
+		var backend core.StructuredSecretManager
+		var cacheTTL config.Duration
+		switch name {
+		case BackendEnv:
+			backend, cacheTTL = NewEnvSecretManager(cfg.Env.Prefix), cfg.Env.CacheTTL
+		case BackendFile:
+			backend, cacheTTL = NewFileSecretManager(cfg.File.Root), cfg.File.CacheTTL
+		case BackendK8s:
+			if kubeClient == nil {
+				return nil, fmt.Errorf("the k8s secret backend requires a kube client")
+			}
+
+			if len(cfg.K8s.Namespace) == 0 {
+				return nil, fmt.Errorf("the k8s secret backend requires a namespace")
+			}
+
+			backend, cacheTTL = NewK8sSecretManager(kubeClient, cfg.K8s.Namespace), cfg.K8s.CacheTTL
+		default:
+			return nil, fmt.Errorf("unknown secret backend [%v]", name)
+		}
+
+		backends = append(backends, NewCachedSecretManager(backend, cacheTTL.Duration, clock.RealClock{}))
+	}
+
+	logger.Infof(ctx, "Looking secrets up in %v", cfg.Backends)
+	if len(backends) == 1 {
+		return backends[0], nil
+	}
+
+	return NewChainedSecretManager(backends...), nil
+}
@@ -0,0 +1,81 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+func TestChainedSecretManager(t *testing.T) {
+	ctx := context.TODO()
+	ref := core.SecretReference{Group: "qubole", Key: "token"}
+
+	missing := &mocks.StructuredSecretManager{}
+	missing.OnGetSecret(ctx, ref).Return("", notFound(ref, "test"))
+	found := &mocks.StructuredSecretManager{}
+	found.OnGetSecret(ctx, ref).Return("secret", nil)
+	failing := &mocks.StructuredSecretManager{}
+	failing.OnGetSecret(ctx, ref).Return("", fmt.Errorf("unavailable"))
+
+	value, err := NewChainedSecretManager(missing, found).GetSecret(ctx, ref)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", value)
+
+	_, err = NewChainedSecretManager(failing, found).GetSecret(ctx, ref)
+	assert.EqualError(t, err, "unavailable")
+
+	_, err = NewChainedSecretManager(missing, missing).GetSecret(ctx, ref)
+	assertNotFound(t, err)
+
+	_, err = NewChainedSecretManager().GetSecret(ctx, ref)
+	assertNotFound(t, err)
+}
+
+func TestNewSecretManager(t *testing.T) {
+	ctx := context.TODO()
+	kubeClient := mocks.NewFakeKubeClient()
+	assert.NoError(t, kubeClient.Create(ctx, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "flyte", Name: "presto"},
+		Data:       map[string][]byte{"token": []byte("from-k8s")},
+	}))
+
+	assert.NoError(t, os.Setenv("TEST_SECRET_QUBOLE_TOKEN", "from-env"))
+	defer func() { assert.NoError(t, os.Unsetenv("TEST_SECRET_QUBOLE_TOKEN")) }()
+
+	cfg := *defaultConfig
+	cfg.Backends = []string{BackendEnv, BackendK8s}
+	cfg.Env.Prefix = "TEST_SECRET_"
+
+	sm, err := NewSecretManager(ctx, &cfg, kubeClient)
+	assert.NoError(t, err)
+
+	value, err := sm.GetSecret(ctx, core.SecretReference{Group: "qubole", Key: "token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "from-env", value)
+
+	value, err = sm.GetSecret(ctx, core.SecretReference{Group: "presto", Key: "token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "from-k8s", value)
+
+	t.Run("Invalid config", func(t *testing.T) {
+		_, err := NewSecretManager(ctx, &cfg, nil)
+		assert.Error(t, err)
+
+		cfg := *defaultConfig
+		cfg.Backends = nil
+		_, err = NewSecretManager(ctx, &cfg, nil)
+		assert.Error(t, err)
+
+		cfg.Backends = []string{"vault"}
+		_, err = NewSecretManager(ctx, &cfg, nil)
+		assert.Error(t, err)
+	})
+}
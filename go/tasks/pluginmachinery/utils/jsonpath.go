@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ParseJSONPath parses a JSONPath expression (e.g. {.status.state}). The name identifies the expression in errors.
+func ParseJSONPath(name, expr string) (*jsonpath.JSONPath, error) {
+	jp := jsonpath.New(name)
+	if err := jp.Parse(expr); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath [%v] for [%v]: %w", expr, name, err)
+	}
+
+	return jp, nil
+}
+
+// EvaluateJSONPath extracts the value matching the JSONPath expression from a decoded JSON document. Objects and arrays
+// are returned as JSON. It fails if the expression refers to keys missing from the document.
+func EvaluateJSONPath(name, expr string, data interface{}) (string, error) {
+	return evaluateJSONPath(name, expr, data, false)
+}
+
+// EvaluateJSONPathOrEmpty behaves like EvaluateJSONPath, except that expressions referring to keys missing from the
+// document evaluate to an empty string.
+func EvaluateJSONPathOrEmpty(name, expr string, data interface{}) (string, error) {
+	return evaluateJSONPath(name, expr, data, true)
+}
+
+func evaluateJSONPath(name, expr string, data interface{}, allowMissingKeys bool) (string, error) {
+	// Parsed expressions hold evaluation state, they can't be shared across goroutines.
+	jp, err := ParseJSONPath(name, expr)
+	if err != nil {
+		return "", err
+	}
+
+	jp.AllowMissingKeys(allowMissingKeys)
+	buf := &bytes.Buffer{}
+	if err = jp.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("failed to evaluate JSONPath [%v] for [%v]: %w", expr, name, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateJSONPath(t *testing.T) {
+	data := map[string]interface{}{
+		"status": map[string]interface{}{
+			"state": "Running",
+			"workers": []interface{}{
+				map[string]interface{}{"name": "worker-0"},
+			},
+		},
+	}
+
+	t.Run("value", func(t *testing.T) {
+		v, err := EvaluateJSONPath("state", "{.status.state}", data)
+		assert.NoError(t, err)
+		assert.Equal(t, "Running", v)
+	})
+
+	t.Run("object", func(t *testing.T) {
+		v, err := EvaluateJSONPath("workers", "{.status.workers}", data)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"name": "worker-0"}]`, v)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := EvaluateJSONPath("message", "{.status.message}", data)
+		assert.Error(t, err)
+
+		v, err := EvaluateJSONPathOrEmpty("message", "{.status.message}", data)
+		assert.NoError(t, err)
+		assert.Empty(t, v)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := ParseJSONPath("state", "{.status.state")
+		assert.Error(t, err)
+
+		_, err = EvaluateJSONPathOrEmpty("state", "{.status.state", data)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"context"
+	"sync"
+
+	"github.com/flyteorg/flytestdlib/cache"
+)
+
+// RefreshWorkers tracks the syncs run by the workers of an auto-refresh cache so that stopping the cache can wait for
+// them. Cancelling the context of a cache.AutoRefresh only signals its workers; a worker may still be running a sync,
+// or pick up one more batch, after the cancellation. A nil RefreshWorkers doesn't track anything.
+type RefreshWorkers struct {
+	m       sync.Mutex
+	stopped bool
+	running sync.WaitGroup
+}
+
+// Wrap returns a sync function that runs syncCb until the workers are stopped. Batches synced after Stop are left
+// unchanged.
+func (r *RefreshWorkers) Wrap(syncCb cache.SyncFunc) cache.SyncFunc {
+	if r == nil {
+		return syncCb
+	}
+
+	return func(ctx context.Context, batch cache.Batch) ([]cache.ItemSyncResponse, error) {
+		r.m.Lock()
+		if r.stopped {
+			r.m.Unlock()
+			return nil, nil
+		}
+
+		r.running.Add(1)
+		r.m.Unlock()
+
+		defer r.running.Done()
+		return syncCb(ctx, batch)
+	}
+}
+
+// Stop prevents any further sync and waits for the running ones to return, or until ctx is done. The context of the
+// cache should be cancelled first for the running syncs to return early.
+func (r *RefreshWorkers) Stop(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+
+	r.m.Lock()
+	r.stopped = true
+	r.m.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshWorkers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Waits for running syncs", func(t *testing.T) {
+		workers := &RefreshWorkers{}
+		started := make(chan struct{})
+		release := make(chan struct{})
+		syncCb := workers.Wrap(func(ctx context.Context, batch cache.Batch) ([]cache.ItemSyncResponse, error) {
+			close(started)
+			<-release
+			return []cache.ItemSyncResponse{{ID: "id", Action: cache.Update}}, nil
+		})
+
+		go func() {
+			_, err := syncCb(ctx, nil)
+			assert.NoError(t, err)
+		}()
+
+		<-started
+		stopCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		assert.Equal(t, context.DeadlineExceeded, workers.Stop(stopCtx))
+
+		close(release)
+		assert.NoError(t, workers.Stop(ctx))
+	})
+
+	t.Run("Skips syncs after stop", func(t *testing.T) {
+		workers := &RefreshWorkers{}
+		called := false
+		syncCb := workers.Wrap(func(ctx context.Context, batch cache.Batch) ([]cache.ItemSyncResponse, error) {
+			called = true
+			return nil, nil
+		})
+
+		assert.NoError(t, workers.Stop(ctx))
+		resp, err := syncCb(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, resp)
+		assert.False(t, called)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var workers *RefreshWorkers
+		called := false
+		_, err := workers.Wrap(func(ctx context.Context, batch cache.Batch) ([]cache.ItemSyncResponse, error) {
+			called = true
+			return nil, nil
+		})(ctx, nil)
+		assert.NoError(t, err)
+		assert.True(t, called)
+		assert.NoError(t, workers.Stop(ctx))
+	})
+}
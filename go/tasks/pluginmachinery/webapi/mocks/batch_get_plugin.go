@@ -0,0 +1,88 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webapi "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BatchGetPlugin is an autogenerated mock type for the BatchGetPlugin type
+type BatchGetPlugin struct {
+	mock.Mock
+}
+
+type BatchGetPlugin_BatchGet struct {
+	*mock.Call
+}
+
+func (_m BatchGetPlugin_BatchGet) Return(latest map[string]interface{}, err error) *BatchGetPlugin_BatchGet {
+	return &BatchGetPlugin_BatchGet{Call: _m.Call.Return(latest, err)}
+}
+
+func (_m *BatchGetPlugin) OnBatchGet(ctx context.Context, tCtxs []webapi.GetContext) *BatchGetPlugin_BatchGet {
+	c := _m.On("BatchGet", ctx, tCtxs)
+	return &BatchGetPlugin_BatchGet{Call: c}
+}
+
+func (_m *BatchGetPlugin) OnBatchGetMatch(matchers ...interface{}) *BatchGetPlugin_BatchGet {
+	c := _m.On("BatchGet", matchers...)
+	return &BatchGetPlugin_BatchGet{Call: c}
+}
+
+// BatchGet provides a mock function with given fields: ctx, tCtxs
+func (_m *BatchGetPlugin) BatchGet(ctx context.Context, tCtxs []webapi.GetContext) (map[string]interface{}, error) {
+	ret := _m.Called(ctx, tCtxs)
+
+	var r0 map[string]interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, []webapi.GetContext) map[string]interface{}); ok {
+		r0 = rf(ctx, tCtxs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []webapi.GetContext) error); ok {
+		r1 = rf(ctx, tCtxs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type BatchGetPlugin_ResourceKey struct {
+	*mock.Call
+}
+
+func (_m BatchGetPlugin_ResourceKey) Return(_a0 string) *BatchGetPlugin_ResourceKey {
+	return &BatchGetPlugin_ResourceKey{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *BatchGetPlugin) OnResourceKey(resourceMeta interface{}) *BatchGetPlugin_ResourceKey {
+	c := _m.On("ResourceKey", resourceMeta)
+	return &BatchGetPlugin_ResourceKey{Call: c}
+}
+
+func (_m *BatchGetPlugin) OnResourceKeyMatch(matchers ...interface{}) *BatchGetPlugin_ResourceKey {
+	c := _m.On("ResourceKey", matchers...)
+	return &BatchGetPlugin_ResourceKey{Call: c}
+}
+
+// ResourceKey provides a mock function with given fields: resourceMeta
+func (_m *BatchGetPlugin) ResourceKey(resourceMeta interface{}) string {
+	ret := _m.Called(resourceMeta)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(interface{}) string); ok {
+		r0 = rf(resourceMeta)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
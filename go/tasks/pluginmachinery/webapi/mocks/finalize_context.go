@@ -0,0 +1,219 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	core "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	io "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FinalizeContext is an autogenerated mock type for the FinalizeContext type
+type FinalizeContext struct {
+	mock.Mock
+}
+
+type FinalizeContext_InputReader struct {
+	*mock.Call
+}
+
+func (_m FinalizeContext_InputReader) Return(_a0 io.InputReader) *FinalizeContext_InputReader {
+	return &FinalizeContext_InputReader{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *FinalizeContext) OnInputReader() *FinalizeContext_InputReader {
+	c := _m.On("InputReader")
+	return &FinalizeContext_InputReader{Call: c}
+}
+
+func (_m *FinalizeContext) OnInputReaderMatch(matchers ...interface{}) *FinalizeContext_InputReader {
+	c := _m.On("InputReader", matchers...)
+	return &FinalizeContext_InputReader{Call: c}
+}
+
+// InputReader provides a mock function with given fields:
+func (_m *FinalizeContext) InputReader() io.InputReader {
+	ret := _m.Called()
+
+	var r0 io.InputReader
+	if rf, ok := ret.Get(0).(func() io.InputReader); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.InputReader)
+		}
+	}
+
+	return r0
+}
+
+type FinalizeContext_OutputWriter struct {
+	*mock.Call
+}
+
+func (_m FinalizeContext_OutputWriter) Return(_a0 io.OutputWriter) *FinalizeContext_OutputWriter {
+	return &FinalizeContext_OutputWriter{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *FinalizeContext) OnOutputWriter() *FinalizeContext_OutputWriter {
+	c := _m.On("OutputWriter")
+	return &FinalizeContext_OutputWriter{Call: c}
+}
+
+func (_m *FinalizeContext) OnOutputWriterMatch(matchers ...interface{}) *FinalizeContext_OutputWriter {
+	c := _m.On("OutputWriter", matchers...)
+	return &FinalizeContext_OutputWriter{Call: c}
+}
+
+// OutputWriter provides a mock function with given fields:
+func (_m *FinalizeContext) OutputWriter() io.OutputWriter {
+	ret := _m.Called()
+
+	var r0 io.OutputWriter
+	if rf, ok := ret.Get(0).(func() io.OutputWriter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.OutputWriter)
+		}
+	}
+
+	return r0
+}
+
+type FinalizeContext_ResourceMeta struct {
+	*mock.Call
+}
+
+func (_m FinalizeContext_ResourceMeta) Return(_a0 interface{}) *FinalizeContext_ResourceMeta {
+	return &FinalizeContext_ResourceMeta{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *FinalizeContext) OnResourceMeta() *FinalizeContext_ResourceMeta {
+	c := _m.On("ResourceMeta")
+	return &FinalizeContext_ResourceMeta{Call: c}
+}
+
+func (_m *FinalizeContext) OnResourceMetaMatch(matchers ...interface{}) *FinalizeContext_ResourceMeta {
+	c := _m.On("ResourceMeta", matchers...)
+	return &FinalizeContext_ResourceMeta{Call: c}
+}
+
+// ResourceMeta provides a mock function with given fields:
+func (_m *FinalizeContext) ResourceMeta() interface{} {
+	ret := _m.Called()
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func() interface{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	return r0
+}
+
+type FinalizeContext_SecretManager struct {
+	*mock.Call
+}
+
+func (_m FinalizeContext_SecretManager) Return(_a0 core.SecretManager) *FinalizeContext_SecretManager {
+	return &FinalizeContext_SecretManager{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *FinalizeContext) OnSecretManager() *FinalizeContext_SecretManager {
+	c := _m.On("SecretManager")
+	return &FinalizeContext_SecretManager{Call: c}
+}
+
+func (_m *FinalizeContext) OnSecretManagerMatch(matchers ...interface{}) *FinalizeContext_SecretManager {
+	c := _m.On("SecretManager", matchers...)
+	return &FinalizeContext_SecretManager{Call: c}
+}
+
+// SecretManager provides a mock function with given fields:
+func (_m *FinalizeContext) SecretManager() core.SecretManager {
+	ret := _m.Called()
+
+	var r0 core.SecretManager
+	if rf, ok := ret.Get(0).(func() core.SecretManager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.SecretManager)
+		}
+	}
+
+	return r0
+}
+
+type FinalizeContext_TaskExecutionMetadata struct {
+	*mock.Call
+}
+
+func (_m FinalizeContext_TaskExecutionMetadata) Return(_a0 core.TaskExecutionMetadata) *FinalizeContext_TaskExecutionMetadata {
+	return &FinalizeContext_TaskExecutionMetadata{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *FinalizeContext) OnTaskExecutionMetadata() *FinalizeContext_TaskExecutionMetadata {
+	c := _m.On("TaskExecutionMetadata")
+	return &FinalizeContext_TaskExecutionMetadata{Call: c}
+}
+
+func (_m *FinalizeContext) OnTaskExecutionMetadataMatch(matchers ...interface{}) *FinalizeContext_TaskExecutionMetadata {
+	c := _m.On("TaskExecutionMetadata", matchers...)
+	return &FinalizeContext_TaskExecutionMetadata{Call: c}
+}
+
+// TaskExecutionMetadata provides a mock function with given fields:
+func (_m *FinalizeContext) TaskExecutionMetadata() core.TaskExecutionMetadata {
+	ret := _m.Called()
+
+	var r0 core.TaskExecutionMetadata
+	if rf, ok := ret.Get(0).(func() core.TaskExecutionMetadata); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.TaskExecutionMetadata)
+		}
+	}
+
+	return r0
+}
+
+type FinalizeContext_TaskReader struct {
+	*mock.Call
+}
+
+func (_m FinalizeContext_TaskReader) Return(_a0 core.TaskReader) *FinalizeContext_TaskReader {
+	return &FinalizeContext_TaskReader{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *FinalizeContext) OnTaskReader() *FinalizeContext_TaskReader {
+	c := _m.On("TaskReader")
+	return &FinalizeContext_TaskReader{Call: c}
+}
+
+func (_m *FinalizeContext) OnTaskReaderMatch(matchers ...interface{}) *FinalizeContext_TaskReader {
+	c := _m.On("TaskReader", matchers...)
+	return &FinalizeContext_TaskReader{Call: c}
+}
+
+// TaskReader provides a mock function with given fields:
+func (_m *FinalizeContext) TaskReader() core.TaskReader {
+	ret := _m.Called()
+
+	var r0 core.TaskReader
+	if rf, ok := ret.Get(0).(func() core.TaskReader); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.TaskReader)
+		}
+	}
+
+	return r0
+}
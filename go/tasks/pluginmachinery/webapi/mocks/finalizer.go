@@ -0,0 +1,47 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webapi "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Finalizer is an autogenerated mock type for the Finalizer type
+type Finalizer struct {
+	mock.Mock
+}
+
+type Finalizer_Finalize struct {
+	*mock.Call
+}
+
+func (_m Finalizer_Finalize) Return(_a0 error) *Finalizer_Finalize {
+	return &Finalizer_Finalize{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *Finalizer) OnFinalize(ctx context.Context, tCtx webapi.FinalizeContext) *Finalizer_Finalize {
+	c := _m.On("Finalize", ctx, tCtx)
+	return &Finalizer_Finalize{Call: c}
+}
+
+func (_m *Finalizer) OnFinalizeMatch(matchers ...interface{}) *Finalizer_Finalize {
+	c := _m.On("Finalize", matchers...)
+	return &Finalizer_Finalize{Call: c}
+}
+
+// Finalize provides a mock function with given fields: ctx, tCtx
+func (_m *Finalizer) Finalize(ctx context.Context, tCtx webapi.FinalizeContext) error {
+	ret := _m.Called(ctx, tCtx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, webapi.FinalizeContext) error); ok {
+		r0 = rf(ctx, tCtx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -9,6 +9,40 @@ type GetContext struct {
 	mock.Mock
 }
 
+type GetContext_Resource struct {
+	*mock.Call
+}
+
+func (_m GetContext_Resource) Return(_a0 interface{}) *GetContext_Resource {
+	return &GetContext_Resource{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *GetContext) OnResource() *GetContext_Resource {
+	c := _m.On("Resource")
+	return &GetContext_Resource{Call: c}
+}
+
+func (_m *GetContext) OnResourceMatch(matchers ...interface{}) *GetContext_Resource {
+	c := _m.On("Resource", matchers...)
+	return &GetContext_Resource{Call: c}
+}
+
+// Resource provides a mock function with given fields:
+func (_m *GetContext) Resource() interface{} {
+	ret := _m.Called()
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func() interface{}); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	return r0
+}
+
 type GetContext_ResourceMeta struct {
 	*mock.Call
 }
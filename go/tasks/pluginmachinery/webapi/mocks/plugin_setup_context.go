@@ -3,8 +3,10 @@
 package mocks
 
 import (
-	promutils "github.com/flyteorg/flytestdlib/promutils"
+	core "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	mock "github.com/stretchr/testify/mock"
+
+	promutils "github.com/flyteorg/flytestdlib/promutils"
 )
 
 // PluginSetupContext is an autogenerated mock type for the PluginSetupContext type
@@ -45,3 +47,37 @@ func (_m *PluginSetupContext) MetricsScope() promutils.Scope {
 
 	return r0
 }
+
+type PluginSetupContext_SecretManager struct {
+	*mock.Call
+}
+
+func (_m PluginSetupContext_SecretManager) Return(_a0 core.SecretManager) *PluginSetupContext_SecretManager {
+	return &PluginSetupContext_SecretManager{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *PluginSetupContext) OnSecretManager() *PluginSetupContext_SecretManager {
+	c := _m.On("SecretManager")
+	return &PluginSetupContext_SecretManager{Call: c}
+}
+
+func (_m *PluginSetupContext) OnSecretManagerMatch(matchers ...interface{}) *PluginSetupContext_SecretManager {
+	c := _m.On("SecretManager", matchers...)
+	return &PluginSetupContext_SecretManager{Call: c}
+}
+
+// SecretManager provides a mock function with given fields:
+func (_m *PluginSetupContext) SecretManager() core.SecretManager {
+	ret := _m.Called()
+
+	var r0 core.SecretManager
+	if rf, ok := ret.Get(0).(func() core.SecretManager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.SecretManager)
+		}
+	}
+
+	return r0
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ResourceUpdateSink is an autogenerated mock type for the ResourceUpdateSink type
+type ResourceUpdateSink struct {
+	mock.Mock
+}
+
+type ResourceUpdateSink_Push struct {
+	*mock.Call
+}
+
+func (_m ResourceUpdateSink_Push) Return(_a0 bool) *ResourceUpdateSink_Push {
+	return &ResourceUpdateSink_Push{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ResourceUpdateSink) OnPush(ctx context.Context, resourceMeta interface{}, resource interface{}) *ResourceUpdateSink_Push {
+	c := _m.On("Push", ctx, resourceMeta, resource)
+	return &ResourceUpdateSink_Push{Call: c}
+}
+
+func (_m *ResourceUpdateSink) OnPushMatch(matchers ...interface{}) *ResourceUpdateSink_Push {
+	c := _m.On("Push", matchers...)
+	return &ResourceUpdateSink_Push{Call: c}
+}
+
+// Push provides a mock function with given fields: ctx, resourceMeta, resource
+func (_m *ResourceUpdateSink) Push(ctx context.Context, resourceMeta interface{}, resource interface{}) bool {
+	ret := _m.Called(ctx, resourceMeta, resource)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, interface{}) bool); ok {
+		r0 = rf(ctx, resourceMeta, resource)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
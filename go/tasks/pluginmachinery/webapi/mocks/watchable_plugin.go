@@ -0,0 +1,79 @@
+// Code generated by mockery v1.0.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webapi "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WatchablePlugin is an autogenerated mock type for the WatchablePlugin type
+type WatchablePlugin struct {
+	mock.Mock
+}
+
+type WatchablePlugin_ResourceKey struct {
+	*mock.Call
+}
+
+func (_m WatchablePlugin_ResourceKey) Return(_a0 string) *WatchablePlugin_ResourceKey {
+	return &WatchablePlugin_ResourceKey{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *WatchablePlugin) OnResourceKey(resourceMeta interface{}) *WatchablePlugin_ResourceKey {
+	c := _m.On("ResourceKey", resourceMeta)
+	return &WatchablePlugin_ResourceKey{Call: c}
+}
+
+func (_m *WatchablePlugin) OnResourceKeyMatch(matchers ...interface{}) *WatchablePlugin_ResourceKey {
+	c := _m.On("ResourceKey", matchers...)
+	return &WatchablePlugin_ResourceKey{Call: c}
+}
+
+// ResourceKey provides a mock function with given fields: resourceMeta
+func (_m *WatchablePlugin) ResourceKey(resourceMeta interface{}) string {
+	ret := _m.Called(resourceMeta)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(interface{}) string); ok {
+		r0 = rf(resourceMeta)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type WatchablePlugin_Watch struct {
+	*mock.Call
+}
+
+func (_m WatchablePlugin_Watch) Return(_a0 error) *WatchablePlugin_Watch {
+	return &WatchablePlugin_Watch{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *WatchablePlugin) OnWatch(ctx context.Context, sink webapi.ResourceUpdateSink) *WatchablePlugin_Watch {
+	c := _m.On("Watch", ctx, sink)
+	return &WatchablePlugin_Watch{Call: c}
+}
+
+func (_m *WatchablePlugin) OnWatchMatch(matchers ...interface{}) *WatchablePlugin_Watch {
+	c := _m.On("Watch", matchers...)
+	return &WatchablePlugin_Watch{Call: c}
+}
+
+// Watch provides a mock function with given fields: ctx, sink
+func (_m *WatchablePlugin) Watch(ctx context.Context, sink webapi.ResourceUpdateSink) error {
+	ret := _m.Called(ctx, sink)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, webapi.ResourceUpdateSink) error); ok {
+		r0 = rf(ctx, sink)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -24,6 +24,10 @@ import (
 // that the plugin loader will be called before any Handle/Abort/Finalize functions are invoked
 type PluginLoader func(ctx context.Context, iCtx PluginSetupContext) (AsyncPlugin, error)
 
+// A Lazy loading function, that will load a SyncPlugin. Plugins should be initialized in this method. It is guaranteed
+// that the plugin loader will be called before any Handle/Abort/Finalize functions are invoked
+type SyncPluginLoader func(ctx context.Context, iCtx PluginSetupContext) (SyncPlugin, error)
+
 // PluginEntry is a structure that is used to indicate to the system a K8s plugin
 type PluginEntry struct {
 	// ID/Name of the plugin. This will be used to identify this plugin and has to be unique in the entire system
@@ -46,10 +50,36 @@ type PluginEntry struct {
 	DefaultForTaskTypes []pluginsCore.TaskType
 }
 
+// SyncPluginEntry is a structure that is used to indicate to the system a SyncPlugin
+type SyncPluginEntry struct {
+	// ID/Name of the plugin. This will be used to identify this plugin and has to be unique in the entire system
+	// All functions like enabling and disabling a plugin use this ID
+	ID pluginsCore.TaskType
+
+	// A list of all the task types for which this plugin is applicable.
+	SupportedTaskTypes []pluginsCore.TaskType
+
+	// An instance of the plugin
+	PluginLoader SyncPluginLoader
+
+	// Boolean that indicates if this plugin can be used as the default for unknown task types. There can only be
+	// one default in the system
+	IsDefault bool
+
+	// A list of all task types for which this plugin should be default handler when multiple registered plugins
+	// support the same task type. This must be a subset of RegisteredTaskTypes and at most one default per task type
+	// is supported.
+	DefaultForTaskTypes []pluginsCore.TaskType
+}
+
 // PluginSetupContext is the interface made available to the plugin loader when initializing the plugin.
 type PluginSetupContext interface {
 	// a metrics scope to publish stats under
 	MetricsScope() promutils.Scope
+
+	// Returns a secret manager that can retrieve configured secrets for this plugin. Unlike the one available through
+	// the TaskExecutionContextReader, it can be used in calls that don't have a task context (e.g. Get and Delete).
+	SecretManager() pluginsCore.SecretManager
 }
 
 type TaskExecutionContextReader interface {
@@ -81,6 +111,9 @@ type TaskExecutionContext interface {
 
 type GetContext interface {
 	ResourceMeta() ResourceMeta
+
+	// Resource returns the last retrieved version of the resource, or nil if it hasn't been retrieved yet.
+	Resource() Resource
 }
 
 type DeleteContext interface {
@@ -99,7 +132,9 @@ type StatusContext interface {
 type ResourceMeta = interface{}
 type Resource = interface{}
 
-// AsyncPlugin defines the interface for plugins that call Async Web APIs.
+// AsyncPlugin defines the interface for plugins that call Async Web APIs. Plugins can optionally implement
+// pluginsCore.TaskValidator to validate task templates ahead of their execution, pluginsCore.HealthChecker to report
+// the health of the remote service and pluginsCore.Stopper to release what they hold when Flyte shuts down.
 type AsyncPlugin interface {
 	// GetConfig gets the loaded plugin config. This will be used to control the interactions with the remote service.
 	GetConfig() PluginConfig
@@ -137,6 +172,8 @@ type AsyncPlugin interface {
 
 	// Delete the object in the remote service using the resource key. Flyte will call this API at least once. If the
 	// resource has already been deleted, the API should not fail.
+	// The reason for the deletion is available through the DeleteContext: "Aborted" when the execution is aborted
+	// and "Timeout" when the resource exceeded the timeout defined in the task metadata.
 	Delete(ctx context.Context, tCtx DeleteContext) error
 
 	// Status checks the status of a given resource and translates it to a Flyte-understandable PhaseInfo. This API
@@ -144,11 +181,74 @@ type AsyncPlugin interface {
 	Status(ctx context.Context, tCtx StatusContext) (phase pluginsCore.PhaseInfo, err error)
 }
 
-// SyncPlugin defines the interface for plugins that call Web APIs synchronously.
+// BatchGetPlugin is an optional interface an AsyncPlugin can implement to retrieve the status of multiple resources in
+// a single call to the remote service. When implemented, the system groups in-flight resources into batches of up to
+// CachingConfig.BatchSize and calls BatchGet instead of Get.
+type BatchGetPlugin interface {
+	// ResourceKey returns a unique and stable key for the resource identified by resourceMeta. It's used to match the
+	// resources returned by BatchGet to the requested ones.
+	ResourceKey(resourceMeta ResourceMeta) string
+
+	// BatchGet retrieves the resources that match all the GetContexts. The returned map is keyed by ResourceKey.
+	// Resources missing from the map are counted as failures to sync for the corresponding tasks. If the plugin hits
+	// any failure that affects the entire batch, it should stop and return the failure.
+	BatchGet(ctx context.Context, tCtxs []GetContext) (latest map[string]Resource, err error)
+}
+
+// ResourceUpdateSink receives resource updates pushed by a WatchablePlugin.
+type ResourceUpdateSink interface {
+	// Push records the latest version of the resource identified by resourceMeta and signals the owning task to be
+	// re-evaluated immediately. It returns false if no in-flight task is tracking the resource; such updates are
+	// dropped.
+	Push(ctx context.Context, resourceMeta ResourceMeta, resource Resource) bool
+}
+
+// WatchablePlugin is an optional interface an AsyncPlugin can implement to push resource updates (e.g. from a webhook
+// receiver, a queue consumer or a stream) instead of waiting for the next periodic Get. Polling through Get remains in
+// place as a fallback.
+type WatchablePlugin interface {
+	// ResourceKey returns a unique and stable key for the resource identified by resourceMeta. Pushed updates are
+	// matched to in-flight tasks using this key.
+	ResourceKey(resourceMeta ResourceMeta) string
+
+	// Watch starts watching the remote service for updates and pushes them into the provided sink. It's called once
+	// when the plugin is loaded and must not block; the watcher is expected to stop once ctx is done, which happens
+	// when the plugin is stopped.
+	Watch(ctx context.Context, sink ResourceUpdateSink) error
+}
+
+type FinalizeContext interface {
+	TaskExecutionContextReader
+
+	ResourceMeta() ResourceMeta
+}
+
+// Finalizer is an optional interface an AsyncPlugin can implement to clean up side-effects a resource left behind in
+// the remote service (e.g. temporary tables, staging buckets or open sessions) once it has succeeded. Finalize is
+// called with the ResourceMeta returned by Create when the task is finalized. A failed call fails the finalization of
+// the task, which Flyte retries.
+type Finalizer interface {
+	// Finalize cleans up after the resource. Flyte will call this API at least once. If there is nothing left to clean
+	// up, the API should not fail.
+	Finalize(ctx context.Context, tCtx FinalizeContext) error
+}
+
+// SyncPlugin defines the interface for plugins that call Web APIs synchronously. Plugins can optionally implement
+// pluginsCore.TaskValidator to validate task templates ahead of their execution, pluginsCore.HealthChecker to report
+// the health of the remote service and pluginsCore.Stopper to release what they hold when Flyte shuts down.
 type SyncPlugin interface {
 	// GetConfig gets the loaded plugin config. This will be used to control the interactions with the remote service.
 	GetConfig() PluginConfig
 
-	// Do performs the action associated with this plugin.
+	// ResourceRequirements analyzes the task to execute and determines the ResourceNamespace to be used when allocating
+	// tokens. It's only invoked if the plugin config defines ResourceQuotas.
+	ResourceRequirements(ctx context.Context, tCtx TaskExecutionContextReader) (
+		namespace pluginsCore.ResourceNamespace, constraints pluginsCore.ResourceConstraintsSpec, err error)
+
+	// Do performs the action associated with this plugin. The returned phase is expected to be terminal; the system
+	// persists it and will not call Do again for the same task execution once a terminal phase has been recorded. If
+	// a non-terminal phase is returned, Do will be invoked again in the next evaluation round.
+	// If the remote API failed due to a system error (network failure, timeout... etc.), the plugin should return a
+	// non-nil error. The system will automatically retry the operation.
 	Do(ctx context.Context, tCtx TaskExecutionContext) (phase pluginsCore.PhaseInfo, err error)
 }
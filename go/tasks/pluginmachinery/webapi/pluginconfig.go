@@ -16,6 +16,7 @@ var (
 			ResyncInterval:    config.Duration{Duration: 30 * time.Second},
 			Workers:           10,
 			MaxSystemFailures: 5,
+			BatchSize:         10,
 		},
 		ReadRateLimiter: RateLimiterConfig{
 			QPS:   30,
@@ -25,6 +26,13 @@ var (
 			QPS:   20,
 			Burst: 200,
 		},
+		CircuitBreaker: CircuitBreakerConfig{
+			Enabled:           false,
+			FailurePercentage: 50,
+			MinRequests:       20,
+			Window:            config.Duration{Duration: time.Minute},
+			OpenDuration:      config.Duration{Duration: 30 * time.Second},
+		},
 	}
 )
 
@@ -50,6 +58,28 @@ type CachingConfig struct {
 
 	// MaxSystemFailures defines the number of failures to fetch a task before failing the task.
 	MaxSystemFailures int `json:"maxSystemFailures" pflag:",Defines the number of failures to fetch a task before failing the task."`
+
+	// BatchSize controls how many resources are retrieved in a single call for plugins that implement BatchGetPlugin.
+	BatchSize int `json:"batchSize" pflag:",Defines the maximum number of resources to retrieve in a single batched Get."`
+}
+
+// The plugin manager stops calling the remote service once the percentage of failed calls within a window reaches the
+// configured threshold. After OpenDuration, a single call is let through to probe whether the service recovered.
+type CircuitBreakerConfig struct {
+	// Whether the circuit breaker is enabled.
+	Enabled bool `json:"enabled" pflag:",Defines whether the circuit breaker is enabled."`
+
+	// The percentage of failed calls within a window at which the circuit opens.
+	FailurePercentage int `json:"failurePercentage" pflag:",Defines the percentage of failed calls within a window at which the circuit opens."`
+
+	// The minimum number of calls within a window before the failure percentage is evaluated.
+	MinRequests int `json:"minRequests" pflag:",Defines the minimum number of calls within a window before the failure percentage is evaluated."`
+
+	// The duration of the window in which calls are counted.
+	Window config.Duration `json:"window" pflag:",Defines the duration of the window in which calls are counted."`
+
+	// How long the circuit stays open before a probe call is let through.
+	OpenDuration config.Duration `json:"openDuration" pflag:",Defines how long the circuit stays open before a probe call is let through."`
 }
 
 type ResourceQuotas map[core.ResourceNamespace]int
@@ -62,6 +92,12 @@ type PluginConfig struct {
 	ReadRateLimiter  RateLimiterConfig `json:"readRateLimiter" pflag:",Defines rate limiter properties for read actions (e.g. retrieve status)."`
 	WriteRateLimiter RateLimiterConfig `json:"writeRateLimiter" pflag:",Defines rate limiter properties for write actions."`
 	Caching          CachingConfig     `json:"caching" pflag:",Defines caching characteristics."`
+	// CircuitBreaker stops calls to the remote service while it's unhealthy. Its state is shared across Create and Get.
+	CircuitBreaker CircuitBreakerConfig `json:"circuitBreaker" pflag:",Defines circuit breaker properties for calls to the remote service."`
+	// ResourceLeaseTTL is how long allocation tokens are leased for, if the resource manager supports leases. Leases
+	// are renewed while the task is in flight; tokens of tasks that are never finalized are reclaimed once their lease
+	// expires. Leases are disabled if it's zero.
+	ResourceLeaseTTL config.Duration `json:"resourceLeaseTTL" pflag:",Defines how long allocation tokens are leased for. Leases are disabled if zero."`
 	// Gets an empty copy for the custom state that can be used in ResourceMeta when
 	// interacting with the remote service.
 	ResourceMeta ResourceMeta `json:"resourceMeta" pflag:"-,A copy for the custom state."`
@@ -49,5 +49,12 @@ func (cfg PluginConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "caching.resyncInterval"), DefaultPluginConfig.Caching.ResyncInterval.String(), "Defines the sync interval.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "caching.workers"), DefaultPluginConfig.Caching.Workers, "Defines the number of workers to start up to process items.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "caching.maxSystemFailures"), DefaultPluginConfig.Caching.MaxSystemFailures, "Defines the number of failures to fetch a task before failing the task.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "caching.batchSize"), DefaultPluginConfig.Caching.BatchSize, "Defines the maximum number of resources to retrieve in a single batched Get.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "circuitBreaker.enabled"), DefaultPluginConfig.CircuitBreaker.Enabled, "Defines whether the circuit breaker is enabled.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "circuitBreaker.failurePercentage"), DefaultPluginConfig.CircuitBreaker.FailurePercentage, "Defines the percentage of failed calls within a window at which the circuit opens.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "circuitBreaker.minRequests"), DefaultPluginConfig.CircuitBreaker.MinRequests, "Defines the minimum number of calls within a window before the failure percentage is evaluated.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "circuitBreaker.window"), DefaultPluginConfig.CircuitBreaker.Window.String(), "Defines the duration of the window in which calls are counted.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "circuitBreaker.openDuration"), DefaultPluginConfig.CircuitBreaker.OpenDuration.String(), "Defines how long the circuit stays open before a probe call is let through.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "resourceLeaseTTL"), DefaultPluginConfig.ResourceLeaseTTL.String(), "Defines how long allocation tokens are leased for. Leases are disabled if zero.")
 	return cmdFlags
 }
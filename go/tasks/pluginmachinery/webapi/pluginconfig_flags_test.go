@@ -275,4 +275,158 @@ func TestPluginConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_caching.batchSize", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("caching.batchSize"); err == nil {
+				assert.Equal(t, int(DefaultPluginConfig.Caching.BatchSize), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("caching.batchSize", testValue)
+			if vInt, err := cmdFlags.GetInt("caching.batchSize"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vInt), &actual.Caching.BatchSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuitBreaker.enabled", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("circuitBreaker.enabled"); err == nil {
+				assert.Equal(t, bool(DefaultPluginConfig.CircuitBreaker.Enabled), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("circuitBreaker.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("circuitBreaker.enabled"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vBool), &actual.CircuitBreaker.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuitBreaker.failurePercentage", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("circuitBreaker.failurePercentage"); err == nil {
+				assert.Equal(t, int(DefaultPluginConfig.CircuitBreaker.FailurePercentage), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("circuitBreaker.failurePercentage", testValue)
+			if vInt, err := cmdFlags.GetInt("circuitBreaker.failurePercentage"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vInt), &actual.CircuitBreaker.FailurePercentage)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuitBreaker.minRequests", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("circuitBreaker.minRequests"); err == nil {
+				assert.Equal(t, int(DefaultPluginConfig.CircuitBreaker.MinRequests), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("circuitBreaker.minRequests", testValue)
+			if vInt, err := cmdFlags.GetInt("circuitBreaker.minRequests"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vInt), &actual.CircuitBreaker.MinRequests)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuitBreaker.window", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("circuitBreaker.window"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.CircuitBreaker.Window.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultPluginConfig.CircuitBreaker.Window.String()
+
+			cmdFlags.Set("circuitBreaker.window", testValue)
+			if vString, err := cmdFlags.GetString("circuitBreaker.window"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.CircuitBreaker.Window)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_circuitBreaker.openDuration", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("circuitBreaker.openDuration"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.CircuitBreaker.OpenDuration.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultPluginConfig.CircuitBreaker.OpenDuration.String()
+
+			cmdFlags.Set("circuitBreaker.openDuration", testValue)
+			if vString, err := cmdFlags.GetString("circuitBreaker.openDuration"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.CircuitBreaker.OpenDuration)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_resourceLeaseTTL", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("resourceLeaseTTL"); err == nil {
+				assert.Equal(t, string(DefaultPluginConfig.ResourceLeaseTTL.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultPluginConfig.ResourceLeaseTTL.String()
+
+			cmdFlags.Set("resourceLeaseTTL", testValue)
+			if vString, err := cmdFlags.GetString("resourceLeaseTTL"); err == nil {
+				testDecodeJson_PluginConfig(t, fmt.Sprintf("%v", vString), &actual.ResourceLeaseTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
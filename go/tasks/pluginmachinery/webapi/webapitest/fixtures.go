@@ -0,0 +1,74 @@
+// Package webapitest provides fixtures to test implementations of webapi.AsyncPlugin.
+package webapitest
+
+import (
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/mock"
+
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+)
+
+const (
+	// GeneratedName is the generated name of the task executions returned by NewTaskExecutionContext.
+	GeneratedName = "my-id"
+	// OutputPrefix is the output prefix of the task executions returned by NewTaskExecutionContext.
+	OutputPrefix = storage.DataReference("s3://bucket/outputs")
+)
+
+// NewTaskExecutionContext returns the context of the first retry of a task execution that reads the given template and
+// inputs.
+func NewTaskExecutionContext(taskTemplate *idlCore.TaskTemplate, inputs *idlCore.LiteralMap) *mocks.TaskExecutionContextReader {
+	tr := &coreMocks.TaskReader{}
+	tr.OnReadMatch(mock.Anything).Return(taskTemplate, nil)
+
+	ir := &ioMocks.InputReader{}
+	ir.OnGetInputPath().Return(storage.DataReference("s3://bucket/inputs.pb"))
+	ir.OnGetInputPrefixPath().Return(storage.DataReference("s3://bucket"))
+	ir.OnGetMatch(mock.Anything).Return(inputs, nil)
+
+	ow := &ioMocks.OutputWriter{}
+	ow.OnGetOutputPrefixPath().Return(OutputPrefix)
+	ow.OnGetRawOutputPrefix().Return(storage.DataReference("s3://bucket/raw"))
+
+	tID := &coreMocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return(GeneratedName)
+	tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{RetryAttempt: 1})
+
+	tMeta := &coreMocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+
+	tCtx := &mocks.TaskExecutionContextReader{}
+	tCtx.OnTaskReader().Return(tr)
+	tCtx.OnInputReader().Return(ir)
+	tCtx.OnOutputWriter().Return(ow)
+	tCtx.OnTaskExecutionMetadata().Return(tMeta)
+	return tCtx
+}
+
+// NewGetContext returns the context to retrieve the resource identified by resourceMeta, last retrieved as resource.
+func NewGetContext(resourceMeta webapi.ResourceMeta, resource webapi.Resource) *mocks.GetContext {
+	gCtx := &mocks.GetContext{}
+	gCtx.OnResourceMeta().Return(resourceMeta)
+	gCtx.OnResource().Return(resource)
+	return gCtx
+}
+
+// NewStatusContext returns the context to compute the status of a resource of a task that reads the given template and
+// writes its outputs to ow.
+func NewStatusContext(taskTemplate *idlCore.TaskTemplate, resourceMeta webapi.ResourceMeta, resource webapi.Resource,
+	ow io.OutputWriter) *mocks.StatusContext {
+	tr := &coreMocks.TaskReader{}
+	tr.OnReadMatch(mock.Anything).Return(taskTemplate, nil)
+
+	sCtx := &mocks.StatusContext{}
+	sCtx.OnResourceMeta().Return(resourceMeta)
+	sCtx.OnResource().Return(resource)
+	sCtx.OnTaskReader().Return(tr)
+	sCtx.OnOutputWriter().Return(ow)
+	return sCtx
+}
@@ -125,3 +125,35 @@ func (_m *IndexedWorkQueue) Start(ctx context.Context) error {
 
 	return r0
 }
+
+type IndexedWorkQueue_Stop struct {
+	*mock.Call
+}
+
+func (_m IndexedWorkQueue_Stop) Return(_a0 error) *IndexedWorkQueue_Stop {
+	return &IndexedWorkQueue_Stop{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *IndexedWorkQueue) OnStop(ctx context.Context) *IndexedWorkQueue_Stop {
+	c := _m.On("Stop", ctx)
+	return &IndexedWorkQueue_Stop{Call: c}
+}
+
+func (_m *IndexedWorkQueue) OnStopMatch(matchers ...interface{}) *IndexedWorkQueue_Stop {
+	c := _m.On("Stop", matchers...)
+	return &IndexedWorkQueue_Stop{Call: c}
+}
+
+// Stop provides a mock function with given fields: ctx
+func (_m *IndexedWorkQueue) Stop(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
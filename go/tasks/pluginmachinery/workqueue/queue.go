@@ -33,6 +33,7 @@ const (
 
 const (
 	ErrNotYetStarted errors.ErrorCode = "NOT_STARTED"
+	ErrStopped       errors.ErrorCode = "STOPPED"
 )
 
 func (w WorkStatus) IsTerminal() bool {
@@ -62,6 +63,10 @@ type IndexedWorkQueue interface {
 
 	// Start must be called before queuing items into the queue.
 	Start(ctx context.Context) error
+
+	// Stop stops accepting new items and waits for the workers to process the queued items once and exit, or until ctx
+	// is done. Items that aren't done after that aren't requeued.
+	Stop(ctx context.Context) error
 }
 
 // Represents the processor logic to operate on work items.
@@ -113,6 +118,8 @@ type queue struct {
 	workers    int
 	maxRetries int
 	started    bool
+	stopped    bool
+	workersWg  sync.WaitGroup
 	queue      workqueue.Interface
 	index      workItemCache
 	processor  Processor
@@ -149,6 +156,10 @@ func (q *queue) Queue(ctx context.Context, id WorkItemID, once WorkItem) error {
 		return errors.Errorf(ErrNotYetStarted, "Queue must be started before enqueuing any item.")
 	}
 
+	if q.stopped {
+		return errors.Errorf(ErrStopped, "Queue has been stopped.")
+	}
+
 	if _, found := q.index.Get(id); found {
 		return nil
 	}
@@ -196,7 +207,9 @@ func (q *queue) Start(ctx context.Context) error {
 	}
 
 	for i := 0; i < q.workers; i++ {
+		q.workersWg.Add(1)
 		go func(ctx context.Context) {
+			defer q.workersWg.Done()
 			for {
 				select {
 				case <-ctx.Done():
@@ -255,6 +268,34 @@ func (q *queue) Start(ctx context.Context) error {
 	return nil
 }
 
+func (q *queue) Stop(ctx context.Context) error {
+	q.wlock.Lock()
+	if !q.started {
+		q.wlock.Unlock()
+		return errors.Errorf(ErrNotYetStarted, "Queue must be started before it's stopped.")
+	}
+
+	q.stopped = true
+	q.wlock.Unlock()
+
+	// Workers keep getting items until the queue is empty, items that are re-added after shutdown are dropped.
+	q.queue.ShutDown()
+
+	done := make(chan struct{})
+	go func() {
+		q.workersWg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logger.Infof(ctx, "Work queue [%v] stopped.", q.name)
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for the workers of queue [%v] to exit: %w", q.name, ctx.Err())
+	}
+}
+
 func newMetrics(scope promutils.Scope) metrics {
 	return metrics{
 		CacheHit:        scope.MustNewCounter("cache_hit", "Counter for cache hits."),
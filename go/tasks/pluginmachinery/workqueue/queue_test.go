@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/errors"
 
 	"github.com/go-test/deep"
 
@@ -234,6 +235,59 @@ func Test_queue_Start(t *testing.T) {
 	assert.Error(t, q.Start(ctx))
 }
 
+type blockingProcessor struct {
+	release chan struct{}
+}
+
+func (b blockingProcessor) Process(ctx context.Context, workItem WorkItem) (WorkStatus, error) {
+	<-b.release
+	return WorkStatusSucceeded, nil
+}
+
+func Test_queue_Stop(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Drains queued items", func(t *testing.T) {
+		q, err := NewIndexedWorkQueue("test1", newSingleStatusProcessor("", WorkStatusSucceeded),
+			Config{Workers: 2, MaxRetries: 0, IndexCacheMaxItems: 10}, promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.Error(t, q.Stop(ctx))
+
+		assert.NoError(t, q.Start(ctx))
+		assert.NoError(t, q.Queue(ctx, "abc", "hello"))
+		assert.NoError(t, q.Queue(ctx, "def", "world"))
+		assert.NoError(t, q.Stop(ctx))
+
+		for _, id := range []WorkItemID{"abc", "def"} {
+			info, found, err := q.Get(id)
+			assert.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, WorkStatusSucceeded, info.Status())
+		}
+
+		err = q.Queue(ctx, "ghi", "again")
+		assert.Error(t, err)
+		code, found := errors.GetErrorCode(err)
+		assert.True(t, found)
+		assert.Equal(t, ErrStopped, code)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		processor := blockingProcessor{release: make(chan struct{})}
+		defer close(processor.release)
+
+		q, err := NewIndexedWorkQueue("test1", processor, Config{Workers: 1, MaxRetries: 0, IndexCacheMaxItems: 10},
+			promutils.NewTestScope())
+		assert.NoError(t, err)
+		assert.NoError(t, q.Start(ctx))
+		assert.NoError(t, q.Queue(ctx, "abc", "hello"))
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		assert.Error(t, q.Stop(timeoutCtx))
+	})
+}
+
 func Test_Failures(t *testing.T) {
 	q, err := NewIndexedWorkQueue("test1", alwaysFailingProcessor{}, Config{Workers: 1, MaxRetries: 0, IndexCacheMaxItems: 1}, promutils.NewTestScope())
 	assert.NoError(t, err)
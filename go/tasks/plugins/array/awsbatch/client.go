@@ -23,6 +23,9 @@ import (
 
 //go:generate mockery -all -case=underscore
 
+// The ID of the job described to check the health of AWS Batch. It's not expected to exist.
+const healthCheckJobID = "flyte-health-check"
+
 // AWS Batch Client interface.
 type Client interface {
 	// Submits a new job to AWS Batch and retrieves job info. Note that submitted jobs will not have status populated.
@@ -154,6 +157,23 @@ func (b *client) GetJobDetailsBatch(ctx context.Context, jobIds []JobID) ([]*bat
 	return output.Jobs, nil
 }
 
+// Checks that AWS Batch is reachable by describing a job that doesn't exist.
+func (b *client) CheckHealth(ctx context.Context) error {
+	if err := b.getRateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	input := batch.DescribeJobsInput{
+		Jobs: []*string{refStr(healthCheckJobID)},
+	}
+
+	if _, err := b.Batch.DescribeJobsWithContext(ctx, &input); err != nil {
+		return fmt.Errorf("AWS Batch in region [%v] is unreachable: %w", b.region, err)
+	}
+
+	return nil
+}
+
 // Initializes a new Batch Client that can be used to interact with AWS Batch.
 func NewBatchClient(awsClient aws.Client,
 	getRateLimiter utils.RateLimiter,
@@ -6,6 +6,7 @@ package awsbatch
 
 import (
 	"context"
+	"fmt"
 
 	stdConfig "github.com/flyteorg/flytestdlib/config"
 
@@ -18,6 +19,7 @@ import (
 
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/batch"
 	"github.com/stretchr/testify/assert"
 )
@@ -79,6 +81,18 @@ func TestClient_GetJobDetailsBatch(t *testing.T) {
 	assert.Equal(t, "fake_job_id", *o[0].JobId)
 }
 
+func TestClient_CheckHealth(t *testing.T) {
+	c := newClientWithMockBatch()
+	assert.NoError(t, c.CheckHealth(context.TODO()))
+
+	c.Batch.(*mocks.MockAwsBatchClient).DescribeJobsWithContextCb = func(ctx context.Context,
+		input *batch.DescribeJobsInput, opts ...request.Option) (*batch.DescribeJobsOutput, error) {
+		return nil, fmt.Errorf("connection refused")
+	}
+
+	assert.Error(t, c.CheckHealth(context.TODO()))
+}
+
 func TestClient_RegisterJobDefinition(t *testing.T) {
 	c := newClientWithMockBatch()
 	j, err := c.RegisterJobDefinition(context.TODO(), "name-abc", "img", "admin-role")
@@ -3,6 +3,8 @@ package awsbatch
 import (
 	"context"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
 	arrayCore "github.com/flyteorg/flyteplugins/go/tasks/plugins/array/core"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
@@ -112,11 +114,15 @@ func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 	logger.Infof(ctx, "Exiting handle with phase [%v]", pluginState.State.CurrentPhase)
 
 	// Determine transition information from the state
-	phaseInfo, err := arrayCore.MapArrayStateToPluginPhase(ctx, pluginState.State, subTaskDetails.LogLinks, subTaskDetails.SubTaskIDs)
+	phaseInfo, err := arrayCore.MapArrayStateToPluginPhase(ctx, pluginState.State, subTaskDetails.LogLinks, subTaskDetails.SubTasks)
 	if err != nil {
 		return core.UnknownTransition, err
 	}
 
+	if phaseInfo.Info() != nil {
+		phaseInfo.Info().ResourceUsage = subTaskDetails.ResourceUsage
+	}
+
 	return core.DoTransition(phaseInfo), nil
 }
 
@@ -169,6 +175,34 @@ func NewExecutor(ctx context.Context, awsClient aws.Client, cfg *batchConfig.Con
 	}, nil
 }
 
+// ValidateTask checks that the task template holds a valid ArrayJob and defines the container of the sub-tasks.
+func (e Executor) ValidateTask(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	return arrayCore.ValidateArrayTask(taskTemplate)
+}
+
+// CheckHealth reports the plugin as unhealthy if AWS Batch is unreachable.
+func (e Executor) CheckHealth(ctx context.Context) error {
+	if checker, ok := e.jobStore.Client.(core.HealthChecker); ok {
+		return checker.CheckHealth(ctx)
+	}
+
+	return nil
+}
+
+// Stop stops refreshing the jobs and waits for the running job syncs to return, then for the output and error
+// assemblers to drain.
+func (e Executor) Stop(ctx context.Context) error {
+	if err := e.jobStore.Stop(ctx); err != nil {
+		return err
+	}
+
+	if err := e.outputAssembler.Stop(ctx); err != nil {
+		return err
+	}
+
+	return e.errorAssembler.Stop(ctx)
+}
+
 func (e Executor) Start(ctx context.Context) error {
 	if err := e.jobStore.Start(ctx); err != nil {
 		return err
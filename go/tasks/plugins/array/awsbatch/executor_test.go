@@ -27,6 +27,7 @@ import (
 	batchConfig "github.com/flyteorg/flyteplugins/go/tasks/plugins/array/awsbatch/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/awsbatch/definition"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/awsbatch/mocks"
+	"github.com/flyteorg/flytestdlib/cache"
 	cacheMocks "github.com/flyteorg/flytestdlib/cache/mocks"
 	"github.com/flyteorg/flytestdlib/utils"
 )
@@ -148,3 +149,64 @@ func TestExecutor_Start(t *testing.T) {
 
 	assert.NoError(t, exec.Start(context.Background()))
 }
+
+func TestExecutor_Stop(t *testing.T) {
+	ctx := context.Background()
+	js, err := NewJobStore(ctx, newClientWithMockBatch(), batchConfig.GetConfig().JobStoreConfig, EventHandler{},
+		promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.NoError(t, js.Start(ctx))
+
+	q := &queueMocks.IndexedWorkQueue{}
+	q.OnStop(ctx).Return(nil)
+
+	e := Executor{
+		jobStore:        &js,
+		outputAssembler: array.OutputAssembler{IndexedWorkQueue: q},
+		errorAssembler:  array.OutputAssembler{IndexedWorkQueue: q},
+	}
+
+	assert.NoError(t, pluginCore.CheckPluginHealth(ctx, e))
+	assert.NoError(t, pluginCore.StopPlugin(ctx, e))
+	assert.False(t, js.IsStarted())
+	q.AssertNumberOfCalls(t, "Stop", 2)
+
+	t.Run("Waits for running job syncs", func(t *testing.T) {
+		js, err := NewJobStore(ctx, newClientWithMockBatch(), batchConfig.GetConfig().JobStoreConfig, EventHandler{},
+			promutils.NewTestScope())
+		assert.NoError(t, err)
+		storeCtx, stop := context.WithCancel(ctx)
+		js.stop = stop
+
+		// A sync that only returns once the store is stopped.
+		syncing := make(chan struct{})
+		synced := false
+		syncCb := js.refreshWorkers.Wrap(func(ctx context.Context, batch cache.Batch) ([]cache.ItemSyncResponse, error) {
+			close(syncing)
+			<-ctx.Done()
+			synced = true
+			return nil, ctx.Err()
+		})
+
+		go func() {
+			_, err := syncCb(storeCtx, nil)
+			assert.Error(t, err)
+		}()
+
+		<-syncing
+		q := &queueMocks.IndexedWorkQueue{}
+		q.OnStop(ctx).Run(func(args mock.Arguments) {
+			assert.True(t, synced)
+		}).Return(nil)
+
+		e := Executor{
+			jobStore:        &js,
+			outputAssembler: array.OutputAssembler{IndexedWorkQueue: q},
+			errorAssembler:  array.OutputAssembler{IndexedWorkQueue: q},
+		}
+
+		assert.NoError(t, pluginCore.StopPlugin(ctx, e))
+		assert.True(t, synced)
+		q.AssertNumberOfCalls(t, "Stop", 2)
+	})
+}
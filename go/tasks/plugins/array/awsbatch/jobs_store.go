@@ -17,6 +17,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flytestdlib/logger"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -317,6 +318,9 @@ type JobStore struct {
 	cache.AutoRefresh
 
 	started bool
+	// Stops the background refresh of the cache.
+	stop           context.CancelFunc
+	refreshWorkers *utils.RefreshWorkers
 }
 
 // Submits a new job to AWS Batch and retrieves job info. Note that submitted jobs will not have status populated.
@@ -333,15 +337,29 @@ func (s JobStore) SubmitJob(ctx context.Context, input *batch.SubmitJobInput) (j
 }
 
 func (s *JobStore) Start(ctx context.Context) error {
+	ctx, stop := context.WithCancel(ctx)
 	err := s.AutoRefresh.Start(ctx)
 	if err != nil {
+		stop()
 		return err
 	}
 
 	s.started = true
+	s.stop = stop
 	return nil
 }
 
+// Stops refreshing the jobs and waits for the running syncs to return. Jobs aren't tracked anymore after the store is
+// stopped.
+func (s *JobStore) Stop(ctx context.Context) error {
+	if s.stop != nil {
+		s.stop()
+	}
+
+	s.started = false
+	return s.refreshWorkers.Stop(ctx)
+}
+
 func (s JobStore) GetOrCreate(jobName string, job *Job) (*Job, error) {
 	j, err := s.AutoRefresh.GetOrCreate(jobName, job)
 	if err != nil {
@@ -369,12 +387,13 @@ func NewJobStore(ctx context.Context, batchClient Client, cfg config.JobStoreCon
 	handler EventHandler, scope promutils.Scope) (JobStore, error) {
 
 	store := JobStore{
-		Client: batchClient,
+		Client:         batchClient,
+		refreshWorkers: &utils.RefreshWorkers{},
 	}
 
 	autoCache, err := cache.NewAutoRefreshBatchedCache("aws-batch-jobs", batchJobsForSync(ctx, cfg.BatchChunkSize),
-		syncBatches(ctx, store, handler, cfg.BatchChunkSize), workqueue.DefaultControllerRateLimiter(), cfg.ResyncPeriod.Duration,
-		cfg.Parallelizm, cfg.CacheSize, scope)
+		store.refreshWorkers.Wrap(syncBatches(ctx, store, handler, cfg.BatchChunkSize)),
+		workqueue.DefaultControllerRateLimiter(), cfg.ResyncPeriod.Duration, cfg.Parallelizm, cfg.CacheSize, scope)
 
 	store.AutoRefresh = autoCache
 	return store, err
@@ -2,6 +2,12 @@ package awsbatch
 
 import (
 	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/core"
 
@@ -37,20 +43,58 @@ func GetJobTaskLog(jobSize int, accountID, region, queue, jobID string) *idlCore
 }
 
 type SubTaskDetails struct {
-	LogLinks   []*idlCore.TaskLog
-	SubTaskIDs []*string
+	LogLinks []*idlCore.TaskLog
+	SubTasks []*core.SubTaskExecution
+	// The resources reserved by the attempts of the sub-tasks that started, nil if none did.
+	ResourceUsage *pluginCore.ResourceUsage
+}
+
+// getJobResourceUsage returns the usage of the attempts of the sub-jobs. Each attempt reserves the resources of the job
+// definition from its start until it stopped. Sub-jobs don't necessarily run at the same time, the memory high-water
+// mark is the one of a single sub-job.
+func getJobResourceUsage(ctx context.Context, taskMeta pluginCore.TaskExecutionMetadata, job *Job) *pluginCore.ResourceUsage {
+	var usage *pluginCore.ResourceUsage
+	var resources *v1.ResourceRequirements
+	for _, subJob := range job.SubJobs {
+		for _, attempt := range subJob.Attempts {
+			if attempt.StartedAt.IsZero() {
+				continue
+			}
+
+			if usage == nil {
+				usage = &pluginCore.ResourceUsage{}
+				// Batch jobs reserve the limits set by the overrides, see toContainerOverrides.
+				resources = flytek8s.ApplyResourceOverrides(ctx, *taskMeta.GetOverrides().GetResources())
+			}
+
+			stoppedAt := attempt.StoppedAt
+			if stoppedAt.IsZero() {
+				stoppedAt = time.Now()
+			}
+
+			usage.Add(flytek8s.ContainerResourceUsage(v1.ResourceRequirements{Limits: resources.Limits},
+				stoppedAt.Sub(attempt.StartedAt)))
+		}
+	}
+
+	if usage == nil {
+		return nil
+	}
+
+	*usage = usage.WithEstimatedCost(pluginsConfig.GetConfig().ResourcePrices)
+	return usage
 }
 
 func GetTaskLinks(ctx context.Context, taskMeta pluginCore.TaskExecutionMetadata, jobStore *JobStore, state *State) (
 	SubTaskDetails, error) {
 
 	logLinks := make([]*idlCore.TaskLog, 0, 4)
-	subTaskIDs := make([]*string, 0)
+	subTasks := make([]*core.SubTaskExecution, 0)
 
 	if state.GetExternalJobID() == nil {
 		return SubTaskDetails{
-			LogLinks:   logLinks,
-			SubTaskIDs: subTaskIDs,
+			LogLinks: logLinks,
+			SubTasks: subTasks,
 		}, nil
 	}
 
@@ -68,8 +112,8 @@ func GetTaskLinks(ctx context.Context, taskMeta pluginCore.TaskExecutionMetadata
 
 	if err != nil {
 		return SubTaskDetails{
-			LogLinks:   logLinks,
-			SubTaskIDs: subTaskIDs,
+			LogLinks: logLinks,
+			SubTasks: subTasks,
 		}, errors.Wrapf(errors2.DownstreamSystemError, err, "Failed to retrieve a job from job store.")
 	}
 
@@ -78,8 +122,8 @@ func GetTaskLinks(ctx context.Context, taskMeta pluginCore.TaskExecutionMetadata
 			"size of the LRU cache.", *state.GetExternalJobID())
 
 		return SubTaskDetails{
-			LogLinks:   logLinks,
-			SubTaskIDs: subTaskIDs,
+			LogLinks: logLinks,
+			SubTasks: subTasks,
 		}, nil
 	}
 
@@ -89,20 +133,48 @@ func GetTaskLinks(ctx context.Context, taskMeta pluginCore.TaskExecutionMetadata
 		finalPhaseIdx := detailedArrayStatus.GetItem(childIdx)
 		finalPhase := pluginCore.Phases[finalPhaseIdx]
 
+		if len(subJob.Attempts) == 0 {
+			subTasks = append(subTasks, &core.SubTaskExecution{
+				Index:      originalIndex,
+				Phase:      finalPhase.String(),
+				ExternalID: subJob.ID,
+			})
+
+			continue
+		}
+
 		// The caveat here is that we will mark all attempts with the final phase we are tracking in the state.
 		for attemptIdx, attempt := range subJob.Attempts {
+			// Only the last attempt can be in the phase tracked in the state, the previous ones were retried.
+			attemptPhase := pluginCore.PhaseRetryableFailure
+			if attemptIdx == len(subJob.Attempts)-1 {
+				attemptPhase = finalPhase
+			}
+
+			subTask := &core.SubTaskExecution{
+				Index:        originalIndex,
+				RetryAttempt: uint32(attemptIdx),
+				Phase:        attemptPhase.String(),
+				ExternalID:   subJob.ID,
+			}
+
 			if len(attempt.LogStream) > 0 {
-				logLinks = append(logLinks, &idlCore.TaskLog{
+				logLink := &idlCore.TaskLog{
 					Name: fmt.Sprintf("AWS Batch #%v-%v (%v)", originalIndex, attemptIdx, finalPhase),
 					Uri:  fmt.Sprintf(LogStreamFormatter, jobStore.GetRegion(), attempt.LogStream),
-				})
+				}
+
+				logLinks = append(logLinks, logLink)
+				subTask.Logs = []*idlCore.TaskLog{logLink}
 			}
+
+			subTasks = append(subTasks, subTask)
 		}
-		subTaskIDs = append(subTaskIDs, &subJob.ID)
 	}
 
 	return SubTaskDetails{
-		LogLinks:   logLinks,
-		SubTaskIDs: subTaskIDs,
+		LogLinks:      logLinks,
+		SubTasks:      subTasks,
+		ResourceUsage: getJobResourceUsage(ctx, taskMeta, job),
 	}, nil
 }
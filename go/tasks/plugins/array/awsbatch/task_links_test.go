@@ -1 +1,103 @@
 package awsbatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/bitarray"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/arraystatus"
+	arrayCore "github.com/flyteorg/flyteplugins/go/tasks/plugins/array/core"
+)
+
+func TestGetTaskLinks(t *testing.T) {
+	ctx := context.TODO()
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetGeneratedName().Return("generated-name")
+
+	overrides := &mocks.TaskOverrides{}
+	overrides.OnGetConfig().Return(&v1.ConfigMap{Data: map[string]string{DynamicTaskQueueKey: "queue1"}})
+	overrides.OnGetResources().Return(&v1.ResourceRequirements{})
+
+	tMeta := &mocks.TaskExecutionMetadata{}
+	tMeta.OnGetTaskExecutionID().Return(tID)
+	tMeta.OnGetOverrides().Return(overrides)
+
+	detailed := arrayCore.NewPhasesCompactArray(2)
+	detailed.SetItem(0, bitarray.Item(pluginCore.PhaseSuccess))
+	detailed.SetItem(1, bitarray.Item(pluginCore.PhaseRunning))
+	state := &State{State: &arrayCore.State{
+		ExecutionArraySize: 2,
+		ArrayStatus:        arraystatus.ArrayStatus{Detailed: detailed},
+	}}
+	state.SetExternalJobID("job-id")
+
+	jobStore := newJobsStore(t, newClientWithMockBatch())
+	_, err := jobStore.GetOrCreate("generated-name", &Job{
+		ID: "job-id",
+		SubJobs: []*Job{
+			{ID: "job-id:0", Attempts: []Attempt{{LogStream: "stream-0"}}},
+			{ID: "job-id:1", Attempts: []Attempt{{LogStream: "stream-1-0"}, {LogStream: "stream-1-1"}}},
+		},
+	})
+	assert.NoError(t, err)
+
+	details, err := GetTaskLinks(ctx, tMeta, jobStore, state)
+	assert.NoError(t, err)
+	// The link to the array job and the links to the logs of each attempt.
+	assert.Len(t, details.LogLinks, 4)
+	// One sub-task execution per attempt.
+	assert.Len(t, details.SubTasks, 3)
+
+	assert.Equal(t, 0, details.SubTasks[0].Index)
+	assert.Equal(t, "job-id:0", details.SubTasks[0].ExternalID)
+	assert.Equal(t, pluginCore.PhaseSuccess.String(), details.SubTasks[0].Phase)
+	assert.Equal(t, details.LogLinks[1:2], details.SubTasks[0].Logs)
+
+	for attempt, subTask := range details.SubTasks[1:] {
+		assert.Equal(t, 1, subTask.Index)
+		assert.Equal(t, "job-id:1", subTask.ExternalID)
+		assert.Equal(t, uint32(attempt), subTask.RetryAttempt)
+		assert.Equal(t, details.LogLinks[2+attempt:3+attempt], subTask.Logs)
+	}
+
+	// The first attempt was retried, the second one is in the phase tracked in the state.
+	assert.Equal(t, pluginCore.PhaseRetryableFailure.String(), details.SubTasks[1].Phase)
+	assert.Equal(t, pluginCore.PhaseRunning.String(), details.SubTasks[2].Phase)
+}
+
+func TestGetJobResourceUsage(t *testing.T) {
+	overrides := &mocks.TaskOverrides{}
+	overrides.OnGetResources().Return(&v1.ResourceRequirements{
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("2"),
+			v1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	})
+
+	tMeta := &mocks.TaskExecutionMetadata{}
+	tMeta.OnGetOverrides().Return(overrides)
+
+	startedAt := time.Now().Add(-time.Hour)
+	job := &Job{SubJobs: []*Job{
+		{Attempts: []Attempt{
+			{StartedAt: startedAt, StoppedAt: startedAt.Add(10 * time.Second)},
+			{StartedAt: startedAt.Add(20 * time.Second), StoppedAt: startedAt.Add(50 * time.Second)},
+		}},
+		{Attempts: []Attempt{{StartedAt: startedAt, StoppedAt: startedAt.Add(60 * time.Second)}}},
+		{},
+	}}
+
+	usage := getJobResourceUsage(context.TODO(), tMeta, job)
+	assert.NotNil(t, usage)
+	assert.Equal(t, float64(200), usage.CPUSeconds)
+	assert.Equal(t, int64(1<<30), usage.MemoryHighWaterMarkBytes)
+
+	assert.Nil(t, getJobResourceUsage(context.TODO(), tMeta, &Job{SubJobs: []*Job{{}}}))
+}
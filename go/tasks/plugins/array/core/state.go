@@ -14,7 +14,9 @@ import (
 
 	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	idlPlugins "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flytestdlib/logger"
 	structpb "github.com/golang/protobuf/ptypes/struct"
@@ -159,6 +161,44 @@ func ToArrayJob(structObj *structpb.Struct, taskTypeVersion int32) (*idlPlugins.
 	return arrayJob, err
 }
 
+// ValidateArrayTask checks that the task template holds a valid ArrayJob and defines the container of the sub-tasks.
+func ValidateArrayTask(taskTemplate *idlCore.TaskTemplate) error {
+	if _, err := ToArrayJob(taskTemplate.GetCustom(), taskTemplate.GetTaskTypeVersion()); err != nil {
+		return pluginErrors.Wrapf(pluginErrors.BadTaskSpecification, err, "Invalid ArrayJob [%v].",
+			taskTemplate.GetCustom())
+	}
+
+	return flytek8s.ValidateContainerTask(taskTemplate)
+}
+
+const (
+	// SubTasksCustomInfoKey is the key of the CustomInfo of array task executions listing their sub-tasks.
+	SubTasksCustomInfoKey = "subTasks"
+	// SubTasksTruncatedCustomInfoKey is the key of the CustomInfo of array task executions holding the number of
+	// sub-task executions left out of the list under SubTasksCustomInfoKey.
+	SubTasksTruncatedCustomInfoKey = "subTasksTruncated"
+)
+
+// MaxReportedSubTasks caps the number of sub-task executions listed in the CustomInfo of each event of an array task
+// execution. Array jobs can have thousands of sub-tasks and the whole list is sent with every event.
+const MaxReportedSubTasks = 100
+
+// SubTaskExecution describes an attempt of a sub-task of an array job. Each sub-task is reported as an
+// ExternalResourceInfo identified by its ExternalID, the rest of the details are reported in the CustomInfo of the task
+// execution under SubTasksCustomInfoKey.
+type SubTaskExecution struct {
+	// The index of the sub-task in the original array, i.e. including the sub-tasks found in the cache.
+	Index int `json:"index"`
+	// The attempt of the sub-task the details are about.
+	RetryAttempt uint32 `json:"retryAttempt"`
+	Phase        string `json:"phase"`
+	// The ID of the sub-task in the system that runs it, e.g. the ID of the AWS Batch job.
+	ExternalID string `json:"externalId"`
+	// The name of the pod running the sub-task, if any.
+	PodName string             `json:"podName,omitempty"`
+	Logs    []*idlCore.TaskLog `json:"logs,omitempty"`
+}
+
 func GetPhaseVersionOffset(currentPhase Phase, length int64) uint32 {
 	// NB: Make sure this is the last/highest value of the Phase!
 	return uint32(length * (int64(core.PhasePermanentFailure) + 1) * int64(currentPhase))
@@ -170,23 +210,62 @@ func GetPhaseVersionOffset(currentPhase Phase, length int64) uint32 {
 // Info fields will always be nil, because we're going to send log links individually. This simplifies our state
 // handling as we don't have to keep an ever growing list of log links (our batch jobs can be 5000 sub-tasks, keeping
 // all the log links takes up a lot of space).
-func MapArrayStateToPluginPhase(_ context.Context, state *State, logLinks []*idlCore.TaskLog, subTaskIDs []*string) (core.PhaseInfo, error) {
+func MapArrayStateToPluginPhase(_ context.Context, state *State, logLinks []*idlCore.TaskLog, subTasks []*SubTaskExecution) (core.PhaseInfo, error) {
 
 	phaseInfo := core.PhaseInfoUndefined
 	t := time.Now()
 	nowTaskInfo := &core.TaskInfo{
 		OccurredAt: &t,
-		Logs:       logLinks,
-	}
-	if nowTaskInfo.Metadata == nil {
-		nowTaskInfo.Metadata = &event.TaskExecutionMetadata{}
+		Metadata:   &event.TaskExecutionMetadata{},
 	}
-	for _, subTaskID := range subTaskIDs {
+
+	reportedIDs := make(map[string]bool, len(subTasks))
+	for _, subTask := range subTasks {
+		// Sub-tasks retried within the same execution are listed once per attempt.
+		if reportedIDs[subTask.ExternalID] {
+			continue
+		}
+
+		reportedIDs[subTask.ExternalID] = true
 		nowTaskInfo.Metadata.ExternalResources = append(nowTaskInfo.Metadata.ExternalResources, &event.ExternalResourceInfo{
-			ExternalId: *subTaskID,
+			ExternalId: subTask.ExternalID,
 		})
 	}
 
+	reported := subTasks
+	if len(reported) > MaxReportedSubTasks {
+		reported = reported[:MaxReportedSubTasks]
+	}
+
+	// The logs of the reported sub-tasks are only listed with them, the rest of the logs (e.g. the logs of the array job
+	// or of the sub-tasks left out) are reported as the logs of the task execution.
+	reportedLogs := make(map[*idlCore.TaskLog]bool)
+	for _, subTask := range reported {
+		for _, taskLog := range subTask.Logs {
+			reportedLogs[taskLog] = true
+		}
+	}
+
+	for _, taskLog := range logLinks {
+		if !reportedLogs[taskLog] {
+			nowTaskInfo.Logs = append(nowTaskInfo.Logs, taskLog)
+		}
+	}
+
+	if len(reported) > 0 {
+		info := map[string]interface{}{SubTasksCustomInfoKey: reported}
+		if truncated := len(subTasks) - len(reported); truncated > 0 {
+			info[SubTasksTruncatedCustomInfoKey] = truncated
+		}
+
+		customInfo, err := utils.MarshalObjToStruct(info)
+		if err != nil {
+			return phaseInfo, err
+		}
+
+		nowTaskInfo.CustomInfo = customInfo
+	}
+
 	switch p, version := state.GetPhase(); p {
 	case PhaseStart:
 		phaseInfo = core.PhaseInfoInitializing(t, core.DefaultPhaseVersion, state.GetReason(), &core.TaskInfo{OccurredAt: &t})
@@ -317,8 +396,12 @@ func NewPhasesCompactArray(count uint) bitarray.CompactArray {
 	return a
 }
 
-// Compute the original index of a sub-task.
+// Compute the original index of a sub-task. Without a set of indexes to cache, all sub-tasks are executed.
 func CalculateOriginalIndex(childIdx int, toCache *bitarray.BitSet) int {
+	if toCache == nil {
+		return childIdx
+	}
+
 	var sum = 0
 	for i := uint(0); i < toCache.Cap(); i++ {
 		if toCache.IsSet(i) {
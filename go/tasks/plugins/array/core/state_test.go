@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"testing"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
 	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 
 	"github.com/flyteorg/flytestdlib/bitarray"
+	"github.com/flyteorg/flytestdlib/errors"
 
+	pluginErrors "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/stretchr/testify/assert"
 )
@@ -51,12 +55,12 @@ func assertBitSetsEqual(t testing.TB, b1, b2 *bitarray.BitSet, len int) {
 	}
 }
 
-func assertTaskExecutionMetadata(t *testing.T, subTaskIDs []*string, metadata *event.TaskExecutionMetadata) {
+func assertTaskExecutionMetadata(t *testing.T, subTasks []*SubTaskExecution, metadata *event.TaskExecutionMetadata) {
 	assert.NotNil(t, metadata)
-	var externalResources = make([]*event.ExternalResourceInfo, len(subTaskIDs))
-	for i, subTaskID := range subTaskIDs {
+	var externalResources = make([]*event.ExternalResourceInfo, len(subTasks))
+	for i, subTask := range subTasks {
 		externalResources[i] = &event.ExternalResourceInfo{
-			ExternalId: *subTaskID,
+			ExternalId: subTask.ExternalID,
 		}
 	}
 	assert.True(t, proto.Equal(&event.TaskExecutionMetadata{
@@ -66,17 +70,20 @@ func assertTaskExecutionMetadata(t *testing.T, subTaskIDs []*string, metadata *e
 
 func TestMapArrayStateToPluginPhase(t *testing.T) {
 	ctx := context.Background()
-	var subTaskIDs = make([]*string, 3)
+	var subTasks = make([]*SubTaskExecution, 3)
 	for i := 0; i < 3; i++ {
-		subTaskID := fmt.Sprintf("sub_task_%d", i)
-		subTaskIDs[i] = &subTaskID
+		subTasks[i] = &SubTaskExecution{
+			Index:      i,
+			Phase:      core.PhaseRunning.String(),
+			ExternalID: fmt.Sprintf("sub_task_%d", i),
+		}
 	}
 
 	t.Run("start", func(t *testing.T) {
 		s := State{
 			CurrentPhase: PhaseStart,
 		}
-		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTaskIDs)
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTasks)
 		assert.NoError(t, err)
 		assert.Equal(t, core.PhaseInitializing, phaseInfo.Phase())
 	})
@@ -87,7 +94,7 @@ func TestMapArrayStateToPluginPhase(t *testing.T) {
 			PhaseVersion: 0,
 		}
 
-		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTaskIDs)
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTasks)
 		assert.NoError(t, err)
 		assert.Equal(t, core.PhaseRunning, phaseInfo.Phase())
 	})
@@ -100,11 +107,11 @@ func TestMapArrayStateToPluginPhase(t *testing.T) {
 			ExecutionArraySize: 5,
 		}
 
-		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTaskIDs)
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTasks)
 		assert.NoError(t, err)
 		assert.Equal(t, core.PhaseRunning, phaseInfo.Phase())
 		assert.Equal(t, uint32(368), phaseInfo.Version())
-		assertTaskExecutionMetadata(t, subTaskIDs, phaseInfo.Info().Metadata)
+		assertTaskExecutionMetadata(t, subTasks, phaseInfo.Info().Metadata)
 	})
 
 	t.Run("write to discovery", func(t *testing.T) {
@@ -115,11 +122,11 @@ func TestMapArrayStateToPluginPhase(t *testing.T) {
 			ExecutionArraySize: 5,
 		}
 
-		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTaskIDs)
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTasks)
 		assert.NoError(t, err)
 		assert.Equal(t, core.PhaseRunning, phaseInfo.Phase())
 		assert.Equal(t, uint32(548), phaseInfo.Version())
-		assertTaskExecutionMetadata(t, subTaskIDs, phaseInfo.Info().Metadata)
+		assertTaskExecutionMetadata(t, subTasks, phaseInfo.Info().Metadata)
 	})
 
 	t.Run("success", func(t *testing.T) {
@@ -128,10 +135,10 @@ func TestMapArrayStateToPluginPhase(t *testing.T) {
 			PhaseVersion: 0,
 		}
 
-		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTaskIDs)
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTasks)
 		assert.NoError(t, err)
 		assert.Equal(t, core.PhaseSuccess, phaseInfo.Phase())
-		assertTaskExecutionMetadata(t, subTaskIDs, phaseInfo.Info().Metadata)
+		assertTaskExecutionMetadata(t, subTasks, phaseInfo.Info().Metadata)
 	})
 
 	t.Run("retryable failure", func(t *testing.T) {
@@ -140,10 +147,10 @@ func TestMapArrayStateToPluginPhase(t *testing.T) {
 			PhaseVersion: 0,
 		}
 
-		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTaskIDs)
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTasks)
 		assert.NoError(t, err)
 		assert.Equal(t, core.PhaseRetryableFailure, phaseInfo.Phase())
-		assertTaskExecutionMetadata(t, subTaskIDs, phaseInfo.Info().Metadata)
+		assertTaskExecutionMetadata(t, subTasks, phaseInfo.Info().Metadata)
 	})
 
 	t.Run("permanent failure", func(t *testing.T) {
@@ -152,10 +159,102 @@ func TestMapArrayStateToPluginPhase(t *testing.T) {
 			PhaseVersion: 0,
 		}
 
-		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTaskIDs)
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTasks)
 		assert.NoError(t, err)
 		assert.Equal(t, core.PhasePermanentFailure, phaseInfo.Phase())
-		assertTaskExecutionMetadata(t, subTaskIDs, phaseInfo.Info().Metadata)
+		assertTaskExecutionMetadata(t, subTasks, phaseInfo.Info().Metadata)
+	})
+
+	t.Run("sub-task details", func(t *testing.T) {
+		s := State{
+			CurrentPhase: PhaseSuccess,
+			PhaseVersion: 0,
+		}
+
+		logs := []*idlCore.TaskLog{{Name: "sub_task_0 logs", Uri: "https://logs/sub_task_0"}}
+		subTasks[0].Logs = logs
+		defer func() {
+			subTasks[0].Logs = nil
+		}()
+
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTasks)
+		assert.NoError(t, err)
+		reported := phaseInfo.Info().CustomInfo.GetFields()[SubTasksCustomInfoKey].GetListValue().GetValues()
+		assert.Len(t, reported, 3)
+		first := reported[0].GetStructValue().GetFields()
+		assert.Equal(t, float64(0), first["index"].GetNumberValue())
+		assert.Equal(t, "sub_task_0", first["externalId"].GetStringValue())
+		assert.Equal(t, core.PhaseRunning.String(), first["phase"].GetStringValue())
+		assert.Equal(t, "https://logs/sub_task_0",
+			first["logs"].GetListValue().GetValues()[0].GetStructValue().GetFields()["uri"].GetStringValue())
+
+		phaseInfo, err = MapArrayStateToPluginPhase(ctx, &s, nil, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, phaseInfo.Info().CustomInfo)
+	})
+
+	t.Run("sub-task logs aren't duplicated", func(t *testing.T) {
+		s := State{
+			CurrentPhase: PhaseSuccess,
+			PhaseVersion: 0,
+		}
+
+		arrayLog := &idlCore.TaskLog{Name: "array job", Uri: "https://logs/array"}
+		subTaskLog := &idlCore.TaskLog{Name: "sub_task_0 logs", Uri: "https://logs/sub_task_0"}
+		subTasks[0].Logs = []*idlCore.TaskLog{subTaskLog}
+		defer func() {
+			subTasks[0].Logs = nil
+		}()
+
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, []*idlCore.TaskLog{arrayLog, subTaskLog}, subTasks)
+		assert.NoError(t, err)
+		assert.Equal(t, []*idlCore.TaskLog{arrayLog}, phaseInfo.Info().Logs)
+	})
+
+	t.Run("sub-tasks are capped", func(t *testing.T) {
+		s := State{
+			CurrentPhase: PhaseSuccess,
+			PhaseVersion: 0,
+		}
+
+		var logLinks []*idlCore.TaskLog
+		manySubTasks := make([]*SubTaskExecution, MaxReportedSubTasks+2)
+		for i := range manySubTasks {
+			taskLog := &idlCore.TaskLog{Name: fmt.Sprintf("sub_task_%d logs", i)}
+			logLinks = append(logLinks, taskLog)
+			manySubTasks[i] = &SubTaskExecution{
+				Index:      i,
+				Phase:      core.PhaseRunning.String(),
+				ExternalID: fmt.Sprintf("sub_task_%d", i),
+				Logs:       []*idlCore.TaskLog{taskLog},
+			}
+		}
+
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, logLinks, manySubTasks)
+		assert.NoError(t, err)
+		fields := phaseInfo.Info().CustomInfo.GetFields()
+		assert.Len(t, fields[SubTasksCustomInfoKey].GetListValue().GetValues(), MaxReportedSubTasks)
+		assert.Equal(t, float64(2), fields[SubTasksTruncatedCustomInfoKey].GetNumberValue())
+		// The logs of the sub-tasks left out are still reported.
+		assert.Equal(t, logLinks[MaxReportedSubTasks:], phaseInfo.Info().Logs)
+		assertTaskExecutionMetadata(t, manySubTasks, phaseInfo.Info().Metadata)
+	})
+
+	t.Run("attempts of a sub-task are one external resource", func(t *testing.T) {
+		s := State{
+			CurrentPhase: PhaseSuccess,
+			PhaseVersion: 0,
+		}
+
+		attempts := []*SubTaskExecution{
+			{Index: 0, RetryAttempt: 0, Phase: core.PhaseRetryableFailure.String(), ExternalID: "sub_task_0"},
+			{Index: 0, RetryAttempt: 1, Phase: core.PhaseSuccess.String(), ExternalID: "sub_task_0"},
+		}
+
+		phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, attempts)
+		assert.NoError(t, err)
+		assertTaskExecutionMetadata(t, attempts[1:], phaseInfo.Info().Metadata)
+		assert.Len(t, phaseInfo.Info().CustomInfo.GetFields()[SubTasksCustomInfoKey].GetListValue().GetValues(), 2)
 	})
 
 	t.Run("All phases", func(t *testing.T) {
@@ -164,7 +263,7 @@ func TestMapArrayStateToPluginPhase(t *testing.T) {
 				CurrentPhase: p,
 			}
 
-			phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTaskIDs)
+			phaseInfo, err := MapArrayStateToPluginPhase(ctx, &s, nil, subTasks)
 			assert.NoError(t, err)
 			assert.NotEqual(t, core.PhaseUndefined, phaseInfo.Phase())
 		}
@@ -268,3 +367,26 @@ func TestToArrayJob(t *testing.T) {
 		}))
 	})
 }
+
+func TestValidateArrayTask(t *testing.T) {
+	container := &idlCore.TaskTemplate_Container{Container: &idlCore.Container{Image: "image"}}
+	assert.NoError(t, ValidateArrayTask(&idlCore.TaskTemplate{Target: container}))
+
+	t.Run("Invalid ArrayJob", func(t *testing.T) {
+		err := ValidateArrayTask(&idlCore.TaskTemplate{
+			Target: container,
+			Custom: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"size": {Kind: &structpb.Value_StringValue{StringValue: "large"}},
+			}},
+		})
+
+		assert.Error(t, err)
+		code, found := errors.GetErrorCode(err)
+		assert.True(t, found)
+		assert.Equal(t, pluginErrors.BadTaskSpecification, code)
+	})
+
+	t.Run("Missing container", func(t *testing.T) {
+		assert.Error(t, ValidateArrayTask(&idlCore.TaskTemplate{}))
+	})
+}
@@ -2,7 +2,10 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -15,7 +18,6 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 
-	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 )
 
@@ -23,6 +25,10 @@ const executorName = "k8s-array"
 const arrayTaskType = "container_array"
 const pluginStateVersion = 0
 
+// pluginStateMigrator reads the persisted arrayCore.State. Register migrations here when the state changes in
+// incompatible ways.
+var pluginStateMigrator = core.NewPluginStateMigrator(pluginStateVersion)
+
 type Executor struct {
 	kubeClient       core.KubeClient
 	outputsAssembler array.OutputAssembler
@@ -41,6 +47,17 @@ func (k KubeClientObj) GetCache() cache.Cache {
 	return nil
 }
 
+// CheckHealth checks that the API server of the remote cluster is reachable. Errors returned by the API server, e.g. if
+// listing namespaces is forbidden, still mean that it's reachable.
+func (k KubeClientObj) CheckHealth(ctx context.Context) error {
+	err := k.client.List(ctx, &v1.NamespaceList{}, client.Limit(1))
+	if _, isStatus := err.(k8serrors.APIStatus); err != nil && !isStatus {
+		return fmt.Errorf("remote cluster is unreachable: %w", err)
+	}
+
+	return nil
+}
+
 func NewKubeClientObj(c client.Client) core.KubeClient {
 	return &KubeClientObj{
 		client: c,
@@ -79,14 +96,14 @@ func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 	pluginConfig := GetConfig()
 
 	pluginState := &arrayCore.State{}
-	if _, err := tCtx.PluginStateReader().Get(pluginState); err != nil {
-		return core.UnknownTransition, errors.Wrapf(errors.CorruptedPluginState, err, "Failed to read unmarshal custom state")
+	if err := pluginStateMigrator.Get(tCtx.PluginStateReader(), pluginState); err != nil {
+		return core.UnknownTransition, err
 	}
 
 	var nextState *arrayCore.State
 	var err error
 	var logLinks []*idlCore.TaskLog
-	var subTaskIDs []*string
+	var subTasks []*arrayCore.SubTaskExecution
 
 	switch p, _ := pluginState.GetPhase(); p {
 	case arrayCore.PhaseStart:
@@ -108,7 +125,7 @@ func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 
 	case arrayCore.PhaseCheckingSubTaskExecutions:
 
-		nextState, logLinks, subTaskIDs, err = LaunchAndCheckSubTasksState(ctx, tCtx, e.kubeClient, pluginConfig,
+		nextState, logLinks, subTasks, err = LaunchAndCheckSubTasksState(ctx, tCtx, e.kubeClient, pluginConfig,
 			tCtx.DataStore(), tCtx.OutputWriter().GetOutputPrefixPath(), tCtx.OutputWriter().GetRawOutputPrefix(), pluginState)
 
 	case arrayCore.PhaseAssembleFinalOutput:
@@ -136,7 +153,7 @@ func (e Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 	}
 
 	// Determine transition information from the state
-	phaseInfo, err := arrayCore.MapArrayStateToPluginPhase(ctx, nextState, logLinks, subTaskIDs)
+	phaseInfo, err := arrayCore.MapArrayStateToPluginPhase(ctx, nextState, logLinks, subTasks)
 	if err != nil {
 		return core.UnknownTransition, err
 	}
@@ -152,13 +169,36 @@ func (e Executor) Finalize(ctx context.Context, tCtx core.TaskExecutionContext)
 	pluginConfig := GetConfig()
 
 	pluginState := &arrayCore.State{}
-	if _, err := tCtx.PluginStateReader().Get(pluginState); err != nil {
-		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to read unmarshal custom state")
+	if err := pluginStateMigrator.Get(tCtx.PluginStateReader(), pluginState); err != nil {
+		return err
 	}
 
 	return TerminateSubTasks(ctx, tCtx, e.kubeClient, pluginConfig, pluginState)
 }
 
+// ValidateTask checks that the task template holds a valid ArrayJob and defines the container of the sub-tasks.
+func (e Executor) ValidateTask(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	return arrayCore.ValidateArrayTask(taskTemplate)
+}
+
+// CheckHealth reports the plugin as unhealthy if the remote cluster, when configured, is unreachable.
+func (e Executor) CheckHealth(ctx context.Context) error {
+	if checker, ok := e.kubeClient.(core.HealthChecker); ok {
+		return checker.CheckHealth(ctx)
+	}
+
+	return nil
+}
+
+// Stop waits for the output and error assemblers to drain.
+func (e Executor) Stop(ctx context.Context) error {
+	if err := e.outputsAssembler.Stop(ctx); err != nil {
+		return err
+	}
+
+	return e.errorAssembler.Stop(ctx)
+}
+
 func (e Executor) Start(ctx context.Context) error {
 	if err := e.outputsAssembler.Start(ctx); err != nil {
 		return err
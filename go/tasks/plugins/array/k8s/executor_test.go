@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	queueMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/workqueue/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array"
+)
+
+// listErrorClient fails every List call with err.
+type listErrorClient struct {
+	client.Client
+	err error
+}
+
+func (c listErrorClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.err
+}
+
+func TestExecutor_CheckHealth(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, core.CheckPluginHealth(ctx, Executor{kubeClient: &mocks.KubeClient{}}))
+	assert.NoError(t, core.CheckPluginHealth(ctx, Executor{kubeClient: NewKubeClientObj(mocks.NewFakeKubeClient())}))
+
+	forbidden := k8serrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", fmt.Errorf("forbidden"))
+	assert.NoError(t, core.CheckPluginHealth(ctx, Executor{kubeClient: NewKubeClientObj(listErrorClient{
+		Client: mocks.NewFakeKubeClient(),
+		err:    forbidden,
+	})}))
+
+	assert.Error(t, core.CheckPluginHealth(ctx, Executor{kubeClient: NewKubeClientObj(listErrorClient{
+		Client: mocks.NewFakeKubeClient(),
+		err:    fmt.Errorf("connection refused"),
+	})}))
+}
+
+func TestExecutor_Stop(t *testing.T) {
+	ctx := context.Background()
+	q := &queueMocks.IndexedWorkQueue{}
+	q.OnStop(ctx).Return(nil)
+
+	e := Executor{
+		outputsAssembler: array.OutputAssembler{IndexedWorkQueue: q},
+		errorAssembler:   array.OutputAssembler{IndexedWorkQueue: q},
+	}
+
+	assert.NoError(t, core.StopPlugin(ctx, e))
+	q.AssertNumberOfCalls(t, "Stop", 2)
+}
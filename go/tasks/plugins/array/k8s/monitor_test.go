@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	core2 "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	mocks2 "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/arraystatus"
@@ -93,11 +94,16 @@ func getMockTaskExecutionContext(ctx context.Context) *mocks.TaskExecutionContex
 	return tCtx
 }
 
-func testSubTaskIDs(t *testing.T, actual []*string) {
-	var expected = make([]*string, 5)
+func testSubTasks(t *testing.T, actual []*arrayCore.SubTaskExecution) {
+	var expected = make([]*arrayCore.SubTaskExecution, 5)
 	for i := 0; i < len(expected); i++ {
 		subTaskID := fmt.Sprintf("notfound-%d", i)
-		expected[i] = &subTaskID
+		expected[i] = &arrayCore.SubTaskExecution{
+			Index:      i,
+			Phase:      core.PhaseRunning.String(),
+			ExternalID: subTaskID,
+			PodName:    subTaskID,
+		}
 	}
 	assert.EqualValues(t, expected, actual)
 }
@@ -114,7 +120,7 @@ func TestCheckSubTasksState(t *testing.T) {
 
 	t.Run("Happy case", func(t *testing.T) {
 		config := Config{MaxArrayJobSize: 100}
-		newState, _, subTaskIDs, err := LaunchAndCheckSubTasksState(ctx, tCtx, &kubeClient, &config, nil, "/prefix/", "/prefix-sand/", &arrayCore.State{
+		newState, _, subTasks, err := LaunchAndCheckSubTasksState(ctx, tCtx, &kubeClient, &config, nil, "/prefix/", "/prefix-sand/", &arrayCore.State{
 			CurrentPhase:         arrayCore.PhaseCheckingSubTaskExecutions,
 			ExecutionArraySize:   5,
 			OriginalArraySize:    10,
@@ -126,7 +132,7 @@ func TestCheckSubTasksState(t *testing.T) {
 		p, _ := newState.GetPhase()
 		assert.Equal(t, arrayCore.PhaseCheckingSubTaskExecutions.String(), p.String())
 		resourceManager.AssertNumberOfCalls(t, "AllocateResource", 0)
-		testSubTaskIDs(t, subTaskIDs)
+		testSubTasks(t, subTasks)
 	})
 
 	t.Run("Resource exhausted", func(t *testing.T) {
@@ -138,7 +144,7 @@ func TestCheckSubTasksState(t *testing.T) {
 			},
 		}
 
-		newState, _, subTaskIDs, err := LaunchAndCheckSubTasksState(ctx, tCtx, &kubeClient, &config, nil, "/prefix/", "/prefix-sand/", &arrayCore.State{
+		newState, _, subTasks, err := LaunchAndCheckSubTasksState(ctx, tCtx, &kubeClient, &config, nil, "/prefix/", "/prefix-sand/", &arrayCore.State{
 			CurrentPhase:         arrayCore.PhaseCheckingSubTaskExecutions,
 			ExecutionArraySize:   5,
 			OriginalArraySize:    10,
@@ -152,7 +158,7 @@ func TestCheckSubTasksState(t *testing.T) {
 		p, _ := newState.GetPhase()
 		assert.Equal(t, arrayCore.PhaseWaitingForResources.String(), p.String())
 		resourceManager.AssertNumberOfCalls(t, "AllocateResource", 5)
-		assert.Empty(t, subTaskIDs, "subtask ids are only populated when monitor is called for a successfully launched task")
+		assert.Empty(t, subTasks, "subtask ids are only populated when monitor is called for a successfully launched task")
 	})
 }
 
@@ -177,7 +183,7 @@ func TestCheckSubTasksStateResourceGranted(t *testing.T) {
 			},
 		}
 
-		newState, _, subTaskIDs, err := LaunchAndCheckSubTasksState(ctx, tCtx, &kubeClient, &config, nil, "/prefix/", "/prefix-sand/", &arrayCore.State{
+		newState, _, subTasks, err := LaunchAndCheckSubTasksState(ctx, tCtx, &kubeClient, &config, nil, "/prefix/", "/prefix-sand/", &arrayCore.State{
 			CurrentPhase:         arrayCore.PhaseCheckingSubTaskExecutions,
 			ExecutionArraySize:   5,
 			OriginalArraySize:    10,
@@ -191,7 +197,7 @@ func TestCheckSubTasksStateResourceGranted(t *testing.T) {
 		p, _ := newState.GetPhase()
 		assert.Equal(t, arrayCore.PhaseCheckingSubTaskExecutions.String(), p.String())
 		resourceManager.AssertNumberOfCalls(t, "AllocateResource", 5)
-		testSubTaskIDs(t, subTaskIDs)
+		testSubTasks(t, subTasks)
 	})
 
 	t.Run("All tasks success", func(t *testing.T) {
@@ -211,7 +217,7 @@ func TestCheckSubTasksStateResourceGranted(t *testing.T) {
 			arrayStatus.Detailed.SetItem(childIdx, bitarray.Item(core.PhaseSuccess))
 
 		}
-		newState, _, subTaskIDs, err := LaunchAndCheckSubTasksState(ctx, tCtx, &kubeClient, &config, nil, "/prefix/", "/prefix-sand/", &arrayCore.State{
+		newState, _, subTasks, err := LaunchAndCheckSubTasksState(ctx, tCtx, &kubeClient, &config, nil, "/prefix/", "/prefix-sand/", &arrayCore.State{
 			CurrentPhase:         arrayCore.PhaseCheckingSubTaskExecutions,
 			ExecutionArraySize:   5,
 			OriginalArraySize:    10,
@@ -223,6 +229,56 @@ func TestCheckSubTasksStateResourceGranted(t *testing.T) {
 		p, _ := newState.GetPhase()
 		assert.Equal(t, arrayCore.PhaseWriteToDiscovery.String(), p.String())
 		resourceManager.AssertNumberOfCalls(t, "ReleaseResource", 5)
-		assert.Empty(t, subTaskIDs, "terminal phases don't need to collect subtask IDs")
+		assert.Empty(t, subTasks, "terminal phases don't need to collect subtask IDs")
+	})
+}
+
+func TestAllocateResource(t *testing.T) {
+	ctx := context.Background()
+	previous := *pluginsConfig.GetConfig()
+	defer func() {
+		assert.NoError(t, pluginsConfig.SetConfig(&previous))
+	}()
+
+	// The mock task executions run in the d domain.
+	cfg := previous
+	cfg.ResourcePriorities = []pluginsConfig.ResourcePriorityRule{{Domain: "d", Priority: "high"}}
+	assert.NoError(t, pluginsConfig.SetConfig(&cfg))
+
+	config := &Config{
+		ResourceConfig: ResourceConfig{
+			PrimaryLabel: "p",
+			Limit:        10,
+		},
+	}
+
+	resourceManager := &mocks.ResourceManager{}
+	resourceManager.OnAllocateResource(ctx, core.ResourceNamespace("p"), "notfound-0", core.ResourceConstraintsSpec{
+		Units:    2,
+		Priority: core.ResourcePriorityHigh,
+	}).Return(core.AllocationStatusGranted, nil)
+
+	tCtx := getMockTaskExecutionContext(ctx)
+	tCtx.OnResourceManager().Return(resourceManager)
+
+	t.Run("Weighted allocation", func(t *testing.T) {
+		status, err := allocateResource(ctx, tCtx, config, "notfound-0", &core2.TaskTemplate{
+			Config: map[string]string{
+				core.ResourceUnitsConfigKey:    "2",
+				core.ResourcePriorityConfigKey: "high",
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, core.AllocationStatusGranted, status)
+	})
+
+	t.Run("Invalid weight", func(t *testing.T) {
+		_, err := allocateResource(ctx, tCtx, config, "notfound-0", &core2.TaskTemplate{
+			Config: map[string]string{core.ResourceUnitsConfigKey: "many"},
+		})
+
+		assert.Error(t, err)
+		resourceManager.AssertNumberOfCalls(t, "AllocateResource", 1)
 	})
 }
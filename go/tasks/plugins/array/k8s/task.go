@@ -8,6 +8,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
 
 	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/array/arraystatus"
@@ -30,7 +31,7 @@ type Task struct {
 	Config           *Config
 	ChildIdx         int
 	MessageCollector *errorcollector.ErrorMessageCollector
-	SubTaskIDs       []*string
+	SubTasks         []*arrayCore.SubTaskExecution
 }
 
 type LaunchResult int8
@@ -98,7 +99,7 @@ func (t Task) Launch(ctx context.Context, tCtx core.TaskExecutionContext, kubeCl
 	pod = applyNodeSelectorLabels(ctx, t.Config, pod)
 	pod = applyPodTolerations(ctx, t.Config, pod)
 
-	allocationStatus, err := allocateResource(ctx, tCtx, t.Config, podName)
+	allocationStatus, err := allocateResource(ctx, tCtx, t.Config, podName, taskTemplate)
 	if err != nil {
 		return LaunchError, err
 	}
@@ -132,7 +133,14 @@ func (t Task) Launch(ctx context.Context, tCtx core.TaskExecutionContext, kubeCl
 func (t *Task) Monitor(ctx context.Context, tCtx core.TaskExecutionContext, kubeClient core.KubeClient, dataStore *storage.DataStore, outputPrefix, baseOutputDataSandbox storage.DataReference) (MonitorResult, error) {
 	indexStr := strconv.Itoa(t.ChildIdx)
 	podName := formatSubTaskName(ctx, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), indexStr)
-	t.SubTaskIDs = append(t.SubTaskIDs, &podName)
+	originalIdx := arrayCore.CalculateOriginalIndex(t.ChildIdx, t.State.GetIndexesToCache())
+	subTask := &arrayCore.SubTaskExecution{
+		Index:        originalIdx,
+		RetryAttempt: tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID().RetryAttempt,
+		ExternalID:   podName,
+		PodName:      podName,
+	}
+	t.SubTasks = append(t.SubTasks, subTask)
 	phaseInfo, err := CheckPodStatus(ctx, kubeClient,
 		k8sTypes.NamespacedName{
 			Name:      podName,
@@ -144,6 +152,7 @@ func (t *Task) Monitor(ctx context.Context, tCtx core.TaskExecutionContext, kube
 
 	if phaseInfo.Info() != nil {
 		t.LogLinks = append(t.LogLinks, phaseInfo.Info().Logs...)
+		subTask.Logs = phaseInfo.Info().Logs
 	}
 
 	if phaseInfo.Err() != nil {
@@ -152,13 +161,13 @@ func (t *Task) Monitor(ctx context.Context, tCtx core.TaskExecutionContext, kube
 
 	actualPhase := phaseInfo.Phase()
 	if phaseInfo.Phase().IsSuccess() {
-		originalIdx := arrayCore.CalculateOriginalIndex(t.ChildIdx, t.State.GetIndexesToCache())
 		actualPhase, err = array.CheckTaskOutput(ctx, dataStore, outputPrefix, baseOutputDataSandbox, t.ChildIdx, originalIdx)
 		if err != nil {
 			return MonitorError, err
 		}
 	}
 
+	subTask.Phase = actualPhase.String()
 	t.NewArrayStatus.Detailed.SetItem(t.ChildIdx, bitarray.Item(actualPhase))
 	t.NewArrayStatus.Summary.Inc(actualPhase)
 
@@ -207,15 +216,22 @@ func (t Task) Finalize(ctx context.Context, tCtx core.TaskExecutionContext, kube
 
 }
 
-func allocateResource(ctx context.Context, tCtx core.TaskExecutionContext, config *Config, podName string) (core.AllocationStatus, error) {
+func allocateResource(ctx context.Context, tCtx core.TaskExecutionContext, config *Config, podName string,
+	taskTemplate *idlCore.TaskTemplate) (core.AllocationStatus, error) {
 	if !IsResourceConfigSet(config.ResourceConfig) {
 		return core.AllocationStatusGranted, nil
 	}
 
 	resourceNamespace := core.ResourceNamespace(config.ResourceConfig.PrimaryLabel)
-	resourceConstraintSpec := core.ResourceConstraintsSpec{
+	id := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+	executionID := id.GetNodeExecutionId().GetExecutionId()
+	priority, _ := pluginsConfig.GetConfig().ResolveResourcePriority(executionID.GetProject(), executionID.GetDomain())
+	resourceConstraintSpec, err := core.ResourceConstraintsSpec{
 		ProjectScopeResourceConstraint:   nil,
 		NamespaceScopeResourceConstraint: nil,
+	}.WithTaskOverrides(taskTemplate, priority)
+	if err != nil {
+		return core.AllocationUndefined, err
 	}
 
 	allocationStatus, err := tCtx.ResourceManager().AllocateResource(ctx, resourceNamespace, podName, resourceConstraintSpec)
@@ -15,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const PodKind = "pod"
@@ -29,6 +30,12 @@ func (a *arrayTaskContext) InputReader() io.InputReader {
 	return a.arrayInputReader
 }
 
+// KubeReader forwards the reader of the base TaskExecutionContext, if any, which embedding alone hides from
+// core.GetKubeReader.
+func (a *arrayTaskContext) KubeReader() client.Reader {
+	return core.GetKubeReader(a.TaskExecutionContext)
+}
+
 // Note that Name is not set on the result object.
 // It's up to the caller to set the Name before creating the object in K8s.
 func FlyteArrayJobToK8sPodTemplate(ctx context.Context, tCtx core.TaskExecutionContext) (
@@ -0,0 +1,36 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+type kubeReaderTaskContext struct {
+	*mocks.TaskExecutionContext
+	reader client.Reader
+}
+
+func (k kubeReaderTaskContext) KubeReader() client.Reader {
+	return k.reader
+}
+
+func TestArrayTaskContext_KubeReader(t *testing.T) {
+	t.Run("Forwarded", func(t *testing.T) {
+		reader := mocks.NewFakeKubeClient()
+		arrTCtx := &arrayTaskContext{
+			TaskExecutionContext: kubeReaderTaskContext{TaskExecutionContext: &mocks.TaskExecutionContext{}, reader: reader},
+		}
+
+		assert.Equal(t, reader, core.GetKubeReader(arrTCtx))
+	})
+
+	t.Run("Not provided", func(t *testing.T) {
+		arrTCtx := &arrayTaskContext{TaskExecutionContext: &mocks.TaskExecutionContext{}}
+		assert.Nil(t, core.GetKubeReader(arrTCtx))
+	})
+}
@@ -288,6 +288,32 @@ func (q *quboleClient) GetCommandStatus(ctx context.Context, commandID string, a
 	return cmdStatus, nil
 }
 
+/*
+	Check that the Qubole endpoint is reachable. Any response other than a server error counts, the request isn't
+	authenticated.
+	param: context.Context ctx: The default go context.
+	return: error: error in-case the endpoint is unreachable
+*/
+func (q *quboleClient) CheckHealth(ctx context.Context) error {
+	req, err := http.NewRequest(http.MethodGet, q.commandURL.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := q.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors2.Wrapf(ErrRequestFailed, err, "Qubole endpoint [%v] is unreachable", q.commandURL.Host)
+	}
+
+	defer closeBody(ctx, response)
+	if response.StatusCode >= http.StatusInternalServerError {
+		return errors2.Errorf(ErrRequestFailed, "Qubole endpoint [%v] is unhealthy. Response code [%v]",
+			q.commandURL.Host, response.StatusCode)
+	}
+
+	return nil
+}
+
 func (q *quboleClient) GetLogLinkPath(ctx context.Context, commandID string) (*url.URL, error) {
 	logLink := fmt.Sprintf(logLinkFormat, commandID)
 	l, err := url.Parse(logLink)
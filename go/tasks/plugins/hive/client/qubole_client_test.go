@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -110,6 +111,30 @@ func TestQuboleClient_GetCommandStatusError(t *testing.T) {
 	assert.Equal(t, QuboleStatusUnknown, details)
 }
 
+func TestQuboleClient_CheckHealth(t *testing.T) {
+	ctx := context.Background()
+	c := createQuboleClient("OK")
+	assert.NoError(t, c.CheckHealth(ctx))
+
+	// The request isn't authenticated, client errors still mean the endpoint is reachable.
+	c = createQuboleErrorClient("bad token")
+	assert.NoError(t, c.CheckHealth(ctx))
+
+	c.client = &http.Client{Transport: RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("unavailable")),
+			Header:     make(http.Header),
+		}, nil
+	})}
+	assert.Error(t, c.CheckHealth(ctx))
+
+	c.client = &http.Client{Transport: RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("connection refused")
+	})}
+	assert.Error(t, c.CheckHealth(ctx))
+}
+
 func createQuboleClient(response string) quboleClient {
 	hc := &http.Client{Transport: RoundTripFunc(func(req *http.Request) (*http.Response, error) {
 		response := &http.Response{
@@ -253,4 +253,26 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_resourceLeaseTTL", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("resourceLeaseTTL"); err == nil {
+				assert.Equal(t, string(defaultConfig.ResourceLeaseTTL.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.ResourceLeaseTTL.String()
+
+			cmdFlags.Set("resourceLeaseTTL", testValue)
+			if vString, err := cmdFlags.GetString("resourceLeaseTTL"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ResourceLeaseTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
@@ -84,12 +85,14 @@ func HandleExecutionState(ctx context.Context, tCtx core.TaskExecutionContext, c
 
 	switch currentState.Phase {
 	case PhaseNotStarted:
-		newState, transformError = GetAllocationToken(ctx, tCtx, currentState, metrics)
+		newState, transformError = GetAllocationToken(ctx, tCtx, currentState, cfg, metrics)
 
 	case PhaseQueued:
+		RenewAllocationToken(ctx, tCtx, cfg, metrics)
 		newState, transformError = KickOffQuery(ctx, tCtx, currentState, quboleClient, executionsCache, cfg)
 
 	case PhaseSubmitted:
+		RenewAllocationToken(ctx, tCtx, cfg, metrics)
 		newState, transformError = MonitorQuery(ctx, tCtx, currentState, executionsCache)
 
 	case PhaseWriteOutputFile:
@@ -169,17 +172,16 @@ func ConstructTaskInfo(e ExecutionState) *core.TaskInfo {
 	return nil
 }
 
-func composeResourceNamespaceWithClusterPrimaryLabel(ctx context.Context, tCtx core.TaskExecutionContext) (core.ResourceNamespace, error) {
+func composeResourceNamespaceWithClusterPrimaryLabel(ctx context.Context, tCtx core.TaskExecutionContext, cfg *config.Config) (core.ResourceNamespace, error) {
 	_, clusterLabelOverride, _, _, _, err := GetQueryInfo(ctx, tCtx)
 	if err != nil {
 		return "", err
 	}
-	clusterPrimaryLabel := getClusterPrimaryLabel(ctx, tCtx, clusterLabelOverride)
+	clusterPrimaryLabel := getClusterPrimaryLabel(ctx, tCtx, cfg, clusterLabelOverride)
 	return core.ResourceNamespace(clusterPrimaryLabel), nil
 }
 
-func createResourceConstraintsSpec(ctx context.Context, _ core.TaskExecutionContext, targetClusterPrimaryLabel core.ResourceNamespace) core.ResourceConstraintsSpec {
-	cfg := config.GetQuboleConfig()
+func createResourceConstraintsSpec(ctx context.Context, _ core.TaskExecutionContext, cfg *config.Config, targetClusterPrimaryLabel core.ResourceNamespace) core.ResourceConstraintsSpec {
 	constraintsSpec := core.ResourceConstraintsSpec{
 		ProjectScopeResourceConstraint:   nil,
 		NamespaceScopeResourceConstraint: nil,
@@ -199,18 +201,19 @@ func createResourceConstraintsSpec(ctx context.Context, _ core.TaskExecutionCont
 	return constraintsSpec
 }
 
-func GetAllocationToken(ctx context.Context, tCtx core.TaskExecutionContext, currentState ExecutionState, metric QuboleHiveExecutorMetrics) (ExecutionState, error) {
+func GetAllocationToken(ctx context.Context, tCtx core.TaskExecutionContext, currentState ExecutionState, cfg *config.Config, metric QuboleHiveExecutorMetrics) (ExecutionState, error) {
 	newState := ExecutionState{}
 	uniqueID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
 
-	clusterPrimaryLabel, err := composeResourceNamespaceWithClusterPrimaryLabel(ctx, tCtx)
+	clusterPrimaryLabel, err := composeResourceNamespaceWithClusterPrimaryLabel(ctx, tCtx, cfg)
 	if err != nil {
 		return newState, errors.Wrapf(errors.ResourceManagerFailure, err, "Error getting query info when requesting allocation token %s", uniqueID)
 	}
 
-	resourceConstraintsSpec := createResourceConstraintsSpec(ctx, tCtx, clusterPrimaryLabel)
+	resourceConstraintsSpec := createResourceConstraintsSpec(ctx, tCtx, cfg, clusterPrimaryLabel)
 
-	allocationStatus, err := tCtx.ResourceManager().AllocateResource(ctx, clusterPrimaryLabel, uniqueID, resourceConstraintsSpec)
+	allocationStatus, err := core.AllocateLeasedResource(ctx, tCtx.ResourceManager(), clusterPrimaryLabel, uniqueID,
+		resourceConstraintsSpec, cfg.ResourceLeaseTTL.Duration)
 	if err != nil {
 		logger.Errorf(ctx, "Resource manager failed for TaskExecId [%s] token [%s]. error %s",
 			tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID(), uniqueID, err)
@@ -244,6 +247,42 @@ func GetAllocationToken(ctx context.Context, tCtx core.TaskExecutionContext, cur
 	return newState, nil
 }
 
+// RenewAllocationToken renews the lease of the allocation token of the task, if leases are enabled. Failures are
+// logged and counted but otherwise ignored; the lease is renewed again in the next round.
+func RenewAllocationToken(ctx context.Context, tCtx core.TaskExecutionContext, cfg *config.Config,
+	metric QuboleHiveExecutorMetrics) {
+	ttl := cfg.ResourceLeaseTTL.Duration
+	if _, ok := tCtx.ResourceManager().(core.LeasedResourceManager); !ok || ttl <= 0 {
+		return
+	}
+
+	uniqueID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	clusterPrimaryLabel, err := composeResourceNamespaceWithClusterPrimaryLabel(ctx, tCtx, cfg)
+	if err == nil {
+		err = core.RenewResourceLease(ctx, tCtx.ResourceManager(), clusterPrimaryLabel, uniqueID, ttl)
+	}
+
+	if err != nil {
+		metric.LeaseRenewFailed.Inc(ctx)
+		logger.Warnf(ctx, "Failed to renew the lease of allocation token [%s]. Error: %s", uniqueID, err)
+	}
+}
+
+// getQuboleHiveJob unmarshals and validates the QuboleHiveJob of the task template.
+func getQuboleHiveJob(taskTemplate *idlCore.TaskTemplate) (plugins.QuboleHiveJob, error) {
+	hiveJob := plugins.QuboleHiveJob{}
+	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &hiveJob); err != nil {
+		return hiveJob, errors.Wrapf(errors.BadTaskSpecification, err, "Invalid QuboleHiveJob [%v].",
+			taskTemplate.GetCustom())
+	}
+
+	if err := validateQuboleHiveJob(hiveJob); err != nil {
+		return hiveJob, err
+	}
+
+	return hiveJob, nil
+}
+
 func validateQuboleHiveJob(hiveJob plugins.QuboleHiveJob) error {
 	if hiveJob.Query == nil {
 		return errors.Errorf(errors.BadTaskSpecification,
@@ -262,16 +301,11 @@ func GetQueryInfo(ctx context.Context, tCtx core.TaskExecutionContext) (
 		return "", "", []string{}, 0, "", err
 	}
 
-	hiveJob := plugins.QuboleHiveJob{}
-	err = utils.UnmarshalStruct(taskTemplate.GetCustom(), &hiveJob)
+	hiveJob, err := getQuboleHiveJob(taskTemplate)
 	if err != nil {
 		return "", "", []string{}, 0, "", err
 	}
 
-	if err := validateQuboleHiveJob(hiveJob); err != nil {
-		return "", "", []string{}, 0, "", err
-	}
-
 	query := hiveJob.Query.GetQuery()
 
 	outputs, err := template.Render(ctx, []string{query},
@@ -346,9 +380,7 @@ func mapProjectDomainToDestinationClusterLabel(ctx context.Context, tCtx core.Ta
 	return "", false
 }
 
-func getClusterPrimaryLabel(ctx context.Context, tCtx core.TaskExecutionContext, clusterLabelOverride string) string {
-	cfg := config.GetQuboleConfig()
-
+func getClusterPrimaryLabel(ctx context.Context, tCtx core.TaskExecutionContext, cfg *config.Config, clusterLabelOverride string) string {
 	// If override is not empty and if it has a mapping, we return the mapped primary label
 	if clusterLabelOverride != "" {
 		if primaryLabel, found := mapLabelToPrimaryLabel(ctx, cfg, clusterLabelOverride); found {
@@ -370,7 +402,7 @@ func KickOffQuery(ctx context.Context, tCtx core.TaskExecutionContext, currentSt
 	cache cache.AutoRefresh, cfg *config.Config) (ExecutionState, error) {
 
 	uniqueID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
-	apiKey, err := tCtx.SecretManager().Get(ctx, cfg.TokenKey)
+	apiKey, err := GetQuboleToken(ctx, tCtx.SecretManager(), cfg)
 	if err != nil {
 		return currentState, errors.Wrapf(errors.RuntimeFailure, err, "Failed to read token from secrets manager")
 	}
@@ -380,7 +412,7 @@ func KickOffQuery(ctx context.Context, tCtx core.TaskExecutionContext, currentSt
 		return currentState, err
 	}
 
-	clusterPrimaryLabel := getClusterPrimaryLabel(ctx, tCtx, clusterLabelOverride)
+	clusterPrimaryLabel := getClusterPrimaryLabel(ctx, tCtx, cfg, clusterLabelOverride)
 
 	taskExecutionIdentifier := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID()
 	commandMetadata := client.CommandMetadata{TaskName: taskName,
@@ -469,10 +501,10 @@ func Abort(ctx context.Context, tCtx core.TaskExecutionContext, currentState Exe
 	return nil
 }
 
-func Finalize(ctx context.Context, tCtx core.TaskExecutionContext, _ ExecutionState, metrics QuboleHiveExecutorMetrics) error {
+func Finalize(ctx context.Context, tCtx core.TaskExecutionContext, _ ExecutionState, cfg *config.Config, metrics QuboleHiveExecutorMetrics) error {
 	// Release allocation token
 	uniqueID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
-	clusterPrimaryLabel, err := composeResourceNamespaceWithClusterPrimaryLabel(ctx, tCtx)
+	clusterPrimaryLabel, err := composeResourceNamespaceWithClusterPrimaryLabel(ctx, tCtx, cfg)
 	if err != nil {
 		return errors.Wrapf(errors.ResourceManagerFailure, err, "Error getting query info when releasing allocation token %s", uniqueID)
 	}
@@ -549,3 +581,18 @@ func WriteOutputs(ctx context.Context, tCtx core.TaskExecutionContext, currentSt
 	currentState.Phase = PhaseQuerySucceeded
 	return currentState, nil
 }
+
+// GetQuboleToken reads the Qubole token from the secret manager. TokenKey may reference a secret by group and version
+// in the form [group/]key[@version].
+func GetQuboleToken(ctx context.Context, secretManager core.SecretManager, cfg *config.Config) (string, error) {
+	if !strings.ContainsAny(cfg.TokenKey, "/@") {
+		return secretManager.Get(ctx, cfg.TokenKey)
+	}
+
+	ref, err := core.ParseSecretReference(cfg.TokenKey)
+	if err != nil {
+		return "", err
+	}
+
+	return core.GetSecret(ctx, secretManager, ref)
+}
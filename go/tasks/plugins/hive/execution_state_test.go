@@ -9,10 +9,12 @@ import (
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
 	ioMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 
+	flyteConfig "github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 
@@ -103,6 +105,15 @@ func TestValidateQuboleHiveJob(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestQuboleHiveExecutor_ValidateTask(t *testing.T) {
+	ctx := context.Background()
+	taskTemplate := GetSingleHiveQueryTaskTemplate()
+	assert.NoError(t, core.ValidateTask(ctx, QuboleHiveExecutor{}, &taskTemplate))
+
+	taskTemplate.Custom = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	assert.Error(t, core.ValidateTask(ctx, QuboleHiveExecutor{}, &taskTemplate))
+}
+
 func TestConstructTaskLog(t *testing.T) {
 	expected := "https://wellness.qubole.com/v2/analyze?command_id=123"
 	u, err := url.Parse(expected)
@@ -194,7 +205,7 @@ func TestGetAllocationToken(t *testing.T) {
 
 		mockCurrentState := ExecutionState{AllocationTokenRequestStartTime: time.Now()}
 		mockMetrics := getQuboleHiveExecutorMetrics(promutils.NewTestScope())
-		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, mockMetrics)
+		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, config.GetQuboleConfig(), mockMetrics)
 		assert.NoError(t, err)
 		assert.Equal(t, PhaseQueued, state.Phase)
 	})
@@ -208,7 +219,7 @@ func TestGetAllocationToken(t *testing.T) {
 
 		mockCurrentState := ExecutionState{AllocationTokenRequestStartTime: time.Now()}
 		mockMetrics := getQuboleHiveExecutorMetrics(promutils.NewTestScope())
-		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, mockMetrics)
+		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, config.GetQuboleConfig(), mockMetrics)
 		assert.NoError(t, err)
 		assert.Equal(t, PhaseNotStarted, state.Phase)
 	})
@@ -222,7 +233,7 @@ func TestGetAllocationToken(t *testing.T) {
 
 		mockCurrentState := ExecutionState{AllocationTokenRequestStartTime: time.Now()}
 		mockMetrics := getQuboleHiveExecutorMetrics(promutils.NewTestScope())
-		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, mockMetrics)
+		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, config.GetQuboleConfig(), mockMetrics)
 		assert.NoError(t, err)
 		assert.Equal(t, PhaseNotStarted, state.Phase)
 	})
@@ -236,7 +247,7 @@ func TestGetAllocationToken(t *testing.T) {
 
 		mockCurrentState := ExecutionState{}
 		mockMetrics := getQuboleHiveExecutorMetrics(promutils.NewTestScope())
-		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, mockMetrics)
+		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, config.GetQuboleConfig(), mockMetrics)
 		assert.NoError(t, err)
 		assert.Equal(t, state.AllocationTokenRequestStartTime.IsZero(), false)
 	})
@@ -251,11 +262,26 @@ func TestGetAllocationToken(t *testing.T) {
 		startTime := time.Now()
 		mockCurrentState := ExecutionState{AllocationTokenRequestStartTime: startTime}
 		mockMetrics := getQuboleHiveExecutorMetrics(promutils.NewTestScope())
-		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, mockMetrics)
+		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, config.GetQuboleConfig(), mockMetrics)
 		assert.NoError(t, err)
 		assert.Equal(t, state.AllocationTokenRequestStartTime.IsZero(), false)
 		assert.Equal(t, state.AllocationTokenRequestStartTime, startTime)
 	})
+
+	t.Run("lease from the passed config", func(t *testing.T) {
+		rm := &mocks.LeasedResourceManager{}
+		rm.OnAllocateResourceWithLeaseMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything, 2*time.Hour).
+			Return(core.AllocationStatusGranted, nil)
+		tCtx := leasedTaskExecutionContext{TaskExecutionContext: GetMockTaskExecutionContext(), rm: rm}
+
+		cfg := *config.GetQuboleConfig()
+		cfg.ResourceLeaseTTL = flyteConfig.Duration{Duration: 2 * time.Hour}
+		mockMetrics := getQuboleHiveExecutorMetrics(promutils.NewTestScope())
+		state, err := GetAllocationToken(ctx, tCtx, ExecutionState{}, &cfg, mockMetrics)
+		assert.NoError(t, err)
+		assert.Equal(t, PhaseQueued, state.Phase)
+		rm.AssertNumberOfCalls(t, "AllocateResourceWithLease", 1)
+	})
 }
 
 func TestAbort(t *testing.T) {
@@ -303,7 +329,7 @@ func TestFinalize(t *testing.T) {
 		called = true
 	}).Return(nil)
 
-	err := Finalize(ctx, tCtx, state, getQuboleHiveExecutorMetrics(promutils.NewTestScope()))
+	err := Finalize(ctx, tCtx, state, config.GetQuboleConfig(), getQuboleHiveExecutorMetrics(promutils.NewTestScope()))
 	assert.NoError(t, err)
 	assert.True(t, called)
 }
@@ -467,9 +493,70 @@ func Test_getClusterPrimaryLabel(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := getClusterPrimaryLabel(tt.args.ctx, tt.args.tCtx, tt.args.clusterLabelOverride); got != tt.want {
+			if got := getClusterPrimaryLabel(tt.args.ctx, tt.args.tCtx, config.GetQuboleConfig(), tt.args.clusterLabelOverride); got != tt.want {
 				t.Errorf("getClusterPrimaryLabel() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+type leasedTaskExecutionContext struct {
+	core.TaskExecutionContext
+	rm *mocks.LeasedResourceManager
+}
+
+func (l leasedTaskExecutionContext) ResourceManager() core.ResourceManager {
+	return l.rm
+}
+
+func TestRenewAllocationToken(t *testing.T) {
+	ctx := context.Background()
+	mockMetrics := getQuboleHiveExecutorMetrics(promutils.NewTestScope())
+
+	t.Run("renewed", func(t *testing.T) {
+		rm := &mocks.LeasedResourceManager{}
+		rm.OnRenewResourceMatch(mock.Anything, mock.Anything, mock.Anything, time.Hour).Return(nil)
+		tCtx := leasedTaskExecutionContext{TaskExecutionContext: GetMockTaskExecutionContext(), rm: rm}
+
+		RenewAllocationToken(ctx, tCtx, &config.Config{ResourceLeaseTTL: flyteConfig.Duration{Duration: time.Hour}}, mockMetrics)
+		rm.AssertNumberOfCalls(t, "RenewResource", 1)
+	})
+
+	t.Run("leases disabled", func(t *testing.T) {
+		rm := &mocks.LeasedResourceManager{}
+		tCtx := leasedTaskExecutionContext{TaskExecutionContext: GetMockTaskExecutionContext(), rm: rm}
+
+		RenewAllocationToken(ctx, tCtx, &config.Config{}, mockMetrics)
+		rm.AssertNotCalled(t, "RenewResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("leases not supported", func(t *testing.T) {
+		RenewAllocationToken(ctx, GetMockTaskExecutionContext(),
+			&config.Config{ResourceLeaseTTL: flyteConfig.Duration{Duration: time.Hour}}, mockMetrics)
+	})
+}
+
+func TestGetQuboleToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Key", func(t *testing.T) {
+		sm := &mocks.SecretManager{}
+		sm.OnGet(ctx, "FLYTE_QUBOLE_CLIENT_TOKEN").Return("token", nil)
+
+		token, err := GetQuboleToken(ctx, sm, &config.Config{TokenKey: "FLYTE_QUBOLE_CLIENT_TOKEN"})
+		assert.NoError(t, err)
+		assert.Equal(t, "token", token)
+	})
+
+	t.Run("Reference", func(t *testing.T) {
+		sm := &mocks.StructuredSecretManager{}
+		sm.OnGetSecret(ctx, core.SecretReference{Group: "qubole", Key: "token", Version: "3"}).Return("token", nil)
+
+		token, err := GetQuboleToken(ctx, sm, &config.Config{TokenKey: "qubole/token@3"})
+		assert.NoError(t, err)
+		assert.Equal(t, "token", token)
+
+		_, err = GetQuboleToken(ctx, sm, &config.Config{TokenKey: "qubole/"})
+		assert.Error(t, err)
+	})
+}
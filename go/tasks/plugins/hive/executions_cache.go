@@ -12,6 +12,7 @@ import (
 	stdErrors "github.com/flyteorg/flytestdlib/errors"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/hive/client"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/hive/config"
 
@@ -33,8 +34,11 @@ type QuboleHiveExecutionsCache struct {
 	cfg           *config.Config
 }
 
+// NewQuboleHiveExecutionsCache creates a cache that refreshes the commands' status. Its syncs are tracked by
+// refreshWorkers, if not nil, so that stopping the cache can wait for them.
 func NewQuboleHiveExecutionsCache(ctx context.Context, quboleClient client.QuboleClient,
-	secretManager core.SecretManager, cfg *config.Config, scope promutils.Scope) (QuboleHiveExecutionsCache, error) {
+	secretManager core.SecretManager, cfg *config.Config, refreshWorkers *utils.RefreshWorkers,
+	scope promutils.Scope) (QuboleHiveExecutionsCache, error) {
 
 	q := QuboleHiveExecutionsCache{
 		quboleClient:  quboleClient,
@@ -42,7 +46,7 @@ func NewQuboleHiveExecutionsCache(ctx context.Context, quboleClient client.Qubol
 		scope:         scope,
 		cfg:           cfg,
 	}
-	autoRefreshCache, err := cache.NewAutoRefreshCache("qubole", q.SyncQuboleQuery, workqueue.DefaultControllerRateLimiter(), ResyncDuration, cfg.Workers, cfg.LruCacheSize, scope)
+	autoRefreshCache, err := cache.NewAutoRefreshCache("qubole", refreshWorkers.Wrap(q.SyncQuboleQuery), workqueue.DefaultControllerRateLimiter(), ResyncDuration, cfg.Workers, cfg.LruCacheSize, scope)
 	if err != nil {
 		logger.Errorf(ctx, "Could not create AutoRefreshCache in QuboleHiveExecutor. [%s]", err)
 		return q, errors.Wrapf(errors.CacheFailed, err, "Error creating AutoRefreshCache")
@@ -92,7 +96,7 @@ func (q *QuboleHiveExecutionsCache) SyncQuboleQuery(ctx context.Context, batch c
 		logger.Debugf(ctx, "Sync loop - processing Hive job [%s] - cache key [%s]",
 			executionStateCacheItem.CommandID, executionStateCacheItem.Identifier)
 
-		quboleAPIKey, err := q.secretManager.Get(ctx, q.cfg.TokenKey)
+		quboleAPIKey, err := GetQuboleToken(ctx, q.secretManager, q.cfg)
 		if err != nil {
 			return nil, err
 		}
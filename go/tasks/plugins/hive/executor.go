@@ -3,11 +3,13 @@ package hive
 import (
 	"context"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
 	"github.com/flyteorg/flytestdlib/cache"
 
-	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	pluginMachinery "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/hive/client"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/hive/config"
 	"github.com/flyteorg/flytestdlib/logger"
@@ -21,6 +23,10 @@ const quboleHiveExecutorID = "qubole-hive-executor"
 // the structure of the stored state
 const pluginStateVersion = 0
 
+// pluginStateMigrator reads the persisted ExecutionState. Register migrations here when the state changes in
+// incompatible ways.
+var pluginStateMigrator = core.NewPluginStateMigrator(pluginStateVersion)
+
 const hiveTaskType = "hive" // This needs to match the type defined in Flytekit constants.py
 
 const DefaultClusterPrimaryLabel = "default"
@@ -31,6 +37,9 @@ type QuboleHiveExecutor struct {
 	quboleClient    client.QuboleClient
 	executionsCache cache.AutoRefresh
 	cfg             *config.Config
+	// Stops the background refresh of the executions cache.
+	stopCache      context.CancelFunc
+	refreshWorkers *utils.RefreshWorkers
 }
 
 func (q QuboleHiveExecutor) GetID() string {
@@ -42,11 +51,10 @@ func (q QuboleHiveExecutor) Handle(ctx context.Context, tCtx core.TaskExecutionC
 
 	// We assume here that the first time this function is called, the custom state we get back is whatever we passed in,
 	// namely the zero-value of our struct.
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if err := pluginStateMigrator.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state when handling [%s] [%s]",
 			q.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
-		return core.UnknownTransition, errors.Wrapf(errors.CorruptedPluginState, err,
-			"Failed to unmarshal custom state in Handle")
+		return core.UnknownTransition, err
 	}
 
 	// Do what needs to be done, and give this function everything it needs to do its job properly
@@ -70,13 +78,13 @@ func (q QuboleHiveExecutor) Handle(ctx context.Context, tCtx core.TaskExecutionC
 
 func (q QuboleHiveExecutor) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
 	incomingState := ExecutionState{}
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if err := pluginStateMigrator.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state in Finalize [%s] Err [%s]",
 			q.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
-		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to unmarshal custom state in Finalize")
+		return err
 	}
 
-	key, err := tCtx.SecretManager().Get(ctx, q.cfg.TokenKey)
+	key, err := GetQuboleToken(ctx, tCtx.SecretManager(), q.cfg)
 	if err != nil {
 		logger.Errorf(ctx, "Error reading token in Finalize [%s]", err)
 		return err
@@ -87,19 +95,48 @@ func (q QuboleHiveExecutor) Abort(ctx context.Context, tCtx core.TaskExecutionCo
 
 func (q QuboleHiveExecutor) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
 	incomingState := ExecutionState{}
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if err := pluginStateMigrator.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state in Finalize [%s] Err [%s]",
 			q.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
-		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to unmarshal custom state in Finalize")
+		return err
 	}
 
-	return Finalize(ctx, tCtx, incomingState, q.metrics)
+	return Finalize(ctx, tCtx, incomingState, q.cfg, q.metrics)
 }
 
 func (q QuboleHiveExecutor) GetProperties() core.PluginProperties {
 	return core.PluginProperties{}
 }
 
+// ValidateTask checks that the task template holds a QuboleHiveJob with a query.
+func (q QuboleHiveExecutor) ValidateTask(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	_, err := getQuboleHiveJob(taskTemplate)
+	return err
+}
+
+// CheckHealth reports the plugin as unhealthy if the Qubole endpoint is unreachable.
+func (q QuboleHiveExecutor) CheckHealth(ctx context.Context) error {
+	if checker, ok := q.quboleClient.(core.HealthChecker); ok {
+		return checker.CheckHealth(ctx)
+	}
+
+	return nil
+}
+
+// Stop stops refreshing the executions cache and waits for the running syncs to return.
+func (q QuboleHiveExecutor) Stop(ctx context.Context) error {
+	if q.stopCache != nil {
+		q.stopCache()
+	}
+
+	if err := q.refreshWorkers.Stop(ctx); err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "Stopped plugin [%v].", q.id)
+	return nil
+}
+
 func QuboleHiveExecutorLoader(ctx context.Context, iCtx core.SetupContext) (core.Plugin, error) {
 	cfg := config.GetQuboleConfig()
 	return InitializeHiveExecutor(ctx, iCtx, cfg, BuildResourceConfig(cfg.ClusterConfigs), client.NewQuboleClient(cfg))
@@ -136,13 +173,16 @@ func InitializeHiveExecutor(ctx context.Context, iCtx core.SetupContext, cfg *co
 
 // type PluginLoader func(ctx context.Context, iCtx SetupContext) (Plugin, error)
 func NewQuboleHiveExecutor(ctx context.Context, cfg *config.Config, quboleClient client.QuboleClient, secretManager core.SecretManager, scope promutils.Scope) (QuboleHiveExecutor, error) {
-	executionsAutoRefreshCache, err := NewQuboleHiveExecutionsCache(ctx, quboleClient, secretManager, cfg, scope.NewSubScope(hiveTaskType))
+	refreshWorkers := &utils.RefreshWorkers{}
+	executionsAutoRefreshCache, err := NewQuboleHiveExecutionsCache(ctx, quboleClient, secretManager, cfg, refreshWorkers,
+		scope.NewSubScope(hiveTaskType))
 	if err != nil {
 		logger.Errorf(ctx, "Failed to create AutoRefreshCache in QuboleHiveExecutor Setup. Error: %v", err)
 		return QuboleHiveExecutor{}, err
 	}
 
-	err = executionsAutoRefreshCache.Start(ctx)
+	cacheCtx, stopCache := context.WithCancel(ctx)
+	err = executionsAutoRefreshCache.Start(cacheCtx)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to start AutoRefreshCache. Error: %v", err)
 	}
@@ -153,6 +193,8 @@ func NewQuboleHiveExecutor(ctx context.Context, cfg *config.Config, quboleClient
 		metrics:         getQuboleHiveExecutorMetrics(scope.NewSubScope("hive")),
 		quboleClient:    quboleClient,
 		executionsCache: executionsAutoRefreshCache,
+		stopCache:       stopCache,
+		refreshWorkers:  refreshWorkers,
 	}, nil
 }
 
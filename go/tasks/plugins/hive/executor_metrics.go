@@ -10,6 +10,7 @@ type QuboleHiveExecutorMetrics struct {
 	Scope                 promutils.Scope
 	ResourceReleased      labeled.Counter
 	ResourceReleaseFailed labeled.Counter
+	LeaseRenewFailed      labeled.Counter
 	AllocationGranted     labeled.Counter
 	AllocationNotGranted  labeled.Counter
 	ResourceWaitTime      prometheus.Summary
@@ -26,6 +27,8 @@ func getQuboleHiveExecutorMetrics(scope promutils.Scope) QuboleHiveExecutorMetri
 			"Resource allocation token released", scope, labeled.EmitUnlabeledMetric),
 		ResourceReleaseFailed: labeled.NewCounter("resource_release_failed",
 			"Error releasing allocation token", scope, labeled.EmitUnlabeledMetric),
+		LeaseRenewFailed: labeled.NewCounter("lease_renew_failed",
+			"Error renewing the lease of an allocation token", scope, labeled.EmitUnlabeledMetric),
 		AllocationGranted: labeled.NewCounter("allocation_grant_success",
 			"Allocation request granted", scope, labeled.EmitUnlabeledMetric),
 		AllocationNotGranted: labeled.NewCounter("allocation_grant_failed",
@@ -3,6 +3,7 @@ package container
 import (
 	"context"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
@@ -26,6 +27,11 @@ func (Plugin) GetProperties() k8s.PluginProperties {
 	return k8s.PluginProperties{}
 }
 
+// ValidateTask checks that the task template defines the container to run.
+func (Plugin) ValidateTask(_ context.Context, taskTemplate *core.TaskTemplate) error {
+	return flytek8s.ValidateContainerTask(taskTemplate)
+}
+
 func (Plugin) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, r client.Object) (pluginsCore.PhaseInfo, error) {
 
 	pod := r.(*v1.Pod)
@@ -40,6 +46,7 @@ func (Plugin) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext,
 			return pluginsCore.PhaseInfoUndefined, err
 		}
 		info.Logs = taskLogs
+		info.ResourceUsage = flytek8s.PodResourceUsage(pod)
 	}
 	switch pod.Status.Phase {
 	case v1.PodSucceeded:
@@ -48,7 +55,7 @@ func (Plugin) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext,
 		code, message := flytek8s.ConvertPodFailureToError(pod.Status)
 		return pluginsCore.PhaseInfoRetryableFailure(code, message, &info), nil
 	case v1.PodPending:
-		return flytek8s.DemystifyPending(pod.Status)
+		return flytek8s.DemystifyPendingPod(ctx, pluginContext, pod)
 	case v1.PodUnknown:
 		return pluginsCore.PhaseInfoUndefined, nil
 	}
@@ -11,16 +11,20 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
 	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	pluginsIOMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
 )
 
 var resourceRequirements = &v1.ResourceRequirements{
@@ -137,6 +141,69 @@ func TestContainerTaskExecutor_BuildResource(t *testing.T) {
 	assert.Equal(t, "service-account", j.Spec.ServiceAccountName)
 }
 
+// secretsTaskContext overrides the security context of the task.
+type secretsTaskContext struct {
+	pluginsCore.TaskExecutionContext
+	secrets []*core.Secret
+}
+
+func (s secretsTaskContext) TaskExecutionMetadata() pluginsCore.TaskExecutionMetadata {
+	return secretsTaskMetadata{TaskExecutionMetadata: s.TaskExecutionContext.TaskExecutionMetadata(), secrets: s.secrets}
+}
+
+type secretsTaskMetadata struct {
+	pluginsCore.TaskExecutionMetadata
+	secrets []*core.Secret
+}
+
+func (s secretsTaskMetadata) GetSecurityContext() core.SecurityContext {
+	return core.SecurityContext{Secrets: s.secrets}
+}
+
+func TestContainerTaskExecutor_BuildResource_Secrets(t *testing.T) {
+	previous := *config.GetK8sPluginConfig()
+	defer func() { assert.NoError(t, config.SetK8sPluginConfig(&previous)) }()
+
+	cfg := previous
+	cfg.SecretInjection = config.SecretInjectionConfig{
+		Enabled:          true,
+		DefaultMountType: config.SecretMountTypeEnvVar,
+		EnvVarPrefix:     "_FSEC_",
+		MountPath:        "/etc/flyte/secrets",
+	}
+	assert.NoError(t, config.SetK8sPluginConfig(&cfg))
+
+	c := Plugin{}
+	taskCtx := dummyContainerTaskContext(resourceRequirements, []string{"command"}, nil)
+
+	r, err := c.BuildResource(context.TODO(), secretsTaskContext{
+		TaskExecutionContext: taskCtx,
+		secrets:              []*core.Secret{{Group: "db", Key: "password"}},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, r.(*v1.Pod).Spec.Containers[0].Env, v1.EnvVar{
+		Name: "_FSEC_DB_PASSWORD",
+		ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "db"},
+			Key:                  "password",
+		}},
+	})
+
+	_, err = c.BuildResource(context.TODO(), secretsTaskContext{
+		TaskExecutionContext: taskCtx,
+		secrets:              []*core.Secret{{Key: "password"}},
+	})
+	assert.Error(t, err)
+	code, found := stdErrors.GetErrorCode(err)
+	assert.True(t, found)
+	assert.Equal(t, errors.BadTaskSpecification, code)
+}
+
+type pluginContextWithEvents struct {
+	*k8sMocks.PluginContext
+	*k8sMocks.EventsReader
+}
+
 func TestContainerTaskExecutor_GetTaskStatus(t *testing.T) {
 	c := Plugin{}
 	j := &v1.Pod{
@@ -158,6 +225,25 @@ func TestContainerTaskExecutor_GetTaskStatus(t *testing.T) {
 		assert.Equal(t, pluginsCore.PhaseQueued, phaseInfo.Phase())
 	})
 
+	t.Run("missingSecret", func(t *testing.T) {
+		j.Status.Phase = v1.PodPending
+		events := &k8sMocks.EventsReader{}
+		events.OnRecentEvents(ctx, j).Return([]v1.Event{
+			{
+				Type:    v1.EventTypeWarning,
+				Reason:  "FailedMount",
+				Message: `MountVolume.SetUp failed for volume "flyte-secrets-0" : secret "my-group" not found`,
+				// Reported as many times as the default k8s.fatal-pod-event-min-count.
+				Count: 3,
+			},
+		}, nil)
+
+		phaseInfo, err := c.GetTaskPhase(ctx, pluginContextWithEvents{&k8sMocks.PluginContext{}, events}, j)
+		assert.NoError(t, err)
+		assert.Equal(t, pluginsCore.PhasePermanentFailure, phaseInfo.Phase())
+		assert.Equal(t, string(errors.BadTaskSpecification), phaseInfo.Err().GetCode())
+	})
+
 	t.Run("failNoCondition", func(t *testing.T) {
 		j.Status.Phase = v1.PodFailed
 		phaseInfo, err := c.GetTaskPhase(ctx, nil, j)
@@ -197,3 +283,15 @@ func TestContainerTaskExecutor_GetProperties(t *testing.T) {
 	expected := k8s.PluginProperties{}
 	assert.Equal(t, expected, plugin.GetProperties())
 }
+
+func TestContainerTaskExecutor_ValidateTask(t *testing.T) {
+	assert.NoError(t, Plugin{}.ValidateTask(context.TODO(), &core.TaskTemplate{
+		Target: &core.TaskTemplate_Container{Container: &core.Container{Image: "image"}},
+	}))
+
+	err := Plugin{}.ValidateTask(context.TODO(), &core.TaskTemplate{})
+	assert.Error(t, err)
+	code, found := stdErrors.GetErrorCode(err)
+	assert.True(t, found)
+	assert.Equal(t, errors.BadTaskSpecification, code)
+}
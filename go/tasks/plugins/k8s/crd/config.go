@@ -0,0 +1,76 @@
+package crd
+
+import (
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+)
+
+var (
+	defaultConfig = &Config{}
+
+	configSection = pluginsConfig.MustRegisterSubSection("crd", defaultConfig)
+)
+
+type Config struct {
+	Resources map[string]ResourceConfig `json:"resources" pflag:"-,Defines the custom resource created for each task type, indexed by task type."`
+}
+
+// ResourceConfig defines the custom resource created for the tasks of a task type and how to interpret its status.
+type ResourceConfig struct {
+	// The API version and kind of the custom resource, e.g. ray.io/v1alpha1 and RayJob.
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// The custom resource in YAML or JSON. It's a template evaluated when the task is submitted. It supports the
+	// variables of the core/template package (e.g. {{ .Inputs.myInput }} or {{ .OutputPrefix }}) as well as:
+	// - {{ .Name }} and {{ .Namespace }}, the name and namespace of the custom resource.
+	// - {{ .PodSpec }}, the pod spec built from the container of the task. It's only built when it's referenced, in
+	// 		which case the task must define a container.
+	// The template is parsed before the variables are evaluated, so variables can only be used in the values and keys
+	// of fields and are always substituted as strings. {{ .PodSpec }} is the exception: it must be the whole value of a
+	// field, which it replaces with the pod spec object.
+	Template string `json:"template"`
+
+	// Defines how to interpret the status of the custom resource.
+	Status StatusConfig `json:"status"`
+
+	// The log links of the pods created by the operator for the custom resource.
+	Logs []PodLogConfig `json:"logs"`
+}
+
+// StatusConfig holds JSONPath expressions (e.g. {.status.state}) evaluated against the custom resource.
+type StatusConfig struct {
+	// Extracts the state of the custom resource. An empty state, e.g. before the operator sets the status, is
+	// considered queued.
+	Phase string `json:"phase"`
+
+	// Extracts a human-readable message, e.g. the reason of a failure. Optional.
+	Message string `json:"message"`
+
+	// Maps the states of the custom resource to Flyte phases. Unknown states are considered running.
+	PhaseValues PhaseValues `json:"phaseValues"`
+}
+
+type PhaseValues struct {
+	Queued          []string `json:"queued"`
+	Succeeded       []string `json:"succeeded"`
+	Failed          []string `json:"failed"`
+	RetryableFailed []string `json:"retryableFailed"`
+}
+
+// PodLogConfig defines the log link of a pod created for the custom resource.
+type PodLogConfig struct {
+	// The name of the pod. It's a template that supports {{ .Name }} and {{ .Namespace }}, e.g. {{ .Name }}-head-0.
+	PodName string `json:"podName"`
+
+	// The name of the log link, e.g. head.
+	LogName string `json:"logName"`
+}
+
+func GetConfig() *Config {
+	return configSection.GetConfig().(*Config)
+}
+
+// This method should be used for unit testing only
+func setConfig(cfg *Config) error {
+	return configSection.SetConfig(cfg)
+}
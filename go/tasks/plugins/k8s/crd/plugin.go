@@ -0,0 +1,389 @@
+// Package crd implements a k8s plugin for custom resources managed by an operator (e.g. Ray, MPI or Dask). The custom
+// resource of each task type, and how to interpret its status, is entirely defined in config, so integrating a new
+// operator requires no code.
+package crd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/logs"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/tasklog"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+)
+
+var (
+	nameRegex        = regexp.MustCompile(`(?i){{\s*[\.$]Name\s*}}`)
+	namespaceRegex   = regexp.MustCompile(`(?i){{\s*[\.$]Namespace\s*}}`)
+	podSpecRegex     = regexp.MustCompile(`(?i){{\s*[\.$]PodSpec\s*}}`)
+	variableRegex    = regexp.MustCompile(`{{[^}]*}}`)
+	placeholderRegex = regexp.MustCompile(`__flyte_template_var_\d+__`)
+)
+
+// variablePlaceholder is substituted for the i-th variable of a template before the template is parsed. It's a plain
+// YAML scalar so that the variables can be placed anywhere a string is expected, quoted or not.
+func variablePlaceholder(i int) string {
+	return fmt.Sprintf("__flyte_template_var_%d__", i)
+}
+
+type crdResourceHandler struct {
+	taskType string
+	cfg      ResourceConfig
+}
+
+// Sanity test that the plugin implements method of k8s.Plugin
+var _ k8s.Plugin = crdResourceHandler{}
+
+func (h crdResourceHandler) newResource() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(h.cfg.APIVersion)
+	obj.SetKind(h.cfg.Kind)
+	return obj
+}
+
+// ValidateTask checks that the task template defines a container if the custom resource embeds the pod spec.
+func (h crdResourceHandler) ValidateTask(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	if podSpecRegex.MatchString(h.cfg.Template) {
+		return flytek8s.ValidateContainerTask(taskTemplate)
+	}
+
+	return nil
+}
+
+func (crdResourceHandler) GetProperties() k8s.PluginProperties {
+	return k8s.PluginProperties{}
+}
+
+func (h crdResourceHandler) BuildIdentityResource(_ context.Context, _ pluginsCore.TaskExecutionMetadata) (
+	client.Object, error) {
+	return h.newResource(), nil
+}
+
+// BuildResource parses the template before evaluating its variables, then substitutes the values of the variables in the
+// strings of the parsed resource. Values, the inputs of the task in particular, can't add fields to the resource.
+func (h crdResourceHandler) BuildResource(ctx context.Context, taskCtx pluginsCore.TaskExecutionContext) (
+	client.Object, error) {
+	var variables []string
+	manifest := variableRegex.ReplaceAllStringFunc(h.cfg.Template, func(variable string) string {
+		variables = append(variables, variable)
+		return variablePlaceholder(len(variables) - 1)
+	})
+
+	obj := h.newResource()
+	if err := yaml.Unmarshal([]byte(manifest), &obj.Object); err != nil {
+		return nil, errors.Wrapf(errors.BadTaskSpecification, err, "Failed to parse the [%v] resource of task type [%v].",
+			h.cfg.Kind, h.taskType)
+	}
+
+	values, err := template.Render(ctx, variables, template.Parameters{
+		TaskExecMetadata: taskCtx.TaskExecutionMetadata(),
+		Inputs:           taskCtx.InputReader(),
+		OutputPath:       taskCtx.OutputWriter(),
+		Task:             taskCtx.TaskReader(),
+	})
+
+	if err != nil {
+		return nil, errors.Wrapf(errors.BadTaskSpecification, err, "Failed to render the [%v] resource of task type [%v].",
+			h.cfg.Kind, h.taskType)
+	}
+
+	name := taskCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	namespace := taskCtx.TaskExecutionMetadata().GetNamespace()
+	placeholders := make(map[string]interface{}, len(values))
+	var podSpec map[string]interface{}
+	for i, value := range values {
+		if !podSpecRegex.MatchString(value) {
+			placeholders[variablePlaceholder(i)] = renderNames(value, name, namespace)
+			continue
+		}
+
+		// The pod spec is only built when it's referenced.
+		if podSpec == nil {
+			if podSpec, err = h.buildPodSpec(ctx, taskCtx); err != nil {
+				return nil, err
+			}
+		}
+
+		placeholders[variablePlaceholder(i)] = podSpec
+	}
+
+	substituted, err := substitutePlaceholders(obj.Object, placeholders)
+	if err != nil {
+		return nil, errors.Wrapf(errors.BadTaskSpecification, err, "Failed to render the [%v] resource of task type [%v].",
+			h.cfg.Kind, h.taskType)
+	}
+
+	obj.Object, _ = substituted.(map[string]interface{})
+	if obj.Object == nil {
+		obj.Object = map[string]interface{}{}
+	}
+
+	obj.SetAPIVersion(h.cfg.APIVersion)
+	obj.SetKind(h.cfg.Kind)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return obj, nil
+}
+
+// buildPodSpec returns the pod spec built from the container of the task as an unstructured object.
+func (h crdResourceHandler) buildPodSpec(ctx context.Context, taskCtx pluginsCore.TaskExecutionContext) (
+	map[string]interface{}, error) {
+	podSpec, err := flytek8s.ToK8sPodSpec(ctx, taskCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(podSpec)
+	if err != nil {
+		return nil, errors.Wrapf(errors.BadTaskSpecification, err, "Failed to serialize the pod spec.")
+	}
+
+	unstructuredPodSpec := map[string]interface{}{}
+	if err = json.Unmarshal(raw, &unstructuredPodSpec); err != nil {
+		return nil, errors.Wrapf(errors.BadTaskSpecification, err, "Failed to serialize the pod spec.")
+	}
+
+	return unstructuredPodSpec, nil
+}
+
+// substitutePlaceholders replaces the placeholders found in the strings of a parsed resource with their values. A string
+// that is exactly a placeholder is replaced with its value, which may be an object (e.g. the pod spec). Otherwise, the
+// placeholders within the string are replaced with their values, which must then be strings.
+func substitutePlaceholders(value interface{}, placeholders map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		substituted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			k, err := substitutePlaceholders(key, placeholders)
+			if err != nil {
+				return nil, err
+			}
+
+			keyString, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("the key [%v] must be a string", key)
+			}
+
+			if substituted[keyString], err = substitutePlaceholders(item, placeholders); err != nil {
+				return nil, err
+			}
+		}
+
+		return substituted, nil
+	case []interface{}:
+		substituted := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			s, err := substitutePlaceholders(item, placeholders)
+			if err != nil {
+				return nil, err
+			}
+
+			substituted = append(substituted, s)
+		}
+
+		return substituted, nil
+	case string:
+		if placeholderValue, found := placeholders[v]; found {
+			return placeholderValue, nil
+		}
+
+		embedsObject := false
+		substituted := placeholderRegex.ReplaceAllStringFunc(v, func(placeholder string) string {
+			placeholderValue, ok := placeholders[placeholder].(string)
+			embedsObject = embedsObject || !ok
+			return placeholderValue
+		})
+
+		if embedsObject {
+			return nil, fmt.Errorf("{{ .PodSpec }} must be the whole value of a field, found in [%v]", v)
+		}
+
+		return substituted, nil
+	}
+
+	return value, nil
+}
+
+// renderNames evaluates the name and namespace variables of a template.
+func renderNames(t, name, namespace string) string {
+	t = nameRegex.ReplaceAllLiteralString(t, name)
+	return namespaceRegex.ReplaceAllLiteralString(t, namespace)
+}
+
+func (h crdResourceHandler) getLogs(obj *unstructured.Unstructured) ([]*idlCore.TaskLog, error) {
+	if len(h.cfg.Logs) == 0 {
+		return nil, nil
+	}
+
+	logPlugin, err := logs.InitializeLogPlugins(logs.GetLogConfig())
+	if err != nil || logPlugin == nil {
+		return nil, err
+	}
+
+	var taskLogs []*idlCore.TaskLog
+	for _, l := range h.cfg.Logs {
+		o, err := logPlugin.GetTaskLogs(tasklog.Input{
+			PodName:   renderNames(l.PodName, obj.GetName(), obj.GetNamespace()),
+			Namespace: obj.GetNamespace(),
+			LogName:   l.LogName,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		taskLogs = append(taskLogs, o.TaskLogs...)
+	}
+
+	return taskLogs, nil
+}
+
+func (h crdResourceHandler) GetTaskPhase(ctx context.Context, _ k8s.PluginContext, resource client.Object) (
+	pluginsCore.PhaseInfo, error) {
+	obj := resource.(*unstructured.Unstructured)
+	state, err := utils.EvaluateJSONPathOrEmpty("phase", h.cfg.Status.Phase, obj.Object)
+	if err != nil {
+		return pluginsCore.PhaseInfoUndefined, errors.Wrapf(errors.DownstreamSystemError, err,
+			"Failed to extract the state of [%v] [%v].", h.cfg.Kind, obj.GetName())
+	}
+
+	var message string
+	if len(h.cfg.Status.Message) > 0 {
+		// Messages are usually only set in some states, a missing message isn't an error.
+		message, err = utils.EvaluateJSONPathOrEmpty("message", h.cfg.Status.Message, obj.Object)
+		if err != nil {
+			logger.Debugf(ctx, "Failed to extract the message of [%v] [%v]. Error: %v", h.cfg.Kind, obj.GetName(), err)
+		}
+	}
+
+	taskLogs, err := h.getLogs(obj)
+	if err != nil {
+		return pluginsCore.PhaseInfoUndefined, err
+	}
+
+	occurredAt := time.Now()
+	info := &pluginsCore.TaskInfo{
+		Logs:       taskLogs,
+		OccurredAt: &occurredAt,
+	}
+
+	if status, found := obj.Object["status"]; found {
+		info.CustomInfo, _ = utils.MarshalObjToStruct(status)
+	}
+
+	switch h.cfg.Status.PhaseValues.toPhase(state) {
+	case pluginsCore.PhaseQueued:
+		return pluginsCore.PhaseInfoQueued(occurredAt, pluginsCore.DefaultPhaseVersion, message), nil
+	case pluginsCore.PhaseSuccess:
+		return pluginsCore.PhaseInfoSuccess(info), nil
+	case pluginsCore.PhasePermanentFailure:
+		return pluginsCore.PhaseInfoFailure(errors.TaskFailedWithError, message, info), nil
+	case pluginsCore.PhaseRetryableFailure:
+		return pluginsCore.PhaseInfoRetryableFailure(errors.DownstreamSystemError, message, info), nil
+	}
+
+	return pluginsCore.PhaseInfoRunning(pluginsCore.DefaultPhaseVersion, info), nil
+}
+
+func (v PhaseValues) toPhase(state string) pluginsCore.Phase {
+	switch {
+	case len(state) == 0 || contains(v.Queued, state):
+		return pluginsCore.PhaseQueued
+	case contains(v.Succeeded, state):
+		return pluginsCore.PhaseSuccess
+	case contains(v.Failed, state):
+		return pluginsCore.PhasePermanentFailure
+	case contains(v.RetryableFailed, state):
+		return pluginsCore.PhaseRetryableFailure
+	}
+
+	return pluginsCore.PhaseRunning
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateResourceConfig(cfg ResourceConfig) error {
+	if len(cfg.APIVersion) == 0 || len(cfg.Kind) == 0 {
+		return fmt.Errorf("apiVersion and kind are required")
+	}
+
+	if len(cfg.Template) == 0 {
+		return fmt.Errorf("template is required")
+	}
+
+	if len(cfg.Status.Phase) == 0 {
+		return fmt.Errorf("status phase path is required")
+	}
+
+	for name, expr := range map[string]string{"phase": cfg.Status.Phase, "message": cfg.Status.Message} {
+		if _, err := utils.ParseJSONPath(name, expr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newPluginEntries returns a plugin for each task type defined in config, each watching its own custom resource.
+func newPluginEntries(cfg *Config) ([]k8s.PluginEntry, error) {
+	taskTypes := make([]string, 0, len(cfg.Resources))
+	for taskType := range cfg.Resources {
+		taskTypes = append(taskTypes, taskType)
+	}
+
+	sort.Strings(taskTypes)
+	entries := make([]k8s.PluginEntry, 0, len(taskTypes))
+	for _, taskType := range taskTypes {
+		resourceCfg := cfg.Resources[taskType]
+		if err := validateResourceConfig(resourceCfg); err != nil {
+			return nil, fmt.Errorf("task type [%v]: %w", taskType, err)
+		}
+
+		handler := crdResourceHandler{taskType: taskType, cfg: resourceCfg}
+		entries = append(entries, k8s.PluginEntry{
+			ID:                  taskType,
+			RegisteredTaskTypes: []pluginsCore.TaskType{taskType},
+			ResourceToWatch:     handler.newResource(),
+			Plugin:              handler,
+			IsDefault:           false,
+			DefaultForTaskTypes: []pluginsCore.TaskType{taskType},
+		})
+	}
+
+	return entries, nil
+}
+
+func init() {
+	pluginmachinery.PluginRegistry().RegisterK8sPluginLoader(func(ctx context.Context) ([]k8s.PluginEntry, error) {
+		entries, err := newPluginEntries(GetConfig())
+		if err != nil {
+			return nil, fmt.Errorf("invalid crd plugin config: %w", err)
+		}
+
+		return entries, nil
+	})
+}
@@ -0,0 +1,261 @@
+package crd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/logs"
+	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	pluginIOMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+)
+
+const rayJobTemplate = `
+metadata:
+  labels:
+    epochs: "{{ .Inputs.epochs }}"
+spec:
+  entrypoint: train --output {{ .OutputPrefix }}
+  headService: {{ .Name }}-head.{{ .Namespace }}
+  rayClusterSpec:
+    headGroupSpec:
+      template:
+        spec: {{ .PodSpec }}
+`
+
+var rayJobConfig = ResourceConfig{
+	APIVersion: "ray.io/v1alpha1",
+	Kind:       "RayJob",
+	Template:   rayJobTemplate,
+	Status: StatusConfig{
+		Phase:   "{.status.jobStatus}",
+		Message: "{.status.message}",
+		PhaseValues: PhaseValues{
+			Queued:          []string{"PENDING"},
+			Succeeded:       []string{"SUCCEEDED"},
+			Failed:          []string{"FAILED"},
+			RetryableFailed: []string{"STOPPED"},
+		},
+	},
+	Logs: []PodLogConfig{
+		{PodName: "{{ .Name }}-head-0", LogName: "head"},
+	},
+}
+
+func dummyTaskTemplate() *core.TaskTemplate {
+	return &core.TaskTemplate{
+		Id:   &core.Identifier{Name: "ray-task"},
+		Type: "ray",
+		Target: &core.TaskTemplate_Container{
+			Container: &core.Container{
+				Image: "image://",
+				Args:  []string{"train"},
+			},
+		},
+	}
+}
+
+func dummyTaskContext(taskTemplate *core.TaskTemplate) pluginsCore.TaskExecutionContext {
+	return dummyTaskContextWithInputs(taskTemplate, &core.LiteralMap{
+		Literals: map[string]*core.Literal{"epochs": utils.MustMakeLiteral(10)},
+	})
+}
+
+func dummyTaskContextWithInputs(taskTemplate *core.TaskTemplate, inputs *core.LiteralMap) pluginsCore.TaskExecutionContext {
+	taskCtx := &mocks.TaskExecutionContext{}
+	inputReader := &pluginIOMocks.InputReader{}
+	inputReader.OnGetInputPrefixPath().Return(storage.DataReference("/input/prefix"))
+	inputReader.OnGetInputPath().Return(storage.DataReference("/input"))
+	inputReader.OnGetMatch(mock.Anything).Return(inputs, nil)
+	taskCtx.OnInputReader().Return(inputReader)
+
+	outputReader := &pluginIOMocks.OutputWriter{}
+	outputReader.OnGetOutputPath().Return(storage.DataReference("/data/outputs.pb"))
+	outputReader.OnGetOutputPrefixPath().Return(storage.DataReference("/data/"))
+	outputReader.OnGetRawOutputPrefix().Return(storage.DataReference(""))
+	taskCtx.OnOutputWriter().Return(outputReader)
+
+	taskReader := &mocks.TaskReader{}
+	taskReader.OnReadMatch(mock.Anything).Return(taskTemplate, nil)
+	taskCtx.OnTaskReader().Return(taskReader)
+
+	tID := &mocks.TaskExecutionID{}
+	tID.OnGetID().Return(core.TaskExecutionIdentifier{
+		NodeExecutionId: &core.NodeExecutionIdentifier{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Name:    "my_name",
+				Project: "my_project",
+				Domain:  "my_domain",
+			},
+		},
+	})
+	tID.OnGetGeneratedName().Return("some-acceptable-name")
+
+	overrides := &mocks.TaskOverrides{}
+	overrides.OnGetResources().Return(&corev1.ResourceRequirements{})
+
+	taskExecutionMetadata := &mocks.TaskExecutionMetadata{}
+	taskExecutionMetadata.OnGetTaskExecutionID().Return(tID)
+	taskExecutionMetadata.OnGetNamespace().Return("test-namespace")
+	taskExecutionMetadata.OnGetAnnotations().Return(map[string]string{})
+	taskExecutionMetadata.OnGetLabels().Return(map[string]string{})
+	taskExecutionMetadata.OnIsInterruptible().Return(false)
+	taskExecutionMetadata.OnGetOverrides().Return(overrides)
+	taskExecutionMetadata.OnGetK8sServiceAccount().Return("service-account")
+	taskCtx.OnTaskExecutionMetadata().Return(taskExecutionMetadata)
+	return taskCtx
+}
+
+func TestNewPluginEntries(t *testing.T) {
+	entries, err := newPluginEntries(&Config{Resources: map[string]ResourceConfig{"ray": rayJobConfig}})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "ray", entries[0].ID)
+	assert.Equal(t, []pluginsCore.TaskType{"ray"}, entries[0].RegisteredTaskTypes)
+	assert.Equal(t, "RayJob", entries[0].ResourceToWatch.GetObjectKind().GroupVersionKind().Kind)
+
+	invalid := rayJobConfig
+	invalid.Status.Phase = "{.status.jobStatus"
+	_, err = newPluginEntries(&Config{Resources: map[string]ResourceConfig{"ray": invalid}})
+	assert.Error(t, err)
+
+	invalid = rayJobConfig
+	invalid.Kind = ""
+	_, err = newPluginEntries(&Config{Resources: map[string]ResourceConfig{"ray": invalid}})
+	assert.Error(t, err)
+}
+
+func TestValidateTask(t *testing.T) {
+	handler := crdResourceHandler{taskType: "ray", cfg: rayJobConfig}
+	assert.NoError(t, handler.ValidateTask(context.TODO(), dummyTaskTemplate()))
+
+	err := handler.ValidateTask(context.TODO(), &core.TaskTemplate{Type: "ray"})
+	assert.Error(t, err)
+	code, _ := stdErrors.GetErrorCode(err)
+	assert.Equal(t, flyteerr.BadTaskSpecification, code)
+
+	withoutPodSpec := rayJobConfig
+	withoutPodSpec.Template = "spec: {}"
+	handler = crdResourceHandler{taskType: "ray", cfg: withoutPodSpec}
+	assert.NoError(t, handler.ValidateTask(context.TODO(), &core.TaskTemplate{Type: "ray"}))
+}
+
+func TestBuildResource(t *testing.T) {
+	handler := crdResourceHandler{taskType: "ray", cfg: rayJobConfig}
+	resource, err := handler.BuildResource(context.TODO(), dummyTaskContext(dummyTaskTemplate()))
+	assert.NoError(t, err)
+
+	obj := resource.(*unstructured.Unstructured)
+	assert.Equal(t, "ray.io/v1alpha1", obj.GetAPIVersion())
+	assert.Equal(t, "RayJob", obj.GetKind())
+	assert.Equal(t, "some-acceptable-name", obj.GetName())
+	assert.Equal(t, "test-namespace", obj.GetNamespace())
+	assert.Equal(t, map[string]string{"epochs": "10"}, obj.GetLabels())
+
+	entrypoint, _, _ := unstructured.NestedString(obj.Object, "spec", "entrypoint")
+	assert.Equal(t, "train --output /data/", entrypoint)
+	headService, _, _ := unstructured.NestedString(obj.Object, "spec", "headService")
+	assert.Equal(t, "some-acceptable-name-head.test-namespace", headService)
+
+	containers, found, err := unstructured.NestedSlice(obj.Object, "spec", "rayClusterSpec", "headGroupSpec",
+		"template", "spec", "containers")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Len(t, containers, 1)
+	assert.Equal(t, "image://", containers[0].(map[string]interface{})["image"])
+
+	t.Run("inputs can't add fields", func(t *testing.T) {
+		taskCtx := dummyTaskContextWithInputs(dummyTaskTemplate(), &core.LiteralMap{
+			Literals: map[string]*core.Literal{"epochs": utils.MustMakeLiteral("10\"\n  injected: \"true")},
+		})
+
+		resource, err := handler.BuildResource(context.TODO(), taskCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"epochs": "10\"\n  injected: \"true"}, resource.GetLabels())
+	})
+
+	t.Run("pod spec within a string", func(t *testing.T) {
+		invalid := rayJobConfig
+		invalid.Template = "spec: \"containers: {{ .PodSpec }}\""
+		handler := crdResourceHandler{taskType: "ray", cfg: invalid}
+		_, err := handler.BuildResource(context.TODO(), dummyTaskContext(dummyTaskTemplate()))
+		assert.Error(t, err)
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, flyteerr.BadTaskSpecification, code)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		invalid := rayJobConfig
+		invalid.Template = "spec: ["
+		handler := crdResourceHandler{taskType: "ray", cfg: invalid}
+		_, err := handler.BuildResource(context.TODO(), dummyTaskContext(dummyTaskTemplate()))
+		assert.Error(t, err)
+		code, _ := stdErrors.GetErrorCode(err)
+		assert.Equal(t, flyteerr.BadTaskSpecification, code)
+	})
+}
+
+func TestGetTaskPhase(t *testing.T) {
+	ctx := context.TODO()
+	previous := *logs.GetLogConfig()
+	defer func() {
+		assert.NoError(t, logs.SetLogConfig(&previous))
+	}()
+
+	assert.NoError(t, logs.SetLogConfig(&logs.LogConfig{
+		IsKubernetesEnabled:   true,
+		KubernetesTemplateURI: "k8s.com/#!/log/{{ .namespace }}/{{ .podName }}/pod?namespace={{ .namespace }}",
+	}))
+
+	handler := crdResourceHandler{taskType: "ray", cfg: rayJobConfig}
+	resource, err := handler.BuildResource(ctx, dummyTaskContext(dummyTaskTemplate()))
+	assert.NoError(t, err)
+
+	// The operator updates the status of the custom resource, which the framework observes through its client.
+	kubeClient := mocks.NewFakeKubeClient()
+	assert.NoError(t, kubeClient.Create(ctx, resource))
+	getTaskPhase := func(status map[string]interface{}) pluginsCore.PhaseInfo {
+		updated := resource.DeepCopyObject().(*unstructured.Unstructured)
+		if status != nil {
+			updated.Object["status"] = status
+		}
+
+		assert.NoError(t, kubeClient.Update(ctx, updated))
+		observed, err := handler.BuildIdentityResource(ctx, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, kubeClient.Get(ctx, client.ObjectKeyFromObject(resource), observed))
+
+		phaseInfo, err := handler.GetTaskPhase(ctx, nil, observed)
+		assert.NoError(t, err)
+		return phaseInfo
+	}
+
+	assert.Equal(t, pluginsCore.PhaseQueued, getTaskPhase(nil).Phase())
+	assert.Equal(t, pluginsCore.PhaseQueued, getTaskPhase(map[string]interface{}{"jobStatus": "PENDING"}).Phase())
+
+	running := getTaskPhase(map[string]interface{}{"jobStatus": "RUNNING"})
+	assert.Equal(t, pluginsCore.PhaseRunning, running.Phase())
+	assert.Len(t, running.Info().Logs, 1)
+	assert.Equal(t, "k8s.com/#!/log/test-namespace/some-acceptable-name-head-0/pod?namespace=test-namespace",
+		running.Info().Logs[0].Uri)
+	assert.Equal(t, "RUNNING", running.Info().CustomInfo.GetFields()["jobStatus"].GetStringValue())
+
+	assert.Equal(t, pluginsCore.PhaseSuccess, getTaskPhase(map[string]interface{}{"jobStatus": "SUCCEEDED"}).Phase())
+
+	failed := getTaskPhase(map[string]interface{}{"jobStatus": "FAILED", "message": "out of memory"})
+	assert.Equal(t, pluginsCore.PhasePermanentFailure, failed.Phase())
+	assert.Equal(t, "out of memory", failed.Err().GetMessage())
+
+	assert.Equal(t, pluginsCore.PhaseRetryableFailure, getTaskPhase(map[string]interface{}{"jobStatus": "STOPPED"}).Phase())
+}
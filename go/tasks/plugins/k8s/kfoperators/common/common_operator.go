@@ -11,8 +11,11 @@ import (
 	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	commonOp "github.com/kubeflow/tf-operator/pkg/apis/common/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -55,6 +58,13 @@ func GetPhaseInfo(currentCondition commonOp.JobCondition, occurredAt time.Time,
 	return pluginsCore.PhaseInfoUndefined, nil
 }
 
+// GetAbortBehavior returns how the jobs of the kubeflow operators are aborted. Jobs are deleted in the foreground, so the
+// job object is only removed once the garbage collector has deleted its replicas. The delete call doesn't wait for it
+// though, the replicas may still be running when the task is reported aborted.
+func GetAbortBehavior() k8s.AbortBehavior {
+	return k8s.AbortBehaviorDelete(client.PropagationPolicy(metav1.DeletePropagationForeground))
+}
+
 func GetLogs(taskType string, name string, namespace string,
 	workersCount int32, psReplicasCount int32, chiefReplicasCount int32) ([]*core.TaskLog, error) {
 	taskLogs := make([]*core.TaskLog, 0, 10)
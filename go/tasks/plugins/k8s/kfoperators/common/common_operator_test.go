@@ -5,9 +5,12 @@ import (
 	"time"
 
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 	commonOp "github.com/kubeflow/tf-operator/pkg/apis/common/v1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestExtractCurrentCondition(t *testing.T) {
@@ -65,3 +68,12 @@ func TestGetPhaseInfo(t *testing.T) {
 	assert.NotNil(t, taskPhase.Info())
 	assert.Nil(t, err)
 }
+
+func TestGetAbortBehavior(t *testing.T) {
+	behavior := GetAbortBehavior()
+	assert.Equal(t, k8s.AbortBehaviorKindDelete, behavior.Kind)
+
+	opts := &client.DeleteOptions{}
+	opts.ApplyOptions(behavior.DeleteOptions)
+	assert.Equal(t, metav1.DeletePropagationForeground, *opts.PropagationPolicy)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/k8s/kfoperators/common"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
 	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
@@ -30,6 +31,26 @@ type pytorchOperatorResourceHandler struct {
 
 // Sanity test that the plugin implements method of k8s.Plugin
 var _ k8s.Plugin = pytorchOperatorResourceHandler{}
+var _ k8s.PluginAbortOverride = pytorchOperatorResourceHandler{}
+
+func getPyTorchTask(taskTemplate *core.TaskTemplate) (*plugins.DistributedPyTorchTrainingTask, error) {
+	taskExtraArgs := plugins.DistributedPyTorchTrainingTask{}
+	err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &taskExtraArgs)
+	if err != nil {
+		return nil, flyteerr.Errorf(flyteerr.BadTaskSpecification, "invalid TaskSpecification [%v], Err: [%v]", taskTemplate.GetCustom(), err.Error())
+	}
+
+	return &taskExtraArgs, nil
+}
+
+// ValidateTask checks that the task template holds a valid DistributedPyTorchTrainingTask and defines the container of the replicas.
+func (pytorchOperatorResourceHandler) ValidateTask(_ context.Context, taskTemplate *core.TaskTemplate) error {
+	if _, err := getPyTorchTask(taskTemplate); err != nil {
+		return err
+	}
+
+	return flytek8s.ValidateContainerTask(taskTemplate)
+}
 
 func (pytorchOperatorResourceHandler) GetProperties() k8s.PluginProperties {
 	return k8s.PluginProperties{}
@@ -56,10 +77,9 @@ func (pytorchOperatorResourceHandler) BuildResource(ctx context.Context, taskCtx
 		return nil, flyteerr.Errorf(flyteerr.BadTaskSpecification, "nil task specification")
 	}
 
-	pytorchTaskExtraArgs := plugins.DistributedPyTorchTrainingTask{}
-	err = utils.UnmarshalStruct(taskTemplate.GetCustom(), &pytorchTaskExtraArgs)
+	pytorchTaskExtraArgs, err := getPyTorchTask(taskTemplate)
 	if err != nil {
-		return nil, flyteerr.Errorf(flyteerr.BadTaskSpecification, "invalid TaskSpecification [%v], Err: [%v]", taskTemplate.GetCustom(), err.Error())
+		return nil, err
 	}
 
 	podSpec, err := flytek8s.ToK8sPodSpec(ctx, taskCtx)
@@ -101,6 +121,11 @@ func (pytorchOperatorResourceHandler) BuildResource(ctx context.Context, taskCtx
 	return job, nil
 }
 
+// OnAbort deletes the job along with its replicas.
+func (pytorchOperatorResourceHandler) OnAbort(_ context.Context, _ pluginsCore.TaskExecutionContext, _ client.Object) (k8s.AbortBehavior, error) {
+	return common.GetAbortBehavior(), nil
+}
+
 // Analyses the k8s resource and reports the status as TaskPhase. This call is expected to be relatively fast,
 // any operations that might take a long time (limits are configured system-wide) should be offloaded to the
 // background.
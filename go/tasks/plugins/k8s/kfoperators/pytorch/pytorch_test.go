@@ -28,9 +28,12 @@ import (
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
 	"github.com/golang/protobuf/jsonpb"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/stretchr/testify/assert"
+
+	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	ptOp "github.com/kubeflow/pytorch-operator/pkg/apis/pytorch/v1"
@@ -357,8 +360,41 @@ func TestGetLogs(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("k8s.com/#!/log/%s/%s-worker-1/pod?namespace=pytorch-namespace", jobNamespace, jobName), jobLogs[2].Uri)
 }
 
+func TestOnAbort(t *testing.T) {
+	pytorchResourceHandler := pytorchOperatorResourceHandler{}
+	behavior, err := pytorchResourceHandler.OnAbort(context.TODO(), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, common.GetAbortBehavior().Kind, behavior.Kind)
+	assert.Len(t, behavior.DeleteOptions, 1)
+}
+
 func TestGetProperties(t *testing.T) {
 	pytorchResourceHandler := pytorchOperatorResourceHandler{}
 	expected := k8s.PluginProperties{}
 	assert.Equal(t, expected, pytorchResourceHandler.GetProperties())
 }
+
+func TestValidateTaskPytorch(t *testing.T) {
+	handler := pytorchOperatorResourceHandler{}
+	ptObj := dummyPytorchCustomObj(100)
+	assert.NoError(t, handler.ValidateTask(context.TODO(), dummySparkTaskTemplate("the job", ptObj)))
+
+	t.Run("Invalid custom", func(t *testing.T) {
+		taskTemplate := dummySparkTaskTemplate("the job", ptObj)
+		taskTemplate.Custom = &structpb.Struct{Fields: map[string]*structpb.Value{
+			"workers": {Kind: &structpb.Value_StringValue{StringValue: "many"}},
+		}}
+
+		err := handler.ValidateTask(context.TODO(), taskTemplate)
+		assert.Error(t, err)
+		code, found := stdErrors.GetErrorCode(err)
+		assert.True(t, found)
+		assert.Equal(t, flyteerr.BadTaskSpecification, code)
+	})
+
+	t.Run("Missing container", func(t *testing.T) {
+		taskTemplate := dummySparkTaskTemplate("the job", ptObj)
+		taskTemplate.Target = nil
+		assert.Error(t, handler.ValidateTask(context.TODO(), taskTemplate))
+	})
+}
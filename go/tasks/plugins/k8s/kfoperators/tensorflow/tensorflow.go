@@ -8,6 +8,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/k8s/kfoperators/common"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
 	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
@@ -30,6 +31,26 @@ type tensorflowOperatorResourceHandler struct {
 
 // Sanity test that the plugin implements method of k8s.Plugin
 var _ k8s.Plugin = tensorflowOperatorResourceHandler{}
+var _ k8s.PluginAbortOverride = tensorflowOperatorResourceHandler{}
+
+func getTensorflowTask(taskTemplate *core.TaskTemplate) (*plugins.DistributedTensorflowTrainingTask, error) {
+	taskExtraArgs := plugins.DistributedTensorflowTrainingTask{}
+	err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &taskExtraArgs)
+	if err != nil {
+		return nil, flyteerr.Errorf(flyteerr.BadTaskSpecification, "invalid TaskSpecification [%v], Err: [%v]", taskTemplate.GetCustom(), err.Error())
+	}
+
+	return &taskExtraArgs, nil
+}
+
+// ValidateTask checks that the task template holds a valid DistributedTensorflowTrainingTask and defines the container of the replicas.
+func (tensorflowOperatorResourceHandler) ValidateTask(_ context.Context, taskTemplate *core.TaskTemplate) error {
+	if _, err := getTensorflowTask(taskTemplate); err != nil {
+		return err
+	}
+
+	return flytek8s.ValidateContainerTask(taskTemplate)
+}
 
 func (tensorflowOperatorResourceHandler) GetProperties() k8s.PluginProperties {
 	return k8s.PluginProperties{}
@@ -56,10 +77,9 @@ func (tensorflowOperatorResourceHandler) BuildResource(ctx context.Context, task
 		return nil, flyteerr.Errorf(flyteerr.BadTaskSpecification, "nil task specification")
 	}
 
-	tensorflowTaskExtraArgs := plugins.DistributedTensorflowTrainingTask{}
-	err = utils.UnmarshalStruct(taskTemplate.GetCustom(), &tensorflowTaskExtraArgs)
+	tensorflowTaskExtraArgs, err := getTensorflowTask(taskTemplate)
 	if err != nil {
-		return nil, flyteerr.Errorf(flyteerr.BadTaskSpecification, "invalid TaskSpecification [%v], Err: [%v]", taskTemplate.GetCustom(), err.Error())
+		return nil, err
 	}
 
 	podSpec, err := flytek8s.ToK8sPodSpec(ctx, taskCtx)
@@ -111,6 +131,11 @@ func (tensorflowOperatorResourceHandler) BuildResource(ctx context.Context, task
 	return job, nil
 }
 
+// OnAbort deletes the job along with its replicas.
+func (tensorflowOperatorResourceHandler) OnAbort(_ context.Context, _ pluginsCore.TaskExecutionContext, _ client.Object) (k8s.AbortBehavior, error) {
+	return common.GetAbortBehavior(), nil
+}
+
 // Analyses the k8s resource and reports the status as TaskPhase. This call is expected to be relatively fast,
 // any operations that might take a long time (limits are configured system-wide) should be offloaded to the
 // background.
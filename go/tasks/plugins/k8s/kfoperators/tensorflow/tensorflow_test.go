@@ -28,9 +28,12 @@ import (
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
 	"github.com/golang/protobuf/jsonpb"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/stretchr/testify/assert"
+
+	flyteerr "github.com/flyteorg/flyteplugins/go/tasks/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	tfOp "github.com/kubeflow/tf-operator/pkg/apis/tensorflow/v1"
@@ -366,8 +369,41 @@ func TestGetLogs(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("k8s.com/#!/log/%s/%s-chiefReplica-0/pod?namespace=tensorflow-namespace", jobNamespace, jobName), jobLogs[3].Uri)
 }
 
+func TestOnAbort(t *testing.T) {
+	tensorflowResourceHandler := tensorflowOperatorResourceHandler{}
+	behavior, err := tensorflowResourceHandler.OnAbort(context.TODO(), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, common.GetAbortBehavior().Kind, behavior.Kind)
+	assert.Len(t, behavior.DeleteOptions, 1)
+}
+
 func TestGetProperties(t *testing.T) {
 	tensorflowResourceHandler := tensorflowOperatorResourceHandler{}
 	expected := k8s.PluginProperties{}
 	assert.Equal(t, expected, tensorflowResourceHandler.GetProperties())
 }
+
+func TestValidateTaskTensorFlow(t *testing.T) {
+	handler := tensorflowOperatorResourceHandler{}
+	tfObj := dummyTensorFlowCustomObj(100, 50, 1)
+	assert.NoError(t, handler.ValidateTask(context.TODO(), dummySparkTaskTemplate("the job", tfObj)))
+
+	t.Run("Invalid custom", func(t *testing.T) {
+		taskTemplate := dummySparkTaskTemplate("the job", tfObj)
+		taskTemplate.Custom = &structpb.Struct{Fields: map[string]*structpb.Value{
+			"workers": {Kind: &structpb.Value_StringValue{StringValue: "many"}},
+		}}
+
+		err := handler.ValidateTask(context.TODO(), taskTemplate)
+		assert.Error(t, err)
+		code, found := stdErrors.GetErrorCode(err)
+		assert.True(t, found)
+		assert.Equal(t, flyteerr.BadTaskSpecification, code)
+	})
+
+	t.Run("Missing container", func(t *testing.T) {
+		taskTemplate := dummySparkTaskTemplate("the job", tfObj)
+		taskTemplate.Target = nil
+		assert.Error(t, handler.ValidateTask(context.TODO(), taskTemplate))
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	k8sv1 "k8s.io/api/core/v1"
@@ -71,7 +72,18 @@ func validateAndFinalizePod(
 
 	}
 	pod.Spec.Containers = finalizedContainers
-	flytek8s.UpdatePod(taskCtx.TaskExecutionMetadata(), resReqs, &pod.Spec)
+	if err := flytek8s.UpdatePod(ctx, pluginsCore.GetKubeReader(taskCtx), taskCtx.TaskExecutionMetadata(), resReqs,
+		&pod.Spec); err != nil {
+		return nil, err
+	}
+
+	podSpec, err := flytek8s.ApplyBasePodTemplate(ctx, taskCtx.TaskExecutionMetadata().GetNamespace(),
+		primaryContainerName, &pod.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	pod.Spec = *podSpec
 	return &pod, nil
 }
 
@@ -87,46 +99,75 @@ func (sidecarResourceHandler) GetProperties() k8s.PluginProperties {
 	return k8s.PluginProperties{}
 }
 
-func (sidecarResourceHandler) BuildResource(ctx context.Context, taskCtx pluginsCore.TaskExecutionContext) (client.Object, error) {
-	var podSpec k8sv1.PodSpec
-	var primaryContainerName string
-
-	task, err := taskCtx.TaskReader().Read(ctx)
-	if err != nil {
-		return nil, errors.Errorf(errors.BadTaskSpecification,
-			"TaskSpecification cannot be read, Err: [%v]", err.Error())
-	}
+// getPodSpec extracts the pod spec and the name of its primary container from the task template. The version 0 of the
+// task type holds both in the custom field, later versions hold the pod spec in the custom field and the name of the
+// primary container in the config.
+func getPodSpec(task *core.TaskTemplate) (podSpec k8sv1.PodSpec, primaryContainerName string, err error) {
 	if task.TaskTypeVersion == 0 {
 		sidecarJob := sidecarJob{}
 		err := utils.UnmarshalStructToObj(task.GetCustom(), &sidecarJob)
 		if err != nil {
-			return nil, errors.Errorf(errors.BadTaskSpecification,
+			return podSpec, "", errors.Errorf(errors.BadTaskSpecification,
 				"invalid TaskSpecification [%v], Err: [%v]", task.GetCustom(), err.Error())
 		}
 		if sidecarJob.PodSpec == nil {
-			return nil, errors.Errorf(errors.BadTaskSpecification,
+			return podSpec, "", errors.Errorf(errors.BadTaskSpecification,
 				"invalid TaskSpecification, nil PodSpec [%v]", task.GetCustom())
 		}
-		podSpec = *sidecarJob.PodSpec
-		primaryContainerName = sidecarJob.PrimaryContainerName
-	} else {
-		err := utils.UnmarshalStructToObj(task.GetCustom(), &podSpec)
-		if err != nil {
-			return nil, errors.Errorf(errors.BadTaskSpecification,
-				"Unable to unmarshal task custom [%v], Err: [%v]", task.GetCustom(), err.Error())
-		}
-		if len(task.GetConfig()) == 0 {
-			return nil, errors.Errorf(errors.BadTaskSpecification,
-				"invalid TaskSpecification, config needs to be non-empty and include missing [%s] key", primaryContainerKey)
-		}
-		var ok bool
-		primaryContainerName, ok = task.GetConfig()[primaryContainerKey]
-		if !ok {
-			return nil, errors.Errorf(errors.BadTaskSpecification,
-				"invalid TaskSpecification, config missing [%s] key in [%v]", primaryContainerKey, task.GetConfig())
+		return *sidecarJob.PodSpec, sidecarJob.PrimaryContainerName, nil
+	}
+
+	err = utils.UnmarshalStructToObj(task.GetCustom(), &podSpec)
+	if err != nil {
+		return podSpec, "", errors.Errorf(errors.BadTaskSpecification,
+			"Unable to unmarshal task custom [%v], Err: [%v]", task.GetCustom(), err.Error())
+	}
+	if len(task.GetConfig()) == 0 {
+		return podSpec, "", errors.Errorf(errors.BadTaskSpecification,
+			"invalid TaskSpecification, config needs to be non-empty and include missing [%s] key", primaryContainerKey)
+	}
+	primaryContainerName, ok := task.GetConfig()[primaryContainerKey]
+	if !ok {
+		return podSpec, "", errors.Errorf(errors.BadTaskSpecification,
+			"invalid TaskSpecification, config missing [%s] key in [%v]", primaryContainerKey, task.GetConfig())
+	}
+
+	return podSpec, primaryContainerName, nil
+}
+
+// ValidateTask checks that the task template holds a pod spec that defines its primary container.
+func (sidecarResourceHandler) ValidateTask(_ context.Context, taskTemplate *core.TaskTemplate) error {
+	if err := pluginsCore.ValidateTaskTypeVersion(taskTemplate, 0, 1); err != nil {
+		return err
+	}
+
+	podSpec, primaryContainerName, err := getPodSpec(taskTemplate)
+	if err != nil {
+		return err
+	}
+
+	for _, container := range podSpec.Containers {
+		if container.Name == primaryContainerName {
+			return nil
 		}
 	}
 
+	return errors.Errorf(errors.BadTaskSpecification,
+		"invalid Sidecar task, primary container [%s] not defined", primaryContainerName)
+}
+
+func (sidecarResourceHandler) BuildResource(ctx context.Context, taskCtx pluginsCore.TaskExecutionContext) (client.Object, error) {
+	task, err := taskCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return nil, errors.Errorf(errors.BadTaskSpecification,
+			"TaskSpecification cannot be read, Err: [%v]", err.Error())
+	}
+
+	podSpec, primaryContainerName, err := getPodSpec(task)
+	if err != nil {
+		return nil, err
+	}
+
 	pod := flytek8s.BuildPodWithSpec(&podSpec)
 	// Set the restart policy to *not* inherit from the default so that a completed pod doesn't get caught in a
 	// CrashLoopBackoff after the initial job completion.
@@ -188,6 +229,7 @@ func (sidecarResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8
 			return pluginsCore.PhaseInfoUndefined, err
 		}
 		info.Logs = taskLogs
+		info.ResourceUsage = flytek8s.PodResourceUsage(pod)
 	}
 	switch pod.Status.Phase {
 	case k8sv1.PodSucceeded:
@@ -196,7 +238,7 @@ func (sidecarResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8
 		code, message := flytek8s.ConvertPodFailureToError(pod.Status)
 		return pluginsCore.PhaseInfoRetryableFailure(code, message, &info), nil
 	case k8sv1.PodPending:
-		return flytek8s.DemystifyPending(pod.Status)
+		return flytek8s.DemystifyPendingPod(ctx, pluginContext, pod)
 	case k8sv1.PodReasonUnschedulable:
 		return pluginsCore.PhaseInfoQueued(transitionOccurredAt, pluginsCore.DefaultPhaseVersion, "pod unschedulable"), nil
 	case k8sv1.PodUnknown:
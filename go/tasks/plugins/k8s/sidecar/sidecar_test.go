@@ -24,9 +24,11 @@ import (
 
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	pluginsCoreMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	pluginsIOMock "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
+	k8sMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s/mocks"
 )
 
 const ResourceNvidiaGPU = "nvidia.com/gpu"
@@ -338,6 +340,147 @@ func TestBuildSidecarResource(t *testing.T) {
 	}
 }
 
+// secretsTaskContext overrides the security context of the task.
+type secretsTaskContext struct {
+	pluginsCore.TaskExecutionContext
+	secrets []*core.Secret
+}
+
+func (s secretsTaskContext) TaskExecutionMetadata() pluginsCore.TaskExecutionMetadata {
+	return secretsTaskMetadata{TaskExecutionMetadata: s.TaskExecutionContext.TaskExecutionMetadata(), secrets: s.secrets}
+}
+
+type secretsTaskMetadata struct {
+	pluginsCore.TaskExecutionMetadata
+	secrets []*core.Secret
+}
+
+func (s secretsTaskMetadata) GetSecurityContext() core.SecurityContext {
+	return core.SecurityContext{Secrets: s.secrets}
+}
+
+func TestBuildSidecarResource_Secrets(t *testing.T) {
+	previous := *config.GetK8sPluginConfig()
+	defer func() { assert.NoError(t, config.SetK8sPluginConfig(&previous)) }()
+
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		DefaultCPURequest:    "1024m",
+		DefaultMemoryRequest: "1024Mi",
+		SecretInjection: config.SecretInjectionConfig{
+			Enabled:          true,
+			DefaultMountType: config.SecretMountTypeFile,
+			MountPath:        "/etc/flyte/secrets",
+		},
+	}))
+
+	task := getSidecarTaskTemplateForTest(sidecarJob{
+		PrimaryContainerName: "primary",
+		PodSpec: &v1.PodSpec{
+			Containers: []v1.Container{{Name: "primary"}, {Name: "secondary"}},
+		},
+	})
+
+	handler := &sidecarResourceHandler{}
+	res, err := handler.BuildResource(context.TODO(), secretsTaskContext{
+		TaskExecutionContext: getDummySidecarTaskContext(task, resourceRequirements),
+		secrets:              []*core.Secret{{Group: "aws", Key: "credentials"}},
+	})
+	assert.NoError(t, err)
+
+	pod := res.(*v1.Pod)
+	assert.Len(t, pod.Spec.Volumes, 1)
+	assert.Equal(t, "aws", pod.Spec.Volumes[0].Secret.SecretName)
+	for _, c := range pod.Spec.Containers {
+		assert.Equal(t, []v1.VolumeMount{{
+			Name:      pod.Spec.Volumes[0].Name,
+			ReadOnly:  true,
+			MountPath: "/etc/flyte/secrets/aws",
+		}}, c.VolumeMounts)
+	}
+
+	_, err = handler.BuildResource(context.TODO(), secretsTaskContext{
+		TaskExecutionContext: getDummySidecarTaskContext(task, resourceRequirements),
+		secrets:              []*core.Secret{{Group: "aws"}},
+	})
+	assert.True(t, errors.Is(err, errors2.Errorf("BadTaskSpecification", "")))
+}
+
+func TestBuildSidecarResource_BasePodTemplate(t *testing.T) {
+	previous := *config.GetK8sPluginConfig()
+	defer func() { assert.NoError(t, config.SetK8sPluginConfig(&previous)) }()
+
+	assert.NoError(t, config.SetK8sPluginConfig(&config.K8sPluginConfig{
+		DefaultCPURequest:    "1024m",
+		DefaultMemoryRequest: "1024Mi",
+		DefaultPodTemplate: &v1.PodTemplate{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:         flytek8s.DefaultPodTemplateContainerName,
+							VolumeMounts: []v1.VolumeMount{{Name: "cache", MountPath: "/cache"}},
+						},
+					},
+					Volumes:           []v1.Volume{{Name: "cache"}},
+					PriorityClassName: "flyte-tasks",
+				},
+			},
+		},
+	}))
+
+	task := getSidecarTaskTemplateForTest(sidecarJob{
+		PrimaryContainerName: "primary",
+		PodSpec: &v1.PodSpec{
+			Containers: []v1.Container{{Name: "primary", Image: "primary-image"}, {Name: "secondary"}},
+		},
+	})
+
+	handler := &sidecarResourceHandler{}
+	res, err := handler.BuildResource(context.TODO(), getDummySidecarTaskContext(task, resourceRequirements))
+	assert.NoError(t, err)
+
+	pod := res.(*v1.Pod)
+	assert.Equal(t, "flyte-tasks", pod.Spec.PriorityClassName)
+	assert.Equal(t, []v1.Volume{{Name: "cache"}}, pod.Spec.Volumes)
+	assert.Len(t, pod.Spec.Containers, 2)
+	assert.Equal(t, "primary", pod.Spec.Containers[0].Name)
+	assert.Equal(t, "primary-image", pod.Spec.Containers[0].Image)
+	assert.Equal(t, []v1.VolumeMount{{Name: "cache", MountPath: "/cache"}}, pod.Spec.Containers[0].VolumeMounts)
+	// Only the primary container is merged onto the default container of the template.
+	assert.Equal(t, "secondary", pod.Spec.Containers[1].Name)
+	assert.Empty(t, pod.Spec.Containers[1].VolumeMounts)
+}
+
+func TestValidateTask(t *testing.T) {
+	ctx := context.TODO()
+	handler := sidecarResourceHandler{}
+	task := getSidecarTaskTemplateForTest(sidecarJob{
+		PrimaryContainerName: "primary",
+		PodSpec: &v1.PodSpec{
+			Containers: []v1.Container{{Name: "primary"}, {Name: "secondary"}},
+		},
+	})
+	assert.NoError(t, k8s.ValidateTask(ctx, handler, task))
+
+	t.Run("Missing primary container", func(t *testing.T) {
+		task := getSidecarTaskTemplateForTest(sidecarJob{
+			PrimaryContainerName: "PrimaryContainer",
+			PodSpec:              &v1.PodSpec{Containers: []v1.Container{{Name: "secondary"}}},
+		})
+		assert.True(t, errors.Is(k8s.ValidateTask(ctx, handler, task), errors2.Errorf("BadTaskSpecification", "")))
+	})
+
+	t.Run("Missing primary container key", func(t *testing.T) {
+		task := &core.TaskTemplate{Custom: task.Custom, TaskTypeVersion: 1}
+		assert.True(t, errors.Is(k8s.ValidateTask(ctx, handler, task), errors2.Errorf("BadTaskSpecification", "")))
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		task := &core.TaskTemplate{Custom: task.Custom, TaskTypeVersion: 2}
+		assert.True(t, errors.Is(k8s.ValidateTask(ctx, handler, task), errors2.Errorf("BadTaskSpecification", "")))
+	})
+}
+
 func TestBuildSidecarResourceMissingPrimary(t *testing.T) {
 	sideCarJob := sidecarJob{
 		PrimaryContainerName: "PrimaryContainer",
@@ -397,6 +540,37 @@ func TestGetTaskSidecarStatus(t *testing.T) {
 	}
 }
 
+type pluginContextWithEvents struct {
+	*k8sMocks.PluginContext
+	*k8sMocks.EventsReader
+}
+
+func TestDemystifiedSidecarStatus_PendingWithEvents(t *testing.T) {
+	res := &v1.Pod{
+		Status: v1.PodStatus{
+			Phase: v1.PodPending,
+		},
+	}
+	res.SetAnnotations(map[string]string{
+		primaryContainerKey: "PrimaryContainer",
+	})
+
+	events := &k8sMocks.EventsReader{}
+	events.OnRecentEvents(context.TODO(), res).Return([]v1.Event{
+		{
+			Type:    v1.EventTypeWarning,
+			Reason:  "FailedScheduling",
+			Message: "0/3 nodes are available: 3 Insufficient memory.",
+		},
+	}, nil)
+
+	handler := &sidecarResourceHandler{}
+	phaseInfo, err := handler.GetTaskPhase(context.TODO(), pluginContextWithEvents{&k8sMocks.PluginContext{}, events}, res)
+	assert.Nil(t, err)
+	assert.Equal(t, pluginsCore.PhaseQueued, phaseInfo.Phase())
+	assert.Contains(t, phaseInfo.Reason(), "FailedScheduling: 0/3 nodes are available: 3 Insufficient memory.")
+}
+
 func TestDemystifiedSidecarStatus_PrimaryFailed(t *testing.T) {
 	res := &v1.Pod{
 		Status: v1.PodStatus{
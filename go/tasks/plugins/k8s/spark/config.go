@@ -26,6 +26,7 @@ type Config struct {
 	SparkHistoryServerURL string            `json:"spark-history-server-url" pflag:",URL for SparkHistory Server that each job will publish the execution history to."`
 	Features              []Feature         `json:"features" pflag:"-,List of optional features supported."`
 	LogConfig             LogConfig         `json:"logs" pflag:",Config for log links for spark applications."`
+	RetainAborted         bool              `json:"retain-aborted" pflag:",If set, aborted spark applications are stopped by deleting their driver and kept, e.g. to debug them, until their workflow is deleted."`
 }
 
 type LogConfig struct {
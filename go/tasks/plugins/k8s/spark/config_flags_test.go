@@ -1089,4 +1089,26 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_retain-aborted", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("retain-aborted"); err == nil {
+				assert.Equal(t, bool(defaultConfig.RetainAborted), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("retain-aborted", testValue)
+			if vBool, err := cmdFlags.GetBool("retain-aborted"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.RetainAborted)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
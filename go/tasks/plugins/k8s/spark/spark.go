@@ -16,6 +16,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/logs"
 	pluginsCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
@@ -27,6 +28,7 @@ import (
 	sparkOp "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1beta2"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"regexp"
@@ -42,6 +44,20 @@ var featureRegex = regexp.MustCompile(`^spark.((flyteorg)|(flyte)).(.+).enabled$
 
 var sparkTaskType = "spark"
 
+// Units of the memory amounts in the Spark config, amounts without unit are in MiB.
+var sparkMemoryUnits = map[string]int64{
+	"":   1 << 20,
+	"b":  1,
+	"k":  1 << 10,
+	"kb": 1 << 10,
+	"m":  1 << 20,
+	"mb": 1 << 20,
+	"g":  1 << 30,
+	"gb": 1 << 30,
+	"t":  1 << 40,
+	"tb": 1 << 40,
+}
+
 type sparkResourceHandler struct {
 }
 
@@ -57,6 +73,27 @@ func validateSparkJob(sparkJob *plugins.SparkJob) error {
 	return nil
 }
 
+// getSparkJob unmarshals and validates the SparkJob of the task template.
+func getSparkJob(taskTemplate *core.TaskTemplate) (*plugins.SparkJob, error) {
+	sparkJob := plugins.SparkJob{}
+	err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &sparkJob)
+	if err != nil {
+		return nil, errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v], failed to unmarshal", taskTemplate.GetCustom())
+	}
+
+	if err = validateSparkJob(&sparkJob); err != nil {
+		return nil, errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v].", taskTemplate.GetCustom())
+	}
+
+	return &sparkJob, nil
+}
+
+// ValidateTask checks that the task template holds a valid SparkJob.
+func (sparkResourceHandler) ValidateTask(_ context.Context, taskTemplate *core.TaskTemplate) error {
+	_, err := getSparkJob(taskTemplate)
+	return err
+}
+
 func (sparkResourceHandler) GetProperties() k8s.PluginProperties {
 	return k8s.PluginProperties{}
 }
@@ -70,14 +107,9 @@ func (sparkResourceHandler) BuildResource(ctx context.Context, taskCtx pluginsCo
 		return nil, errors.Errorf(errors.BadTaskSpecification, "nil task specification")
 	}
 
-	sparkJob := plugins.SparkJob{}
-	err = utils.UnmarshalStruct(taskTemplate.GetCustom(), &sparkJob)
+	sparkJob, err := getSparkJob(taskTemplate)
 	if err != nil {
-		return nil, errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v], failed to unmarshal", taskTemplate.GetCustom())
-	}
-
-	if err = validateSparkJob(&sparkJob); err != nil {
-		return nil, errors.Wrapf(errors.BadTaskSpecification, err, "invalid TaskSpecification [%v].", taskTemplate.GetCustom())
+		return nil, err
 	}
 
 	annotations := utils.UnionMaps(config.GetK8sPluginConfig().DefaultAnnotations, utils.CopyMap(taskCtx.TaskExecutionMetadata().GetAnnotations()))
@@ -399,6 +431,95 @@ func getEventInfoForSpark(sj *sparkOp.SparkApplication) (*pluginsCore.TaskInfo,
 	}, nil
 }
 
+// sparkMemoryBytes parses a memory amount of the Spark config (e.g. 4g), it returns 0 if the amount isn't valid.
+func sparkMemoryBytes(memory *string) int64 {
+	if memory == nil {
+		return 0
+	}
+
+	amount := strings.ToLower(strings.TrimSpace(*memory))
+	idx := strings.IndexFunc(amount, func(r rune) bool { return r < '0' || r > '9' })
+	if idx < 0 {
+		idx = len(amount)
+	}
+
+	value, err := strconv.ParseInt(amount[:idx], 10, 64)
+	unit, found := sparkMemoryUnits[amount[idx:]]
+	if err != nil || !found {
+		return 0
+	}
+
+	return value * unit
+}
+
+// getPodResources returns the cores, GPUs and bytes of memory of a driver or executor pod.
+func getPodResources(spec sparkOp.SparkPodSpec) (cores, gpus float64, memoryBytes int64) {
+	cores = 1
+	if spec.Cores != nil {
+		cores = float64(*spec.Cores)
+	}
+
+	if spec.GPU != nil {
+		gpus = float64(spec.GPU.Quantity)
+	}
+
+	return cores, gpus, sparkMemoryBytes(spec.Memory)
+}
+
+// getResourceUsage returns the resources reserved by the driver and the executors of the application since it was
+// submitted, with its cost estimated from the configured resource prices. Executors are counted from the status of the
+// application, or from its spec if none was reported.
+func getResourceUsage(app *sparkOp.SparkApplication) *pluginsCore.ResourceUsage {
+	if app.Status.SubmissionTime.IsZero() {
+		return nil
+	}
+
+	end := time.Now()
+	if !app.Status.TerminationTime.IsZero() {
+		end = app.Status.TerminationTime.Time
+	}
+
+	d := end.Sub(app.Status.SubmissionTime.Time)
+	executors := int64(len(app.Status.ExecutorState))
+	if executors == 0 && app.Spec.Executor.Instances != nil {
+		executors = int64(*app.Spec.Executor.Instances)
+	}
+
+	cores, gpus, memoryBytes := getPodResources(app.Spec.Driver.SparkPodSpec)
+	usage := pluginsCore.ResourceUsageOver(d, cores, gpus, memoryBytes)
+	cores, gpus, memoryBytes = getPodResources(app.Spec.Executor.SparkPodSpec)
+	executorsUsage := pluginsCore.ResourceUsageOver(d, cores*float64(executors), gpus*float64(executors),
+		memoryBytes*executors)
+
+	usage.AddConcurrent(executorsUsage)
+	usage = usage.WithEstimatedCost(pluginsConfig.GetConfig().ResourcePrices)
+	return &usage
+}
+
+// OnAbort deletes the application along with its driver and executors. If aborted applications are configured to be
+// retained, only the driver is deleted: the executors it owns are garbage collected and the operator marks the
+// application as failed, keeping its status until the workflow is deleted.
+func (sparkResourceHandler) OnAbort(_ context.Context, _ pluginsCore.TaskExecutionContext, resource client.Object) (k8s.AbortBehavior, error) {
+	app := resource.(*sparkOp.SparkApplication)
+	// Applications that haven't started a driver yet have nothing to debug.
+	if GetSparkConfig().RetainAborted && app.Status.DriverInfo.PodName != "" {
+		driver := &v1.Pod{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       flytek8s.PodKind,
+				APIVersion: v1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      app.Status.DriverInfo.PodName,
+				Namespace: app.Namespace,
+			},
+		}
+
+		return k8s.AbortBehaviorDeleteResource(driver, client.PropagationPolicy(metav1.DeletePropagationForeground)), nil
+	}
+
+	return k8s.AbortBehaviorDelete(client.PropagationPolicy(metav1.DeletePropagationForeground)), nil
+}
+
 func (sparkResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s.PluginContext, resource client.Object) (pluginsCore.PhaseInfo, error) {
 
 	app := resource.(*sparkOp.SparkApplication)
@@ -407,6 +528,8 @@ func (sparkResourceHandler) GetTaskPhase(ctx context.Context, pluginContext k8s.
 		return pluginsCore.PhaseInfoUndefined, err
 	}
 
+	info.ResourceUsage = getResourceUsage(app)
+
 	occurredAt := time.Now()
 	switch app.Status.AppState.State {
 	case sparkOp.NewState:
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/flytek8s/config"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/k8s"
@@ -472,8 +473,84 @@ func TestBuildResourceSpark(t *testing.T) {
 	assert.Nil(t, resource)
 }
 
+func TestValidateTaskSpark(t *testing.T) {
+	ctx := context.TODO()
+	handler := sparkResourceHandler{}
+	taskTemplate := dummySparkTaskTemplate("blah-1", dummySparkConf)
+	assert.NoError(t, k8s.ValidateTask(ctx, handler, taskTemplate))
+
+	// Neither a main application file nor a main class.
+	taskTemplate.Custom = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	assert.Error(t, k8s.ValidateTask(ctx, handler, taskTemplate))
+}
+
+func TestSparkMemoryBytes(t *testing.T) {
+	assert.Equal(t, int64(4<<30), sparkMemoryBytes(strPtr("4g")))
+	assert.Equal(t, int64(512<<20), sparkMemoryBytes(strPtr("512M")))
+	assert.Equal(t, int64(512<<20), sparkMemoryBytes(strPtr("512")))
+	assert.Equal(t, int64(0), sparkMemoryBytes(strPtr("lots")))
+	assert.Equal(t, int64(0), sparkMemoryBytes(nil))
+}
+
+func TestGetResourceUsage(t *testing.T) {
+	app := &sj.SparkApplication{
+		Spec: sj.SparkApplicationSpec{
+			Driver: sj.DriverSpec{SparkPodSpec: sj.SparkPodSpec{Cores: intPtr(2), Memory: strPtr("1g")}},
+			Executor: sj.ExecutorSpec{
+				SparkPodSpec: sj.SparkPodSpec{Memory: strPtr("2g"), GPU: &sj.GPUSpec{Quantity: 1}},
+				Instances:    intPtr(4),
+			},
+		},
+	}
+	assert.Nil(t, getResourceUsage(app))
+
+	submissionTime := time.Now().Add(-time.Hour)
+	app.Status.SubmissionTime = v1.NewTime(submissionTime)
+	app.Status.TerminationTime = v1.NewTime(submissionTime.Add(100 * time.Second))
+	usage := getResourceUsage(app)
+	assert.NotNil(t, usage)
+	// A driver with 2 cores and 4 executors with the default single core.
+	assert.Equal(t, float64(600), usage.CPUSeconds)
+	assert.Equal(t, float64(400), usage.GPUSeconds)
+	assert.Equal(t, int64(9<<30), usage.MemoryHighWaterMarkBytes)
+
+	// Executors that actually ran take precedence over the requested instances.
+	app.Status.ExecutorState = map[string]sj.ExecutorState{"exec-1": sj.ExecutorCompletedState}
+	assert.Equal(t, float64(300), getResourceUsage(app).CPUSeconds)
+}
+
 func TestGetPropertiesSpark(t *testing.T) {
 	sparkResourceHandler := sparkResourceHandler{}
 	expected := k8s.PluginProperties{}
 	assert.Equal(t, expected, sparkResourceHandler.GetProperties())
 }
+
+func TestOnAbortSpark(t *testing.T) {
+	previous := *GetSparkConfig()
+	defer func() {
+		assert.NoError(t, setSparkConfig(&previous))
+	}()
+
+	sparkResourceHandler := sparkResourceHandler{}
+	app := &sj.SparkApplication{ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "ns"}}
+	app.Status.DriverInfo.PodName = "app-driver"
+
+	assert.NoError(t, setSparkConfig(&Config{}))
+	behavior, err := sparkResourceHandler.OnAbort(context.TODO(), nil, app)
+	assert.NoError(t, err)
+	assert.Equal(t, k8s.AbortBehaviorKindDelete, behavior.Kind)
+	assert.Nil(t, behavior.Resource)
+
+	assert.NoError(t, setSparkConfig(&Config{RetainAborted: true}))
+	behavior, err = sparkResourceHandler.OnAbort(context.TODO(), nil, app)
+	assert.NoError(t, err)
+	assert.Equal(t, k8s.AbortBehaviorKindDelete, behavior.Kind)
+	assert.Equal(t, "app-driver", behavior.Resource.GetName())
+	assert.Equal(t, "ns", behavior.Resource.GetNamespace())
+
+	// Applications without a driver are deleted.
+	behavior, err = sparkResourceHandler.OnAbort(context.TODO(), nil, &sj.SparkApplication{})
+	assert.NoError(t, err)
+	assert.Equal(t, k8s.AbortBehaviorKindDelete, behavior.Kind)
+	assert.Nil(t, behavior.Resource)
+}
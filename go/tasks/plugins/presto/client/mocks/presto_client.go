@@ -15,6 +15,38 @@ type PrestoClient struct {
 	mock.Mock
 }
 
+type PrestoClient_CheckHealth struct {
+	*mock.Call
+}
+
+func (_m PrestoClient_CheckHealth) Return(_a0 error) *PrestoClient_CheckHealth {
+	return &PrestoClient_CheckHealth{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *PrestoClient) OnCheckHealth(ctx context.Context) *PrestoClient_CheckHealth {
+	c := _m.On("CheckHealth", ctx)
+	return &PrestoClient_CheckHealth{Call: c}
+}
+
+func (_m *PrestoClient) OnCheckHealthMatch(matchers ...interface{}) *PrestoClient_CheckHealth {
+	c := _m.On("CheckHealth", matchers...)
+	return &PrestoClient_CheckHealth{Call: c}
+}
+
+// CheckHealth provides a mock function with given fields: ctx
+func (_m *PrestoClient) CheckHealth(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type PrestoClient_ExecuteCommand struct {
 	*mock.Call
 }
@@ -7,11 +7,17 @@ import (
 
 	"time"
 
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/config"
 )
 
 const (
 	httpRequestTimeoutSecs = 30
+
+	// The coordinator endpoint that reports the server info, it answers as long as the coordinator is up.
+	infoPath = "/v1/info"
 )
 
 type noopPrestoClient struct {
@@ -35,6 +41,34 @@ func (p noopPrestoClient) GetCommandStatus(ctx context.Context, commandID string
 	return PrestoStatusUnknown, nil
 }
 
+// CheckHealth fails if the info endpoint of the Presto coordinator is unreachable or answers with a server error.
+func (p noopPrestoClient) CheckHealth(ctx context.Context) error {
+	infoURL := p.environment.ResolveReference(&url.URL{Path: infoPath})
+	req, err := http.NewRequest(http.MethodGet, infoURL.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrapf(errors.DownstreamSystemError, err, "Presto coordinator [%v] is unreachable",
+			infoURL.Host)
+	}
+
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			logger.Warnf(ctx, "Failed to close the response body. Error: %v", err)
+		}
+	}()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return errors.Errorf(errors.DownstreamSystemError, "Presto coordinator [%v] is unhealthy. Response code [%v]",
+			infoURL.Host, response.StatusCode)
+	}
+
+	return nil
+}
+
 func NewNoopPrestoClient(cfg *config.Config) PrestoClient {
 	return &noopPrestoClient{
 		client:      &http.Client{Timeout: httpRequestTimeoutSecs * time.Second},
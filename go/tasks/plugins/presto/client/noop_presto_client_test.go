@@ -0,0 +1,52 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/config"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newTestPrestoClient(statusCode int, err error) *noopPrestoClient {
+	c := NewNoopPrestoClient(&config.Config{Environment: config.URLMustParse("https://presto.example.com")}).(*noopPrestoClient)
+	c.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() != "https://presto.example.com/v1/info" {
+			return nil, fmt.Errorf("unexpected URL [%v]", req.URL)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+			Header:     make(http.Header),
+		}, nil
+	})}
+
+	return c
+}
+
+func TestNoopPrestoClient_CheckHealth(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, newTestPrestoClient(http.StatusOK, nil).CheckHealth(ctx))
+
+	// Client errors, e.g. a missing user header, still mean the coordinator is up.
+	assert.NoError(t, newTestPrestoClient(http.StatusUnauthorized, nil).CheckHealth(ctx))
+
+	assert.Error(t, newTestPrestoClient(http.StatusServiceUnavailable, nil).CheckHealth(ctx))
+	assert.Error(t, newTestPrestoClient(0, fmt.Errorf("connection refused")).CheckHealth(ctx))
+}
@@ -29,4 +29,7 @@ type PrestoClient interface {
 
 	// Gets the status of a Presto query
 	GetCommandStatus(ctx context.Context, commandID string) (PrestoStatus, error)
+
+	// Checks that the Presto coordinator is reachable and healthy
+	CheckHealth(ctx context.Context) error
 }
@@ -93,6 +93,7 @@ var (
 			Rate:  5,
 			Burst: 10,
 		},
+		ResourceLeaseTTL: config.Duration{Duration: time.Hour},
 	}
 
 	prestoConfigSection = pluginsConfig.MustRegisterSubSection(prestoConfigSectionKey, &defaultConfig)
@@ -108,6 +109,7 @@ type Config struct {
 	RefreshCacheConfig     RefreshCacheConfig   `json:"refreshCacheConfig" pflag:"Refresh cache config"`
 	ReadRateLimiterConfig  RateLimiterConfig    `json:"readRateLimiterConfig" pflag:"Rate limiter config for read requests going to Presto"`
 	WriteRateLimiterConfig RateLimiterConfig    `json:"writeRateLimiterConfig" pflag:"Rate limiter config for write requests going to Presto"`
+	ResourceLeaseTTL       config.Duration      `json:"resourceLeaseTTL" pflag:",How long allocation tokens are leased for if the resource manager supports leases. Leases are disabled if zero."`
 }
 
 // Retrieves the current config value or default.
@@ -53,5 +53,6 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "readRateLimiterConfig.burst"), defaultConfig.ReadRateLimiterConfig.Burst, "Allowed burst rate of calls per second.")
 	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "writeRateLimiterConfig.rate"), defaultConfig.WriteRateLimiterConfig.Rate, "Allowed rate of calls per second.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "writeRateLimiterConfig.burst"), defaultConfig.WriteRateLimiterConfig.Burst, "Allowed burst rate of calls per second.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "resourceLeaseTTL"), defaultConfig.ResourceLeaseTTL.String(), "How long allocation tokens are leased for if the resource manager supports leases. Leases are disabled if zero.")
 	return cmdFlags
 }
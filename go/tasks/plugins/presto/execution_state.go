@@ -28,8 +28,6 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flytestdlib/logger"
-
-	pb "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
 type ExecutionPhase int
@@ -106,6 +104,7 @@ func HandleExecutionState(
 	currentState ExecutionState,
 	prestoClient client.PrestoClient,
 	executionsCache cache.AutoRefresh,
+	cfg *config.Config,
 	metrics ExecutorMetrics) (ExecutionState, error) {
 
 	var transformError error
@@ -113,10 +112,11 @@ func HandleExecutionState(
 
 	switch currentState.CurrentPhase {
 	case PhaseNotStarted:
-		newState, transformError = GetAllocationToken(ctx, tCtx, currentState, metrics)
+		newState, transformError = GetAllocationToken(ctx, tCtx, currentState, cfg, metrics)
 
 	case PhaseQueued:
-		prestoQuery, err := GetNextQuery(ctx, tCtx, currentState)
+		RenewAllocationToken(ctx, tCtx, cfg, metrics)
+		prestoQuery, err := GetNextQuery(ctx, tCtx, currentState, cfg)
 		if err != nil {
 			return ExecutionState{}, err
 		}
@@ -124,6 +124,7 @@ func HandleExecutionState(
 		newState, transformError = KickOffQuery(ctx, tCtx, currentState, prestoClient, executionsCache)
 
 	case PhaseSubmitted:
+		RenewAllocationToken(ctx, tCtx, cfg, metrics)
 		newState, transformError = MonitorQuery(ctx, tCtx, currentState, executionsCache)
 
 	case PhaseQuerySucceeded:
@@ -153,19 +154,21 @@ func GetAllocationToken(
 	ctx context.Context,
 	tCtx core.TaskExecutionContext,
 	currentState ExecutionState,
+	cfg *config.Config,
 	metric ExecutorMetrics) (ExecutionState, error) {
 
 	newState := ExecutionState{}
 	uniqueID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
 
-	routingGroup, err := composeResourceNamespaceWithRoutingGroup(ctx, tCtx)
+	routingGroup, err := composeResourceNamespaceWithRoutingGroup(ctx, tCtx, cfg)
 	if err != nil {
 		return newState, errors.Wrapf(errors.ResourceManagerFailure, err, "Error getting query info when requesting allocation token %s", uniqueID)
 	}
 
-	resourceConstraintsSpec := createResourceConstraintsSpec(ctx, tCtx, routingGroup)
+	resourceConstraintsSpec := createResourceConstraintsSpec(ctx, tCtx, cfg, routingGroup)
 
-	allocationStatus, err := tCtx.ResourceManager().AllocateResource(ctx, routingGroup, uniqueID, resourceConstraintsSpec)
+	allocationStatus, err := core.AllocateLeasedResource(ctx, tCtx.ResourceManager(), routingGroup, uniqueID,
+		resourceConstraintsSpec, cfg.ResourceLeaseTTL.Duration)
 	if err != nil {
 		logger.Errorf(ctx, "Resource manager failed for TaskExecId [%s] token [%s]. error %s",
 			tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID(), uniqueID, err)
@@ -197,12 +200,33 @@ func GetAllocationToken(
 	return newState, nil
 }
 
-func composeResourceNamespaceWithRoutingGroup(ctx context.Context, tCtx core.TaskExecutionContext) (core.ResourceNamespace, error) {
+// RenewAllocationToken renews the lease of the allocation token of the task, if leases are enabled. Failures are
+// logged and counted but otherwise ignored; the lease is renewed again in the next round.
+func RenewAllocationToken(ctx context.Context, tCtx core.TaskExecutionContext, cfg *config.Config,
+	metric ExecutorMetrics) {
+	ttl := cfg.ResourceLeaseTTL.Duration
+	if _, ok := tCtx.ResourceManager().(core.LeasedResourceManager); !ok || ttl <= 0 {
+		return
+	}
+
+	uniqueID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
+	routingGroup, err := composeResourceNamespaceWithRoutingGroup(ctx, tCtx, cfg)
+	if err == nil {
+		err = core.RenewResourceLease(ctx, tCtx.ResourceManager(), routingGroup, uniqueID, ttl)
+	}
+
+	if err != nil {
+		metric.LeaseRenewFailed.Inc(ctx)
+		logger.Warnf(ctx, "Failed to renew the lease of allocation token [%s]. Error: %s", uniqueID, err)
+	}
+}
+
+func composeResourceNamespaceWithRoutingGroup(ctx context.Context, tCtx core.TaskExecutionContext, cfg *config.Config) (core.ResourceNamespace, error) {
 	routingGroup, _, _, _, err := GetQueryInfo(ctx, tCtx)
 	if err != nil {
 		return "", err
 	}
-	clusterPrimaryLabel := resolveRoutingGroup(ctx, routingGroup, config.GetPrestoConfig())
+	clusterPrimaryLabel := resolveRoutingGroup(ctx, routingGroup, cfg)
 	return core.ResourceNamespace(clusterPrimaryLabel), nil
 }
 
@@ -214,12 +238,8 @@ func GetQueryInfo(ctx context.Context, tCtx core.TaskExecutionContext) (string,
 		return "", "", "", "", err
 	}
 
-	prestoQuery := plugins.PrestoQuery{}
-	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &prestoQuery); err != nil {
-		return "", "", "", "", err
-	}
-
-	if err := validatePrestoStatement(prestoQuery); err != nil {
+	prestoQuery, err := getPrestoQuery(taskTemplate)
+	if err != nil {
 		return "", "", "", "", err
 	}
 
@@ -247,6 +267,21 @@ func GetQueryInfo(ctx context.Context, tCtx core.TaskExecutionContext) (string,
 	return routingGroup, catalog, schema, statement, err
 }
 
+// getPrestoQuery unmarshals and validates the PrestoQuery of the task template.
+func getPrestoQuery(taskTemplate *idlCore.TaskTemplate) (plugins.PrestoQuery, error) {
+	prestoQuery := plugins.PrestoQuery{}
+	if err := utils.UnmarshalStruct(taskTemplate.GetCustom(), &prestoQuery); err != nil {
+		return prestoQuery, errors.Wrapf(errors.BadTaskSpecification, err, "Invalid PrestoQuery [%v].",
+			taskTemplate.GetCustom())
+	}
+
+	if err := validatePrestoStatement(prestoQuery); err != nil {
+		return prestoQuery, err
+	}
+
+	return prestoQuery, nil
+}
+
 func validatePrestoStatement(prestoJob plugins.PrestoQuery) error {
 	if prestoJob.Statement == "" {
 		return errors.Errorf(errors.BadTaskSpecification,
@@ -273,8 +308,7 @@ func resolveRoutingGroup(ctx context.Context, routingGroup string, prestoCfg *co
 	return prestoCfg.DefaultRoutingGroup
 }
 
-func createResourceConstraintsSpec(ctx context.Context, _ core.TaskExecutionContext, routingGroup core.ResourceNamespace) core.ResourceConstraintsSpec {
-	cfg := config.GetPrestoConfig()
+func createResourceConstraintsSpec(ctx context.Context, _ core.TaskExecutionContext, cfg *config.Config, routingGroup core.ResourceNamespace) core.ResourceConstraintsSpec {
 	constraintsSpec := core.ResourceConstraintsSpec{
 		ProjectScopeResourceConstraint:   nil,
 		NamespaceScopeResourceConstraint: nil,
@@ -297,11 +331,11 @@ func createResourceConstraintsSpec(ctx context.Context, _ core.TaskExecutionCont
 func GetNextQuery(
 	ctx context.Context,
 	tCtx core.TaskExecutionContext,
-	currentState ExecutionState) (Query, error) {
+	currentState ExecutionState,
+	prestoCfg *config.Config) (Query, error) {
 
 	switch currentState.QueryCount {
 	case 0:
-		prestoCfg := config.GetPrestoConfig()
 		tempTableName := rand.String(32)
 		routingGroup, catalog, schema, statement, err := GetQueryInfo(ctx, tCtx)
 		if err != nil {
@@ -441,30 +475,13 @@ func MonitorQuery(
 	return cachedExecutionState.ExecutionState, nil
 }
 
+// writeOutput reports the results of the query, written by Presto as Parquet at externalLocation, as the "results"
+// output.
 func writeOutput(ctx context.Context, tCtx core.TaskExecutionContext, externalLocation string) error {
-	taskTemplate, err := tCtx.TaskReader().Read(ctx)
-	if err != nil {
-		return err
-	}
-
-	results := taskTemplate.Interface.Outputs.Variables["results"]
-
-	return tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(
-		&pb.LiteralMap{
-			Literals: map[string]*pb.Literal{
-				"results": {
-					Value: &pb.Literal_Scalar{
-						Scalar: &pb.Scalar{Value: &pb.Scalar_Schema{
-							Schema: &pb.Schema{
-								Uri:  externalLocation,
-								Type: results.GetType().GetSchema(),
-							},
-						},
-						},
-					},
-				},
-			},
-		}, nil))
+	return ioutils.WriteDatasetOutput(ctx, tCtx.TaskReader(), tCtx.OutputWriter(), "results", ioutils.Dataset{
+		Location: externalLocation,
+		Format:   ioutils.DatasetFormatParquet,
+	})
 }
 
 // The 'PhaseInfoRunning' occurs 15 times (3 for each of the 5 Presto queries that get run for every Presto task) which
@@ -539,10 +556,10 @@ func Abort(ctx context.Context, currentState ExecutionState, client client.Prest
 	return nil
 }
 
-func Finalize(ctx context.Context, tCtx core.TaskExecutionContext, _ ExecutionState, metrics ExecutorMetrics) error {
+func Finalize(ctx context.Context, tCtx core.TaskExecutionContext, _ ExecutionState, cfg *config.Config, metrics ExecutorMetrics) error {
 	// Release allocation token
 	uniqueID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
-	routingGroup, err := composeResourceNamespaceWithRoutingGroup(ctx, tCtx)
+	routingGroup, err := composeResourceNamespaceWithRoutingGroup(ctx, tCtx, cfg)
 	if err != nil {
 		return errors.Wrapf(errors.ResourceManagerFailure, err, "Error getting query info when releasing allocation token %s", uniqueID)
 	}
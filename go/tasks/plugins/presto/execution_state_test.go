@@ -6,17 +6,21 @@ import (
 	"testing"
 	"time"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/client"
 	prestoMocks "github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/client/mocks"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/config"
 	mocks2 "github.com/flyteorg/flytestdlib/cache/mocks"
 	stdConfig "github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/contextutils"
+	stdErrors "github.com/flyteorg/flytestdlib/errors"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -81,6 +85,22 @@ func TestValidatePrestoStatement(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestExecutor_ValidateTask(t *testing.T) {
+	ctx := context.Background()
+	taskTemplate := GetPrestoQueryTaskTemplate()
+	assert.NoError(t, core.ValidateTask(ctx, Executor{}, &taskTemplate))
+
+	prestoQuery := plugins.PrestoQuery{RoutingGroup: "adhoc"}
+	stObj := &structpb.Struct{}
+	assert.NoError(t, utils.MarshalStruct(&prestoQuery, stObj))
+	taskTemplate.Custom = stObj
+	err := core.ValidateTask(ctx, Executor{}, &taskTemplate)
+	assert.Error(t, err)
+	code, found := stdErrors.GetErrorCode(err)
+	assert.True(t, found)
+	assert.Equal(t, errors.BadTaskSpecification, code)
+}
+
 func TestConstructTaskLog(t *testing.T) {
 	expected := "https://prestoproxy-internal.flyteorg.net:443"
 	u, err := url.Parse(expected)
@@ -157,7 +177,7 @@ func TestGetAllocationToken(t *testing.T) {
 
 		mockCurrentState := ExecutionState{AllocationTokenRequestStartTime: time.Now()}
 		mockMetrics := getPrestoExecutorMetrics(promutils.NewTestScope())
-		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, mockMetrics)
+		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, config.GetPrestoConfig(), mockMetrics)
 		assert.NoError(t, err)
 		assert.Equal(t, PhaseQueued, state.CurrentPhase)
 	})
@@ -171,7 +191,7 @@ func TestGetAllocationToken(t *testing.T) {
 
 		mockCurrentState := ExecutionState{AllocationTokenRequestStartTime: time.Now()}
 		mockMetrics := getPrestoExecutorMetrics(promutils.NewTestScope())
-		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, mockMetrics)
+		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, config.GetPrestoConfig(), mockMetrics)
 		assert.NoError(t, err)
 		assert.Equal(t, PhaseNotStarted, state.CurrentPhase)
 	})
@@ -185,7 +205,7 @@ func TestGetAllocationToken(t *testing.T) {
 
 		mockCurrentState := ExecutionState{AllocationTokenRequestStartTime: time.Now()}
 		mockMetrics := getPrestoExecutorMetrics(promutils.NewTestScope())
-		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, mockMetrics)
+		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, config.GetPrestoConfig(), mockMetrics)
 		assert.NoError(t, err)
 		assert.Equal(t, PhaseNotStarted, state.CurrentPhase)
 	})
@@ -199,7 +219,7 @@ func TestGetAllocationToken(t *testing.T) {
 
 		mockCurrentState := ExecutionState{}
 		mockMetrics := getPrestoExecutorMetrics(promutils.NewTestScope())
-		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, mockMetrics)
+		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, config.GetPrestoConfig(), mockMetrics)
 		assert.NoError(t, err)
 		assert.Equal(t, state.AllocationTokenRequestStartTime.IsZero(), false)
 	})
@@ -214,11 +234,28 @@ func TestGetAllocationToken(t *testing.T) {
 		startTime := time.Now()
 		mockCurrentState := ExecutionState{AllocationTokenRequestStartTime: startTime}
 		mockMetrics := getPrestoExecutorMetrics(promutils.NewTestScope())
-		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, mockMetrics)
+		state, err := GetAllocationToken(ctx, tCtx, mockCurrentState, config.GetPrestoConfig(), mockMetrics)
 		assert.NoError(t, err)
 		assert.Equal(t, state.AllocationTokenRequestStartTime.IsZero(), false)
 		assert.Equal(t, state.AllocationTokenRequestStartTime, startTime)
 	})
+
+	t.Run("routing group from the passed config", func(t *testing.T) {
+		tCtx := GetMockTaskExecutionContext()
+		mockResourceManager := tCtx.ResourceManager()
+		x := mockResourceManager.(*mocks.ResourceManager)
+		x.On("AllocateResource", mock.Anything, core.ResourceNamespace("batch"), mock.Anything, mock.Anything).
+			Return(core.AllocationStatusGranted, nil)
+
+		cfg := *config.GetPrestoConfig()
+		cfg.DefaultRoutingGroup = "batch"
+		cfg.RoutingGroupConfigs = []config.RoutingGroupConfig{{Name: "batch", Limit: 10}}
+		mockMetrics := getPrestoExecutorMetrics(promutils.NewTestScope())
+		state, err := GetAllocationToken(ctx, tCtx, ExecutionState{}, &cfg, mockMetrics)
+		assert.NoError(t, err)
+		assert.Equal(t, PhaseQueued, state.CurrentPhase)
+		x.AssertNumberOfCalls(t, "AllocateResource", 1)
+	})
 }
 
 func TestAbort(t *testing.T) {
@@ -265,7 +302,7 @@ func TestFinalize(t *testing.T) {
 		called = true
 	}).Return(nil)
 
-	err := Finalize(ctx, tCtx, state, getPrestoExecutorMetrics(promutils.NewTestScope()))
+	err := Finalize(ctx, tCtx, state, config.GetPrestoConfig(), getPrestoExecutorMetrics(promutils.NewTestScope()))
 	assert.NoError(t, err)
 	assert.True(t, called)
 }
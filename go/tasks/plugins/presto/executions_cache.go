@@ -10,6 +10,7 @@ import (
 	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	stdErrors "github.com/flyteorg/flytestdlib/errors"
 
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/client"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/config"
 
@@ -28,10 +29,13 @@ type ExecutionsCache struct {
 	cfg          *config.Config
 }
 
+// NewPrestoExecutionsCache creates a cache that refreshes the queries' status. Its syncs are tracked by refreshWorkers,
+// if not nil, so that stopping the cache can wait for them.
 func NewPrestoExecutionsCache(
 	ctx context.Context,
 	prestoClient client.PrestoClient,
 	cfg *config.Config,
+	refreshWorkers *utils.RefreshWorkers,
 	scope promutils.Scope) (ExecutionsCache, error) {
 
 	q := ExecutionsCache{
@@ -39,7 +43,7 @@ func NewPrestoExecutionsCache(
 		scope:        scope,
 		cfg:          cfg,
 	}
-	autoRefreshCache, err := cache.NewAutoRefreshCache(cfg.RefreshCacheConfig.Name, q.SyncPrestoQuery, workqueue.DefaultControllerRateLimiter(), cfg.RefreshCacheConfig.SyncPeriod.Duration, cfg.RefreshCacheConfig.Workers, cfg.RefreshCacheConfig.LruCacheSize, scope)
+	autoRefreshCache, err := cache.NewAutoRefreshCache(cfg.RefreshCacheConfig.Name, refreshWorkers.Wrap(q.SyncPrestoQuery), workqueue.DefaultControllerRateLimiter(), cfg.RefreshCacheConfig.SyncPeriod.Duration, cfg.RefreshCacheConfig.Workers, cfg.RefreshCacheConfig.LruCacheSize, scope)
 	if err != nil {
 		logger.Errorf(ctx, "Could not create AutoRefreshCache in Executor. [%s]", err)
 		return q, errors.Wrapf(errors.CacheFailed, err, "Error creating AutoRefreshCache")
@@ -3,13 +3,15 @@ package presto
 import (
 	"context"
 
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/client"
 
 	"github.com/flyteorg/flytestdlib/cache"
 
-	"github.com/flyteorg/flyteplugins/go/tasks/errors"
 	pluginMachinery "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
 	"github.com/flyteorg/flyteplugins/go/tasks/plugins/presto/config"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
@@ -22,6 +24,10 @@ const prestoPluginID = "presto"
 // the structure of the stored state
 const pluginStateVersion = 0
 
+// pluginStateMigrator reads the persisted ExecutionState. Register migrations here when the state changes in
+// incompatible ways.
+var pluginStateMigrator = core.NewPluginStateMigrator(pluginStateVersion)
+
 const prestoTaskType = "presto" // This needs to match the type defined in Flytekit constants.py
 
 type Executor struct {
@@ -30,6 +36,9 @@ type Executor struct {
 	prestoClient    client.PrestoClient
 	executionsCache cache.AutoRefresh
 	cfg             *config.Config
+	// Stops the background refresh of the executions cache.
+	stopCache      context.CancelFunc
+	refreshWorkers *utils.RefreshWorkers
 }
 
 func (p Executor) GetID() string {
@@ -41,15 +50,14 @@ func (p Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 
 	// We assume here that the first time this function is called, the custom state we get back is whatever we passed in,
 	// namely the zero-value of our struct.
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if err := pluginStateMigrator.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state when handling [%s] [%s]",
 			p.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
-		return core.UnknownTransition, errors.Wrapf(errors.CorruptedPluginState, err,
-			"Failed to unmarshal custom state in Handle")
+		return core.UnknownTransition, err
 	}
 
 	// Do what needs to be done, and give this function everything it needs to do its job properly
-	outgoingState, transformError := HandleExecutionState(ctx, tCtx, incomingState, p.prestoClient, p.executionsCache, p.metrics)
+	outgoingState, transformError := HandleExecutionState(ctx, tCtx, incomingState, p.prestoClient, p.executionsCache, p.cfg, p.metrics)
 
 	// Return if there was an error
 	if transformError != nil {
@@ -68,10 +76,10 @@ func (p Executor) Handle(ctx context.Context, tCtx core.TaskExecutionContext) (c
 
 func (p Executor) Abort(ctx context.Context, tCtx core.TaskExecutionContext) error {
 	incomingState := ExecutionState{}
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if err := pluginStateMigrator.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state in Finalize [%s] Err [%s]",
 			p.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
-		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to unmarshal custom state in Finalize")
+		return err
 	}
 
 	return Abort(ctx, incomingState, p.prestoClient)
@@ -79,19 +87,44 @@ func (p Executor) Abort(ctx context.Context, tCtx core.TaskExecutionContext) err
 
 func (p Executor) Finalize(ctx context.Context, tCtx core.TaskExecutionContext) error {
 	incomingState := ExecutionState{}
-	if _, err := tCtx.PluginStateReader().Get(&incomingState); err != nil {
+	if err := pluginStateMigrator.Get(tCtx.PluginStateReader(), &incomingState); err != nil {
 		logger.Errorf(ctx, "Plugin %s failed to unmarshal custom state in Finalize [%s] Err [%s]",
 			p.id, tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
-		return errors.Wrapf(errors.CorruptedPluginState, err, "Failed to unmarshal custom state in Finalize")
+		return err
 	}
 
-	return Finalize(ctx, tCtx, incomingState, p.metrics)
+	return Finalize(ctx, tCtx, incomingState, p.cfg, p.metrics)
 }
 
 func (p Executor) GetProperties() core.PluginProperties {
 	return core.PluginProperties{}
 }
 
+// ValidateTask checks that the task template holds a PrestoQuery with a statement.
+func (p Executor) ValidateTask(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	_, err := getPrestoQuery(taskTemplate)
+	return err
+}
+
+// CheckHealth reports the plugin as unhealthy if the Presto coordinator is unreachable or unhealthy.
+func (p Executor) CheckHealth(ctx context.Context) error {
+	return p.prestoClient.CheckHealth(ctx)
+}
+
+// Stop stops refreshing the executions cache and waits for the running syncs to return.
+func (p Executor) Stop(ctx context.Context) error {
+	if p.stopCache != nil {
+		p.stopCache()
+	}
+
+	if err := p.refreshWorkers.Stop(ctx); err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "Stopped plugin [%v].", p.id)
+	return nil
+}
+
 func ExecutorLoader(ctx context.Context, iCtx core.SetupContext) (core.Plugin, error) {
 	cfg := config.GetPrestoConfig()
 	return InitializePrestoExecutor(ctx, iCtx, cfg, client.NewNoopPrestoClient(cfg))
@@ -126,13 +159,15 @@ func NewPrestoExecutor(
 	prestoClient client.PrestoClient,
 	scope promutils.Scope) (Executor, error) {
 	subScope := scope.NewSubScope(prestoTaskType)
-	executionsAutoRefreshCache, err := NewPrestoExecutionsCache(ctx, prestoClient, cfg, subScope)
+	refreshWorkers := &utils.RefreshWorkers{}
+	executionsAutoRefreshCache, err := NewPrestoExecutionsCache(ctx, prestoClient, cfg, refreshWorkers, subScope)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to create AutoRefreshCache in Executor Setup. Error: %v", err)
 		return Executor{}, err
 	}
 
-	err = executionsAutoRefreshCache.Start(ctx)
+	cacheCtx, stopCache := context.WithCancel(ctx)
+	err = executionsAutoRefreshCache.Start(cacheCtx)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to start AutoRefreshCache. Error: %v", err)
 	}
@@ -143,6 +178,8 @@ func NewPrestoExecutor(
 		metrics:         getPrestoExecutorMetrics(subScope),
 		prestoClient:    prestoClient,
 		executionsCache: executionsAutoRefreshCache,
+		stopCache:       stopCache,
+		refreshWorkers:  refreshWorkers,
 	}, nil
 }
 
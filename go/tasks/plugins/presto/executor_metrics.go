@@ -9,6 +9,7 @@ type ExecutorMetrics struct {
 	Scope                 promutils.Scope
 	ResourceReleased      labeled.Counter
 	ResourceReleaseFailed labeled.Counter
+	LeaseRenewFailed      labeled.Counter
 	AllocationGranted     labeled.Counter
 	AllocationNotGranted  labeled.Counter
 }
@@ -20,6 +21,8 @@ func getPrestoExecutorMetrics(scope promutils.Scope) ExecutorMetrics {
 			"Resource allocation token released", scope, labeled.EmitUnlabeledMetric),
 		ResourceReleaseFailed: labeled.NewCounter("resource_release_failed",
 			"Error releasing allocation token", scope, labeled.EmitUnlabeledMetric),
+		LeaseRenewFailed: labeled.NewCounter("lease_renew_failed",
+			"Error renewing the lease of an allocation token", scope, labeled.EmitUnlabeledMetric),
 		AllocationGranted: labeled.NewCounter("allocation_grant_success",
 			"Allocation request granted", scope, labeled.EmitUnlabeledMetric),
 		AllocationNotGranted: labeled.NewCounter("allocation_grant_failed",
@@ -17,6 +17,7 @@ var (
 			ResourceQuotas: map[core.ResourceNamespace]int{
 				"default": 1000,
 			},
+			ResourceLeaseTTL: config.Duration{Duration: time.Hour},
 			ReadRateLimiter: webapi.RateLimiterConfig{
 				Burst: 100,
 				QPS:   10,
@@ -30,6 +31,8 @@ var (
 				ResyncInterval:    config.Duration{Duration: 30 * time.Second},
 				Workers:           10,
 				MaxSystemFailures: 5,
+				// BatchGetQueryExecution accepts up to 50 query execution ids.
+				BatchSize: 50,
 			},
 			ResourceMeta: nil,
 		},
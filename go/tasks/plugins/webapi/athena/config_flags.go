@@ -49,6 +49,13 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.caching.resyncInterval"), defaultConfig.WebAPI.Caching.ResyncInterval.String(), "Defines the sync interval.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.workers"), defaultConfig.WebAPI.Caching.Workers, "Defines the number of workers to start up to process items.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.maxSystemFailures"), defaultConfig.WebAPI.Caching.MaxSystemFailures, "Defines the number of failures to fetch a task before failing the task.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.batchSize"), defaultConfig.WebAPI.Caching.BatchSize, "Defines the maximum number of resources to retrieve in a single batched Get.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "webApi.circuitBreaker.enabled"), defaultConfig.WebAPI.CircuitBreaker.Enabled, "Defines whether the circuit breaker is enabled.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.circuitBreaker.failurePercentage"), defaultConfig.WebAPI.CircuitBreaker.FailurePercentage, "Defines the percentage of failed calls within a window at which the circuit opens.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.circuitBreaker.minRequests"), defaultConfig.WebAPI.CircuitBreaker.MinRequests, "Defines the minimum number of calls within a window before the failure percentage is evaluated.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.circuitBreaker.window"), defaultConfig.WebAPI.CircuitBreaker.Window.String(), "Defines the duration of the window in which calls are counted.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.circuitBreaker.openDuration"), defaultConfig.WebAPI.CircuitBreaker.OpenDuration.String(), "Defines how long the circuit stays open before a probe call is let through.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.resourceLeaseTTL"), defaultConfig.WebAPI.ResourceLeaseTTL.String(), "Defines how long allocation tokens are leased for. Leases are disabled if zero.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "defaultWorkGroup"), defaultConfig.DefaultWorkGroup, "Defines the default workgroup to use when running on Athena unless overwritten by the task.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "defaultCatalog"), defaultConfig.DefaultCatalog, "Defines the default catalog to use when running on Athena unless overwritten by the task.")
 	return cmdFlags
@@ -275,6 +275,160 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_webApi.caching.batchSize", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("webApi.caching.batchSize"); err == nil {
+				assert.Equal(t, int(defaultConfig.WebAPI.Caching.BatchSize), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webApi.caching.batchSize", testValue)
+			if vInt, err := cmdFlags.GetInt("webApi.caching.batchSize"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.WebAPI.Caching.BatchSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webApi.circuitBreaker.enabled", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vBool, err := cmdFlags.GetBool("webApi.circuitBreaker.enabled"); err == nil {
+				assert.Equal(t, bool(defaultConfig.WebAPI.CircuitBreaker.Enabled), vBool)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webApi.circuitBreaker.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("webApi.circuitBreaker.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.WebAPI.CircuitBreaker.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webApi.circuitBreaker.failurePercentage", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("webApi.circuitBreaker.failurePercentage"); err == nil {
+				assert.Equal(t, int(defaultConfig.WebAPI.CircuitBreaker.FailurePercentage), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webApi.circuitBreaker.failurePercentage", testValue)
+			if vInt, err := cmdFlags.GetInt("webApi.circuitBreaker.failurePercentage"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.WebAPI.CircuitBreaker.FailurePercentage)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webApi.circuitBreaker.minRequests", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vInt, err := cmdFlags.GetInt("webApi.circuitBreaker.minRequests"); err == nil {
+				assert.Equal(t, int(defaultConfig.WebAPI.CircuitBreaker.MinRequests), vInt)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("webApi.circuitBreaker.minRequests", testValue)
+			if vInt, err := cmdFlags.GetInt("webApi.circuitBreaker.minRequests"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.WebAPI.CircuitBreaker.MinRequests)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webApi.circuitBreaker.window", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("webApi.circuitBreaker.window"); err == nil {
+				assert.Equal(t, string(defaultConfig.WebAPI.CircuitBreaker.Window.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.WebAPI.CircuitBreaker.Window.String()
+
+			cmdFlags.Set("webApi.circuitBreaker.window", testValue)
+			if vString, err := cmdFlags.GetString("webApi.circuitBreaker.window"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.WebAPI.CircuitBreaker.Window)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webApi.circuitBreaker.openDuration", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("webApi.circuitBreaker.openDuration"); err == nil {
+				assert.Equal(t, string(defaultConfig.WebAPI.CircuitBreaker.OpenDuration.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.WebAPI.CircuitBreaker.OpenDuration.String()
+
+			cmdFlags.Set("webApi.circuitBreaker.openDuration", testValue)
+			if vString, err := cmdFlags.GetString("webApi.circuitBreaker.openDuration"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.WebAPI.CircuitBreaker.OpenDuration)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_webApi.resourceLeaseTTL", func(t *testing.T) {
+		t.Run("DefaultValue", func(t *testing.T) {
+			// Test that default value is set properly
+			if vString, err := cmdFlags.GetString("webApi.resourceLeaseTTL"); err == nil {
+				assert.Equal(t, string(defaultConfig.WebAPI.ResourceLeaseTTL.String()), vString)
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.WebAPI.ResourceLeaseTTL.String()
+
+			cmdFlags.Set("webApi.resourceLeaseTTL", testValue)
+			if vString, err := cmdFlags.GetString("webApi.resourceLeaseTTL"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.WebAPI.ResourceLeaseTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_defaultWorkGroup", func(t *testing.T) {
 		t.Run("DefaultValue", func(t *testing.T) {
 			// Test that default value is set properly
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/athena"
 	athenaTypes "github.com/aws/aws-sdk-go-v2/service/athena/types"
 	"github.com/flyteorg/flyteplugins/go/tasks/aws"
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
 
 	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 
@@ -23,6 +24,7 @@ import (
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
 )
 
@@ -42,17 +44,50 @@ type Plugin struct {
 type ResourceWrapper struct {
 	Status               *athenaTypes.QueryExecutionStatus
 	ResultsConfiguration *athenaTypes.ResultConfiguration
+	Statistics           *athenaTypes.QueryExecutionStatistics
+	// The columns of the results. Only retrieved once the query succeeded.
+	Columns          []ioutils.DatasetColumn
+	ColumnsRetrieved bool
+}
+
+// awaitsColumns returns whether the query succeeded but the columns of its results haven't been retrieved yet.
+func (r ResourceWrapper) awaitsColumns() bool {
+	return r.Status != nil && r.Status.State == athenaTypes.QueryExecutionStateSucceeded && !r.ColumnsRetrieved
 }
 
 func (p Plugin) GetConfig() webapi.PluginConfig {
 	return GetConfig().WebAPI
 }
 
-func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionContextReader) (
+// ValidateTask checks that the task template holds a valid hive or presto query and valid resource overrides.
+func (p Plugin) ValidateTask(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	if _, err := unmarshalQuery(taskTemplate); err != nil {
+		return errors2.Wrapf(errors2.BadTaskSpecification, err, "Invalid Athena task.")
+	}
+
+	// The priority granted to the project and domain is only known at execution time.
+	_, err := p.cfg.ResourceConstraints.WithTaskOverrides(taskTemplate, "")
+	return err
+}
+
+func (p Plugin) ResourceRequirements(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (
 	namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error) {
 
-	// Resource requirements are assumed to be the same.
-	return "default", p.cfg.ResourceConstraints, nil
+	taskTemplate, err := tCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return "", core.ResourceConstraintsSpec{}, err
+	}
+
+	// Resource constraints are the same for all queries, tasks can only set how much of the resource they claim.
+	id := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+	executionID := id.GetNodeExecutionId().GetExecutionId()
+	priority, _ := pluginsConfig.GetConfig().ResolveResourcePriority(executionID.GetProject(), executionID.GetDomain())
+	constraints, err = p.cfg.ResourceConstraints.WithTaskOverrides(taskTemplate, priority)
+	if err != nil {
+		return "", core.ResourceConstraintsSpec{}, err
+	}
+
+	return "default", constraints, nil
 }
 
 func (p Plugin) Create(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (resourceMeta webapi.ResourceMeta,
@@ -103,6 +138,13 @@ func (p Plugin) Create(ctx context.Context, tCtx webapi.TaskExecutionContextRead
 
 func (p Plugin) Get(ctx context.Context, tCtx webapi.GetContext) (latest webapi.Resource, err error) {
 	exec := tCtx.ResourceMeta().(string)
+
+	// Retrieve the result columns of a query that succeeded instead of its status, so that every call to the service
+	// goes through the read rate limiter.
+	if previous, ok := tCtx.Resource().(ResourceWrapper); ok && previous.awaitsColumns() {
+		return p.withResultColumns(ctx, exec, previous), nil
+	}
+
 	resp, err := p.client.GetQueryExecution(ctx, &athena.GetQueryExecutionInput{
 		QueryExecutionId: awsSdk.String(exec),
 	})
@@ -114,9 +156,90 @@ func (p Plugin) Get(ctx context.Context, tCtx webapi.GetContext) (latest webapi.
 	return ResourceWrapper{
 		Status:               resp.QueryExecution.Status,
 		ResultsConfiguration: resp.QueryExecution.ResultConfiguration,
+		Statistics:           resp.QueryExecution.Statistics,
 	}, nil
 }
 
+// withResultColumns retrieves the columns of the results of a succeeded query. Failing to retrieve them isn't fatal,
+// the columns declared in the task interface are reported instead.
+func (p Plugin) withResultColumns(ctx context.Context, execID string, resource ResourceWrapper) ResourceWrapper {
+	resource.ColumnsRetrieved = true
+	resp, err := p.client.GetQueryResults(ctx, &athena.GetQueryResultsInput{
+		QueryExecutionId: awsSdk.String(execID),
+		MaxResults:       awsSdk.Int32(1),
+	})
+	if err != nil {
+		logger.Warnf(ctx, "Failed to retrieve the result columns of query execution [%v]. Error: %v", execID, err)
+		return resource
+	}
+
+	if resp.ResultSet == nil || resp.ResultSet.ResultSetMetadata == nil {
+		return resource
+	}
+
+	resource.Columns = make([]ioutils.DatasetColumn, 0, len(resp.ResultSet.ResultSetMetadata.ColumnInfo))
+	for _, column := range resp.ResultSet.ResultSetMetadata.ColumnInfo {
+		resource.Columns = append(resource.Columns, ioutils.DatasetColumn{
+			Name: awsSdk.ToString(column.Name),
+			Type: toColumnType(awsSdk.ToString(column.Type)),
+		})
+	}
+
+	return resource
+}
+
+func (p Plugin) ResourceKey(resourceMeta webapi.ResourceMeta) string {
+	return resourceMeta.(string)
+}
+
+func (p Plugin) BatchGet(ctx context.Context, tCtxs []webapi.GetContext) (latest map[string]webapi.Resource, err error) {
+	latest = make(map[string]webapi.Resource, len(tCtxs))
+
+	// Retrieve the result columns of the queries that succeeded instead of their status. Their columns are only
+	// retrieved once, so the batch doesn't stop refreshing the other queries while succeeded ones are drained.
+	execIDs := make([]string, 0, len(tCtxs))
+	for _, tCtx := range tCtxs {
+		execID := tCtx.ResourceMeta().(string)
+		if previous, ok := tCtx.Resource().(ResourceWrapper); ok && previous.awaitsColumns() {
+			latest[execID] = p.withResultColumns(ctx, execID, previous)
+			continue
+		}
+
+		execIDs = append(execIDs, execID)
+	}
+
+	if len(execIDs) == 0 {
+		return latest, nil
+	}
+
+	resp, err := p.client.BatchGetQueryExecution(ctx, &athena.BatchGetQueryExecutionInput{
+		QueryExecutionIds: execIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, unprocessed := range resp.UnprocessedQueryExecutionIds {
+		logger.Warnf(ctx, "Failed to retrieve query execution [%v]. Error: %v",
+			awsSdk.ToString(unprocessed.QueryExecutionId), awsSdk.ToString(unprocessed.ErrorMessage))
+	}
+
+	for _, exec := range resp.QueryExecutions {
+		if exec.QueryExecutionId == nil {
+			continue
+		}
+
+		// Only cache fields we want to keep in memory instead of the potentially huge execution closure.
+		latest[*exec.QueryExecutionId] = ResourceWrapper{
+			Status:               exec.Status,
+			ResultsConfiguration: exec.ResultConfiguration,
+			Statistics:           exec.Statistics,
+		}
+	}
+
+	return latest, nil
+}
+
 func (p Plugin) Delete(ctx context.Context, tCtx webapi.DeleteContext) error {
 	resp, err := p.client.StopQueryExecution(ctx, &athena.StopQueryExecutionInput{
 		QueryExecutionId: awsSdk.String(tCtx.ResourceMeta().(string)),
@@ -137,42 +260,62 @@ func (p Plugin) Status(ctx context.Context, tCtx webapi.StatusContext) (phase co
 		return core.PhaseInfoUndefined, errors.Errorf(ErrSystem, "No Status field set.")
 	}
 
+	info := createTaskInfo(execID, p.awsConfig)
+	info.ResourceUsage = resourceUsage(exec.Statistics)
+
 	switch exec.Status.State {
 	case athenaTypes.QueryExecutionStateQueued:
 		fallthrough
 	case athenaTypes.QueryExecutionStateRunning:
-		return core.PhaseInfoRunning(1, createTaskInfo(execID, p.awsConfig)), nil
+		return core.PhaseInfoRunning(1, info), nil
 	case athenaTypes.QueryExecutionStateCancelled:
 		reason := "Remote execution was aborted."
 		if reasonPtr := exec.Status.StateChangeReason; reasonPtr != nil {
 			reason = *reasonPtr
 		}
 
-		return core.PhaseInfoRetryableFailure("ABORTED", reason, createTaskInfo(execID, p.awsConfig)), nil
+		return core.PhaseInfoRetryableFailure("ABORTED", reason, info), nil
 	case athenaTypes.QueryExecutionStateFailed:
 		reason := "Remote execution failed"
 		if reasonPtr := exec.Status.StateChangeReason; reasonPtr != nil {
 			reason = *reasonPtr
 		}
 
-		return core.PhaseInfoRetryableFailure("FAILED", reason, createTaskInfo(execID, p.awsConfig)), nil
+		return core.PhaseInfoRetryableFailure("FAILED", reason, info), nil
 	case athenaTypes.QueryExecutionStateSucceeded:
+		// The columns of the results are retrieved in the next sync.
+		if !exec.ColumnsRetrieved {
+			return core.PhaseInfoRunning(1, info), nil
+		}
+
 		if outputLocation := exec.ResultsConfiguration.OutputLocation; outputLocation != nil {
 			// If WorkGroup settings overrode the client settings, the location submitted in the request might have been
 			// ignored.
-			err = writeOutput(ctx, tCtx, *outputLocation)
+			err = writeOutput(ctx, tCtx, *outputLocation, exec.Columns)
 			if err != nil {
 				logger.Warnf(ctx, "Failed to write output, uri [%s], err %s", *outputLocation, err.Error())
 				return core.PhaseInfoUndefined, err
 			}
 		}
 
-		return core.PhaseInfoSuccess(createTaskInfo(execID, p.awsConfig)), nil
+		return core.PhaseInfoSuccess(info), nil
 	}
 
 	return core.PhaseInfoUndefined, errors.Errorf(ErrSystem, "Unknown execution phase [%v].", exec.Status.State)
 }
 
+// resourceUsage returns the amount of data scanned by the query so far, with its cost estimated from the configured
+// resource prices.
+func resourceUsage(stats *athenaTypes.QueryExecutionStatistics) *core.ResourceUsage {
+	if stats == nil || stats.DataScannedInBytes == nil {
+		return nil
+	}
+
+	usage := core.ResourceUsage{BytesScanned: *stats.DataScannedInBytes}.WithEstimatedCost(
+		pluginsConfig.GetConfig().ResourcePrices)
+	return &usage
+}
+
 func createTaskInfo(queryID string, cfg awsSdk.Config) *core.TaskInfo {
 	timeNow := time.Now()
 	return &core.TaskInfo{
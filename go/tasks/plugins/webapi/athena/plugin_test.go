@@ -1,13 +1,33 @@
 package athena
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	athenaTypes "github.com/aws/aws-sdk-go-v2/service/athena/types"
 	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/utils"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	errors2 "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/webapitest"
 )
 
 func TestCreateTaskInfo(t *testing.T) {
@@ -28,3 +48,200 @@ func TestCreateTaskInfo(t *testing.T) {
 		},
 	}, taskInfo.Metadata))
 }
+
+func TestResourceUsage(t *testing.T) {
+	previous := *pluginsConfig.GetConfig()
+	defer func() {
+		assert.NoError(t, pluginsConfig.SetConfig(&previous))
+	}()
+
+	cfg := previous
+	cfg.ResourcePrices = pluginsConfig.ResourcePrices{TerabyteScanned: 5}
+	assert.NoError(t, pluginsConfig.SetConfig(&cfg))
+
+	assert.Nil(t, resourceUsage(nil))
+	assert.Nil(t, resourceUsage(&athenaTypes.QueryExecutionStatistics{}))
+	assert.Equal(t, &core.ResourceUsage{BytesScanned: 2e11, EstimatedCost: 1}, resourceUsage(
+		&athenaTypes.QueryExecutionStatistics{DataScannedInBytes: awsSdk.Int64(2e11)}))
+}
+
+func TestPlugin_ResourceRequirements(t *testing.T) {
+	ctx := context.TODO()
+	p := Plugin{cfg: &Config{ResourceConstraints: core.ResourceConstraintsSpec{
+		ProjectScopeResourceConstraint: &core.ResourceConstraint{Value: 10},
+	}}}
+
+	newTaskContext := func(config map[string]string) *mocks.TaskExecutionContextReader {
+		taskReader := &coreMocks.TaskReader{}
+		taskReader.OnRead(ctx).Return(&idlCore.TaskTemplate{Config: config}, nil)
+		tID := &coreMocks.TaskExecutionID{}
+		tID.OnGetID().Return(idlCore.TaskExecutionIdentifier{NodeExecutionId: &idlCore.NodeExecutionIdentifier{
+			ExecutionId: &idlCore.WorkflowExecutionIdentifier{Project: "project", Domain: "development"},
+		}})
+		tMeta := &coreMocks.TaskExecutionMetadata{}
+		tMeta.OnGetTaskExecutionID().Return(tID)
+		tCtx := &mocks.TaskExecutionContextReader{}
+		tCtx.OnTaskReader().Return(taskReader)
+		tCtx.OnTaskExecutionMetadata().Return(tMeta)
+		return tCtx
+	}
+
+	t.Run("Default weight", func(t *testing.T) {
+		namespace, constraints, err := p.ResourceRequirements(ctx, newTaskContext(nil))
+		assert.NoError(t, err)
+		assert.Equal(t, core.ResourceNamespace("default"), namespace)
+		assert.Equal(t, p.cfg.ResourceConstraints, constraints)
+	})
+
+	t.Run("Weighted query", func(t *testing.T) {
+		_, constraints, err := p.ResourceRequirements(ctx, newTaskContext(map[string]string{
+			core.ResourceUnitsConfigKey:    "5",
+			core.ResourcePriorityConfigKey: "low",
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), constraints.Units)
+		assert.Equal(t, core.ResourcePriorityLow, constraints.Priority)
+		assert.Equal(t, int64(10), constraints.ProjectScopeResourceConstraint.Value)
+	})
+}
+
+func TestPlugin_ValidateTask(t *testing.T) {
+	ctx := context.TODO()
+	p := Plugin{cfg: &Config{}}
+	hiveQuery, err := utils.MarshalPbToStruct(&plugins.QuboleHiveJob{
+		ClusterLabel: "mydb",
+		Query:        &plugins.HiveQuery{Query: "Select * from mytable"},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.ValidateTask(ctx, &idlCore.TaskTemplate{Type: "hive", Custom: hiveQuery}))
+
+	t.Run("Missing statement", func(t *testing.T) {
+		prestoQuery, err := utils.MarshalPbToStruct(&plugins.PrestoQuery{Catalog: "catalog"})
+		assert.NoError(t, err)
+		err = p.ValidateTask(ctx, &idlCore.TaskTemplate{Type: "presto", Custom: prestoQuery})
+		code, found := errors.GetErrorCode(err)
+		assert.True(t, found)
+		assert.Equal(t, errors2.BadTaskSpecification, code)
+	})
+
+	t.Run("Unexpected task type", func(t *testing.T) {
+		assert.Error(t, p.ValidateTask(ctx, &idlCore.TaskTemplate{Type: "spark", Custom: hiveQuery}))
+	})
+
+	t.Run("Invalid resource overrides", func(t *testing.T) {
+		assert.Error(t, p.ValidateTask(ctx, &idlCore.TaskTemplate{Type: "hive", Custom: hiveQuery,
+			Config: map[string]string{core.ResourceUnitsConfigKey: "many"}}))
+	})
+}
+
+// fakeAthenaService answers the calls of an Athena client and records the operations they invoke. Batched queries are
+// reported as running.
+type fakeAthenaService struct {
+	operations []string
+	batchedIDs []string
+}
+
+func (s *fakeAthenaService) Do(r *http.Request) (*http.Response, error) {
+	operation := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonAthena.")
+	s.operations = append(s.operations, operation)
+
+	body := "{}"
+	switch operation {
+	case "GetQueryExecution":
+		body = `{"QueryExecution": {"QueryExecutionId": "q1", "Status": {"State": "SUCCEEDED"}}}`
+	case "BatchGetQueryExecution":
+		input := athena.BatchGetQueryExecutionInput{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			return nil, err
+		}
+
+		s.batchedIDs = input.QueryExecutionIds
+		executions := make([]string, 0, len(input.QueryExecutionIds))
+		for _, id := range input.QueryExecutionIds {
+			executions = append(executions, fmt.Sprintf(`{"QueryExecutionId": %q, "Status": {"State": "RUNNING"}}`, id))
+		}
+
+		body = fmt.Sprintf(`{"QueryExecutions": [%v]}`, strings.Join(executions, ","))
+	case "GetQueryResults":
+		body = `{"ResultSet": {"ResultSetMetadata": {"ColumnInfo": [{"Name": "id", "Type": "integer"}]}}}`
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/x-amz-json-1.1"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func TestPlugin_ResultColumns(t *testing.T) {
+	ctx := context.TODO()
+	service := &fakeAthenaService{}
+	p := Plugin{
+		client: athena.New(athena.Options{
+			Region:      "us-east-1",
+			Credentials: awsSdk.AnonymousCredentials{},
+			HTTPClient:  service,
+		}),
+		awsConfig: awsSdk.Config{Region: "us-east-1"},
+	}
+
+	// The status of the query is retrieved first, the task keeps running until the columns are retrieved.
+	succeeded, err := p.Get(ctx, webapitest.NewGetContext("q1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GetQueryExecution"}, service.operations)
+	assert.Nil(t, succeeded.(ResourceWrapper).Columns)
+
+	sCtx := &mocks.StatusContext{}
+	sCtx.OnResourceMeta().Return("q1")
+	sCtx.OnResource().Return(succeeded)
+	phase, err := p.Status(ctx, sCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.PhaseRunning, phase.Phase())
+
+	t.Run("Get", func(t *testing.T) {
+		service.operations = nil
+		latest, err := p.Get(ctx, webapitest.NewGetContext("q1", succeeded))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"GetQueryResults"}, service.operations)
+		assert.True(t, latest.(ResourceWrapper).ColumnsRetrieved)
+		assert.Equal(t, []ioutils.DatasetColumn{{Name: "id", Type: idlCore.SchemaType_SchemaColumn_INTEGER}},
+			latest.(ResourceWrapper).Columns)
+
+		// Once retrieved, the status of the query is retrieved again.
+		service.operations = nil
+		_, err = p.Get(ctx, webapitest.NewGetContext("q1", latest))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"GetQueryExecution"}, service.operations)
+	})
+
+	t.Run("BatchGet", func(t *testing.T) {
+		service.operations = nil
+		latest, err := p.BatchGet(ctx, []webapi.GetContext{
+			webapitest.NewGetContext("q2", nil),
+			webapitest.NewGetContext("q1", succeeded),
+			webapitest.NewGetContext("q3", nil),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"GetQueryResults", "BatchGetQueryExecution"}, service.operations)
+		assert.Equal(t, []string{"q2", "q3"}, service.batchedIDs)
+		assert.Len(t, latest, 3)
+		assert.True(t, latest["q1"].(ResourceWrapper).ColumnsRetrieved)
+		for _, id := range []string{"q2", "q3"} {
+			assert.Equal(t, athenaTypes.QueryExecutionStateRunning, latest[id].(ResourceWrapper).Status.State)
+		}
+
+		// Batches only waiting for result columns don't query the status of any execution.
+		service.operations = nil
+		latest, err = p.BatchGet(ctx, []webapi.GetContext{webapitest.NewGetContext("q1", succeeded)})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"GetQueryResults"}, service.operations)
+		assert.Len(t, latest, 1)
+
+		service.operations = nil
+		latest, err = p.BatchGet(ctx, []webapi.GetContext{webapitest.NewGetContext("q1", latest["q1"])})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"BatchGetQueryExecution"}, service.operations)
+		assert.Len(t, latest, 1)
+	})
+}
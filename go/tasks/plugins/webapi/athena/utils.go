@@ -2,6 +2,7 @@ package athena
 
 import (
 	"context"
+	"strings"
 
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
 
@@ -9,46 +10,42 @@ import (
 
 	pluginsIdl "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/plugins"
 	"github.com/flyteorg/flytestdlib/utils"
+	"github.com/golang/protobuf/proto"
 
 	pb "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
 	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
-	"github.com/flyteorg/flytestdlib/logger"
 )
 
-func writeOutput(ctx context.Context, tCtx webapi.StatusContext, externalLocation string) error {
-	taskTemplate, err := tCtx.TaskReader().Read(ctx)
-	if err != nil {
-		return err
-	}
+// writeOutput reports the results of the query, written by Athena as CSV at externalLocation, as the "results" output.
+func writeOutput(ctx context.Context, tCtx webapi.StatusContext, externalLocation string,
+	columns []ioutils.DatasetColumn) error {
 
-	if taskTemplate.Interface == nil || taskTemplate.Interface.Outputs == nil || taskTemplate.Interface.Outputs.Variables == nil {
-		logger.Infof(ctx, "The task declares no outputs. Skipping writing the outputs.")
-		return nil
-	}
+	return ioutils.WriteDatasetOutput(ctx, tCtx.TaskReader(), tCtx.OutputWriter(), "results", ioutils.Dataset{
+		Location: externalLocation,
+		Format:   ioutils.DatasetFormatCSV,
+		Columns:  columns,
+	})
+}
 
-	resultsSchema, exists := taskTemplate.Interface.Outputs.Variables["results"]
-	if !exists {
-		logger.Infof(ctx, "The task declares no outputs. Skipping writing the outputs.")
-		return nil
+// toColumnType maps an Athena data type (e.g. "varchar" or "decimal(10,2)") to a schema column type. Types without a
+// direct equivalent are reported as strings, which is how Athena writes them in the results.
+func toColumnType(athenaType string) pb.SchemaType_SchemaColumn_SchemaColumnType {
+	t := strings.ToLower(athenaType)
+	switch {
+	case t == "boolean":
+		return pb.SchemaType_SchemaColumn_BOOLEAN
+	case t == "tinyint" || t == "smallint" || t == "int" || t == "integer" || t == "bigint":
+		return pb.SchemaType_SchemaColumn_INTEGER
+	case t == "float" || t == "real" || t == "double" || strings.HasPrefix(t, "decimal"):
+		return pb.SchemaType_SchemaColumn_FLOAT
+	case t == "date" || strings.HasPrefix(t, "timestamp"):
+		return pb.SchemaType_SchemaColumn_DATETIME
+	case strings.HasPrefix(t, "interval"):
+		return pb.SchemaType_SchemaColumn_DURATION
 	}
 
-	return tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(
-		&pb.LiteralMap{
-			Literals: map[string]*pb.Literal{
-				"results": {
-					Value: &pb.Literal_Scalar{
-						Scalar: &pb.Scalar{Value: &pb.Scalar_Schema{
-							Schema: &pb.Schema{
-								Uri:  externalLocation,
-								Type: resultsSchema.GetType().GetSchema(),
-							},
-						},
-						},
-					},
-				},
-			},
-		}, nil))
+	return pb.SchemaType_SchemaColumn_STRING
 }
 
 type QueryInfo struct {
@@ -78,34 +75,63 @@ func validatePrestoQuery(prestoQuery pluginsIdl.PrestoQuery) error {
 	return nil
 }
 
+// unmarshalQuery unmarshals and validates the query of the task template: a QuboleHiveJob for hive tasks and a
+// PrestoQuery for presto tasks.
+func unmarshalQuery(task *pb.TaskTemplate) (proto.Message, error) {
+	switch task.Type {
+	case "hive":
+		hiveQuery := &pluginsIdl.QuboleHiveJob{}
+		err := utils.UnmarshalStructToPb(task.GetCustom(), hiveQuery)
+		if err != nil {
+			return nil, errors.Wrapf(ErrUser, err, "Expects a valid QubleHiveJob proto in custom field.")
+		}
+
+		if err = validateHiveQuery(*hiveQuery); err != nil {
+			return nil, errors.Wrapf(ErrUser, err, "Expects a valid QubleHiveJob proto in custom field.")
+		}
+
+		return hiveQuery, nil
+	case "presto":
+		prestoQuery := &pluginsIdl.PrestoQuery{}
+		err := utils.UnmarshalStructToPb(task.GetCustom(), prestoQuery)
+		if err != nil {
+			return nil, errors.Wrapf(ErrUser, err, "Expects a valid PrestoQuery proto in custom field.")
+		}
+
+		if err = validatePrestoQuery(*prestoQuery); err != nil {
+			return nil, errors.Wrapf(ErrUser, err, "Expects a valid PrestoQuery proto in custom field.")
+		}
+
+		return prestoQuery, nil
+	}
+
+	return nil, errors.Errorf(ErrUser, "Unexpected task type [%v].", task.Type)
+}
+
 func extractQueryInfo(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (QueryInfo, error) {
 	task, err := tCtx.TaskReader().Read(ctx)
 	if err != nil {
 		return QueryInfo{}, err
 	}
 
-	switch task.Type {
-	case "hive":
-		custom := task.GetCustom()
-		hiveQuery := pluginsIdl.QuboleHiveJob{}
-		err := utils.UnmarshalStructToPb(custom, &hiveQuery)
-		if err != nil {
-			return QueryInfo{}, errors.Wrapf(ErrUser, err, "Expects a valid QubleHiveJob proto in custom field.")
-		}
+	query, err := unmarshalQuery(task)
+	if err != nil {
+		return QueryInfo{}, err
+	}
 
-		if err = validateHiveQuery(hiveQuery); err != nil {
-			return QueryInfo{}, errors.Wrapf(ErrUser, err, "Expects a valid QubleHiveJob proto in custom field.")
-		}
+	params := template.Parameters{
+		TaskExecMetadata: tCtx.TaskExecutionMetadata(),
+		Inputs:           tCtx.InputReader(),
+		OutputPath:       tCtx.OutputWriter(),
+		Task:             tCtx.TaskReader(),
+	}
 
+	switch q := query.(type) {
+	case *pluginsIdl.QuboleHiveJob:
 		outputs, err := template.Render(ctx, []string{
-			hiveQuery.Query.Query,
-			hiveQuery.ClusterLabel,
-		}, template.Parameters{
-			TaskExecMetadata: tCtx.TaskExecutionMetadata(),
-			Inputs:           tCtx.InputReader(),
-			OutputPath:       tCtx.OutputWriter(),
-			Task:             tCtx.TaskReader(),
-		})
+			q.Query.Query,
+			q.ClusterLabel,
+		}, params)
 		if err != nil {
 			return QueryInfo{}, err
 		}
@@ -114,29 +140,13 @@ func extractQueryInfo(ctx context.Context, tCtx webapi.TaskExecutionContextReade
 			QueryString: outputs[0],
 			Database:    outputs[1],
 		}, nil
-	case "presto":
-		custom := task.GetCustom()
-		prestoQuery := pluginsIdl.PrestoQuery{}
-		err := utils.UnmarshalStructToPb(custom, &prestoQuery)
-		if err != nil {
-			return QueryInfo{}, errors.Wrapf(ErrUser, err, "Expects a valid PrestoQuery proto in custom field.")
-		}
-
-		if err = validatePrestoQuery(prestoQuery); err != nil {
-			return QueryInfo{}, errors.Wrapf(ErrUser, err, "Expects a valid PrestoQuery proto in custom field.")
-		}
-
+	case *pluginsIdl.PrestoQuery:
 		outputs, err := template.Render(ctx, []string{
-			prestoQuery.RoutingGroup,
-			prestoQuery.Catalog,
-			prestoQuery.Schema,
-			prestoQuery.Statement,
-		}, template.Parameters{
-			TaskExecMetadata: tCtx.TaskExecutionMetadata(),
-			Inputs:           tCtx.InputReader(),
-			OutputPath:       tCtx.OutputWriter(),
-			Task:             tCtx.TaskReader(),
-		})
+			q.RoutingGroup,
+			q.Catalog,
+			q.Schema,
+			q.Statement,
+		}, params)
 		if err != nil {
 			return QueryInfo{}, err
 		}
@@ -149,5 +159,5 @@ func extractQueryInfo(ctx context.Context, tCtx webapi.TaskExecutionContextReade
 		}, nil
 	}
 
-	return QueryInfo{}, errors.Errorf(ErrUser, "Unexpected task type [%v].", task.Type)
+	return QueryInfo{}, errors.Errorf(ErrUser, "Unexpected query type [%T].", query)
 }
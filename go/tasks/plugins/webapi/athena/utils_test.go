@@ -31,8 +31,9 @@ func Test_writeOutput(t *testing.T) {
 
 		statusContext := &mocks.StatusContext{}
 		statusContext.OnTaskReader().Return(taskReader)
+		statusContext.OnOutputWriter().Return(&mocks3.OutputWriter{})
 
-		err := writeOutput(context.Background(), statusContext, "s3://my-external-bucket/key")
+		err := writeOutput(context.Background(), statusContext, "s3://my-external-bucket/key", nil)
 		assert.NoError(t, err)
 	})
 
@@ -50,8 +51,9 @@ func Test_writeOutput(t *testing.T) {
 
 		statusContext := &mocks.StatusContext{}
 		statusContext.OnTaskReader().Return(taskReader)
+		statusContext.OnOutputWriter().Return(&mocks3.OutputWriter{})
 
-		err := writeOutput(context.Background(), statusContext, "s3://my-external-bucket/key")
+		err := writeOutput(context.Background(), statusContext, "s3://my-external-bucket/key", nil)
 		assert.NoError(t, err)
 	})
 
@@ -114,7 +116,7 @@ func Test_writeOutput(t *testing.T) {
 			}, nil)).Return(nil)
 		statusContext.OnOutputWriter().Return(ow)
 
-		err = writeOutput(context.Background(), statusContext, externalLocation)
+		err = writeOutput(context.Background(), statusContext, externalLocation, nil)
 		assert.NoError(t, err)
 	})
 }
@@ -201,3 +203,21 @@ func Test_ExtractQueryInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_toColumnType(t *testing.T) {
+	for athenaType, expected := range map[string]pb.SchemaType_SchemaColumn_SchemaColumnType{
+		"boolean":       pb.SchemaType_SchemaColumn_BOOLEAN,
+		"bigint":        pb.SchemaType_SchemaColumn_INTEGER,
+		"INTEGER":       pb.SchemaType_SchemaColumn_INTEGER,
+		"double":        pb.SchemaType_SchemaColumn_FLOAT,
+		"decimal(10,2)": pb.SchemaType_SchemaColumn_FLOAT,
+		"timestamp":     pb.SchemaType_SchemaColumn_DATETIME,
+		"interval":      pb.SchemaType_SchemaColumn_DURATION,
+		"varchar":       pb.SchemaType_SchemaColumn_STRING,
+		"array":         pb.SchemaType_SchemaColumn_STRING,
+	} {
+		t.Run(athenaType, func(t *testing.T) {
+			assert.Equal(t, expected, toColumnType(athenaType))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package bridge
+
+import (
+	"time"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flytestdlib/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+var (
+	defaultConfig = Config{
+		WebAPI: webapi.PluginConfig{
+			ResourceQuotas: map[core.ResourceNamespace]int{
+				"default": 1000,
+			},
+			ReadRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			WriteRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			Caching: webapi.CachingConfig{
+				Size:              500000,
+				ResyncInterval:    config.Duration{Duration: 30 * time.Second},
+				Workers:           10,
+				MaxSystemFailures: 5,
+				BatchSize:         10,
+			},
+			ResourceMeta: nil,
+		},
+
+		ResourceConstraints: core.ResourceConstraintsSpec{
+			ProjectScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 100,
+			},
+			NamespaceScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 50,
+			},
+		},
+
+		SupportedTaskTypes: []string{"bridge"},
+
+		DefaultServer: ServerConfig{
+			Endpoint: "dns:///flyte-plugin-server.flyte.svc.cluster.local:8000",
+			Insecure: true,
+			Timeout:  config.Duration{Duration: 10 * time.Second},
+		},
+	}
+
+	configSection = pluginsConfig.MustRegisterSubSection("bridge", &defaultConfig)
+)
+
+type Config struct {
+	WebAPI              webapi.PluginConfig          `json:"webApi" pflag:",Defines config for the base WebAPI plugin."`
+	ResourceConstraints core.ResourceConstraintsSpec `json:"resourceConstraints" pflag:"-,Defines resource constraints on how many executions to be created per project/overall at any given time."`
+
+	// The plugin handles these task types and the ones of the plugin servers configured per task type.
+	SupportedTaskTypes []string `json:"supportedTaskTypes" pflag:",Defines the task types forwarded to the default plugin server."`
+
+	DefaultServer ServerConfig            `json:"defaultServer" pflag:",Defines the plugin server tasks are forwarded to unless overridden for their task type."`
+	Servers       map[string]ServerConfig `json:"servers" pflag:"-,Defines plugin servers per task type, overriding the default server."`
+}
+
+// ServerConfig defines how to reach an out-of-process plugin server implementing the PluginService.
+type ServerConfig struct {
+	Endpoint string          `json:"endpoint" pflag:",Defines the gRPC target of the plugin server (e.g. dns:///plugin-server:8000)."`
+	Insecure bool            `json:"insecure" pflag:",Defines whether to connect to the plugin server without TLS."`
+	Timeout  config.Duration `json:"timeout" pflag:",Defines the timeout of each call to the plugin server."`
+}
+
+func GetConfig() *Config {
+	return configSection.GetConfig().(*Config)
+}
@@ -0,0 +1,64 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by robots.
+
+package bridge
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// If v is a pointer, it will get its element value or the zero value of the element type.
+// If v is not a pointer, it will return it as is.
+func (Config) elemValueOrNil(v interface{}) interface{} {
+	if t := reflect.TypeOf(v); t.Kind() == reflect.Ptr {
+		if reflect.ValueOf(v).IsNil() {
+			return reflect.Zero(t.Elem()).Interface()
+		} else {
+			return reflect.ValueOf(v).Interface()
+		}
+	} else if v == nil {
+		return reflect.Zero(t).Interface()
+	}
+
+	return v
+}
+
+func (Config) mustMarshalJSON(v json.Marshaler) string {
+	raw, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return string(raw)
+}
+
+// GetPFlagSet will return strongly types pflags for all fields in Config and its nested types. The format of the
+// flags is json-name.json-sub-name... etc.
+func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
+	cmdFlags := pflag.NewFlagSet("Config", pflag.ExitOnError)
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.readRateLimiter.qps"), defaultConfig.WebAPI.ReadRateLimiter.QPS, "Defines the max rate of calls per second.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.readRateLimiter.burst"), defaultConfig.WebAPI.ReadRateLimiter.Burst, "Defines the maximum burst size.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.writeRateLimiter.qps"), defaultConfig.WebAPI.WriteRateLimiter.QPS, "Defines the max rate of calls per second.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.writeRateLimiter.burst"), defaultConfig.WebAPI.WriteRateLimiter.Burst, "Defines the maximum burst size.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.size"), defaultConfig.WebAPI.Caching.Size, "Defines the maximum number of items to cache.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.caching.resyncInterval"), defaultConfig.WebAPI.Caching.ResyncInterval.String(), "Defines the sync interval.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.workers"), defaultConfig.WebAPI.Caching.Workers, "Defines the number of workers to start up to process items.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.maxSystemFailures"), defaultConfig.WebAPI.Caching.MaxSystemFailures, "Defines the number of failures to fetch a task before failing the task.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.caching.batchSize"), defaultConfig.WebAPI.Caching.BatchSize, "Defines the maximum number of resources to retrieve in a single batched Get.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "webApi.circuitBreaker.enabled"), defaultConfig.WebAPI.CircuitBreaker.Enabled, "Defines whether the circuit breaker is enabled.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.circuitBreaker.failurePercentage"), defaultConfig.WebAPI.CircuitBreaker.FailurePercentage, "Defines the percentage of failed calls within a window at which the circuit opens.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "webApi.circuitBreaker.minRequests"), defaultConfig.WebAPI.CircuitBreaker.MinRequests, "Defines the minimum number of calls within a window before the failure percentage is evaluated.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.circuitBreaker.window"), defaultConfig.WebAPI.CircuitBreaker.Window.String(), "Defines the duration of the window in which calls are counted.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.circuitBreaker.openDuration"), defaultConfig.WebAPI.CircuitBreaker.OpenDuration.String(), "Defines how long the circuit stays open before a probe call is let through.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "webApi.resourceLeaseTTL"), defaultConfig.WebAPI.ResourceLeaseTTL.String(), "Defines how long allocation tokens are leased for. Leases are disabled if zero.")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "supportedTaskTypes"), []string{}, "Defines the task types forwarded to the default plugin server.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "defaultServer.endpoint"), defaultConfig.DefaultServer.Endpoint, "Defines the gRPC target of the plugin server (e.g. dns:///plugin-server:8000).")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "defaultServer.insecure"), defaultConfig.DefaultServer.Insecure, "Defines whether to connect to the plugin server without TLS.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "defaultServer.timeout"), defaultConfig.DefaultServer.Timeout.String(), "Defines the timeout of each call to the plugin server.")
+	return cmdFlags
+}
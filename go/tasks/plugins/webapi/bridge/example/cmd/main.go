@@ -0,0 +1,29 @@
+// Command cmd runs the reference plugin server. Point the bridge plugin's server endpoint at the address it listens on.
+package main
+
+import (
+	"context"
+	"flag"
+	"net"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/webapi/bridge/example"
+)
+
+func main() {
+	address := flag.String("address", ":8000", "The address to listen on.")
+	flag.Parse()
+
+	ctx := context.Background()
+	lis, err := net.Listen("tcp", *address)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to listen on [%v]. Error: %v", *address, err)
+	}
+
+	logger.Infof(ctx, "Serving plugin server on [%v].", lis.Addr())
+	if err = example.Serve(lis, example.NewServer(clock.RealClock{})); err != nil {
+		logger.Fatalf(ctx, "Plugin server failed. Error: %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+// Package example contains a reference PluginService server written in Go. It runs "sleep" tasks in memory: a task
+// waits for the duration set in the "sleep" field of its custom attributes (e.g. "30s") then succeeds and returns its
+// inputs as outputs. It's meant as a starting point for new plugin servers and as a fake server in tests.
+package example
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/clock"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/webapi/bridge/service"
+)
+
+type execution struct {
+	createdAt    time.Time
+	sleep        time.Duration
+	inputs       *idlCore.LiteralMap
+	deleteReason string
+}
+
+// Server implements service.PluginServiceServer. Executions are indexed by the generated name of the task execution,
+// which is also used as the opaque resource meta.
+type Server struct {
+	clock clock.Clock
+
+	m          sync.Mutex
+	executions map[string]*execution
+}
+
+func (s *Server) CreateTask(_ context.Context, req *service.CreateTaskRequest) (*service.CreateTaskResponse, error) {
+	if len(req.GeneratedName) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "generated name must not be empty")
+	}
+
+	sleep, err := getSleep(req.Template)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid sleep duration: %v", err)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	// CreateTask is idempotent, an execution that already exists is left untouched.
+	if _, found := s.executions[req.GeneratedName]; !found {
+		s.executions[req.GeneratedName] = &execution{
+			createdAt: s.clock.Now(),
+			sleep:     sleep,
+			inputs:    req.Inputs,
+		}
+	}
+
+	return &service.CreateTaskResponse{
+		ResourceMeta: []byte(req.GeneratedName),
+	}, nil
+}
+
+func (s *Server) GetTask(_ context.Context, req *service.GetTaskRequest) (*service.GetTaskResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	exec, found := s.executions[string(req.ResourceMeta)]
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "execution [%s] not found", req.ResourceMeta)
+	}
+
+	resource := &service.Resource{}
+	switch {
+	case len(exec.deleteReason) > 0:
+		resource.Phase = idlCore.TaskExecution_FAILED
+		resource.Message = "Execution was deleted: " + exec.deleteReason
+		resource.ErrorCode = "Deleted"
+		resource.Retryable = true
+	case s.clock.Since(exec.createdAt) < exec.sleep:
+		resource.Phase = idlCore.TaskExecution_RUNNING
+	default:
+		resource.Phase = idlCore.TaskExecution_SUCCEEDED
+		resource.Outputs = exec.inputs
+	}
+
+	return &service.GetTaskResponse{Resource: resource}, nil
+}
+
+func (s *Server) DeleteTask(_ context.Context, req *service.DeleteTaskRequest) (*service.DeleteTaskResponse, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if exec, found := s.executions[string(req.ResourceMeta)]; found {
+		exec.deleteReason = req.Reason
+	}
+
+	return &service.DeleteTaskResponse{}, nil
+}
+
+func getSleep(template *idlCore.TaskTemplate) (time.Duration, error) {
+	sleep := template.GetCustom().GetFields()["sleep"].GetStringValue()
+	if len(sleep) == 0 {
+		return 0, nil
+	}
+
+	return time.ParseDuration(sleep)
+}
+
+// Serve registers the server on a new gRPC server and serves requests from lis until it fails.
+func Serve(lis net.Listener, s *Server) error {
+	grpcServer := grpc.NewServer()
+	service.RegisterPluginServiceServer(grpcServer, s)
+	return grpcServer.Serve(lis)
+}
+
+func NewServer(c clock.Clock) *Server {
+	return &Server{
+		clock:      c,
+		executions: map[string]*execution{},
+	}
+}
@@ -0,0 +1,329 @@
+// Package bridge implements a generic webapi.AsyncPlugin that forwards the lifecycle of tasks to out-of-process plugin
+// servers over gRPC. Plugin servers implement the PluginService defined in the service package and can be written in
+// any language and deployed independently of Propeller. The server to forward a task to is chosen by task type.
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	errors2 "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/webapi/bridge/service"
+)
+
+const (
+	ErrRemoteSystem errors.ErrorCode = "RemoteSystem"
+	ErrRemoteUser   errors.ErrorCode = "RemoteUser"
+	ErrSystem       errors.ErrorCode = "System"
+)
+
+// ResourceMetaWrapper is the ResourceMeta persisted for each task. The opaque metadata returned by the plugin server
+// is kept alongside the task type so that later calls are routed to the same server.
+type ResourceMetaWrapper struct {
+	TaskType     string
+	ResourceMeta []byte
+}
+
+type server struct {
+	client  service.PluginServiceClient
+	timeout time.Duration
+}
+
+// withTimeout bounds a call to the plugin server by the configured timeout, if any.
+func (s server) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, s.timeout)
+}
+
+type Plugin struct {
+	metricScope   promutils.Scope
+	cfg           *Config
+	defaultServer server
+	// Plugin servers overriding the default one, indexed by task type.
+	servers map[string]server
+}
+
+// dialer opens a connection to a plugin server.
+type dialer func(cfg ServerConfig) (*grpc.ClientConn, error)
+
+func (p Plugin) GetConfig() webapi.PluginConfig {
+	cfg := GetConfig().WebAPI
+	cfg.ResourceMeta = ResourceMetaWrapper{}
+	return cfg
+}
+
+func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionContextReader) (
+	namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error) {
+
+	// Resource requirements are assumed to be the same.
+	return "default", p.cfg.ResourceConstraints, nil
+}
+
+func (p Plugin) getServer(taskType string) server {
+	if s, found := p.servers[taskType]; found {
+		return s
+	}
+
+	return p.defaultServer
+}
+
+// ValidateTask checks that tasks of this type are forwarded to a plugin server and that the task interface declares
+// the type of every input and output, since the plugin server reads the inputs and produces the outputs based on them.
+func (p Plugin) ValidateTask(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	_, found := p.servers[taskTemplate.GetType()]
+	for _, taskType := range p.cfg.SupportedTaskTypes {
+		found = found || taskType == taskTemplate.GetType()
+	}
+
+	if !found {
+		return errors.Errorf(errors2.BadTaskSpecification, "Task type [%v] isn't forwarded to any plugin server.",
+			taskTemplate.GetType())
+	}
+
+	for name, variable := range taskTemplate.GetInterface().GetInputs().GetVariables() {
+		if variable.GetType() == nil {
+			return errors.Errorf(errors2.BadTaskSpecification, "Input [%v] doesn't declare a type.", name)
+		}
+	}
+
+	for name, variable := range taskTemplate.GetInterface().GetOutputs().GetVariables() {
+		if variable.GetType() == nil {
+			return errors.Errorf(errors2.BadTaskSpecification, "Output [%v] doesn't declare a type.", name)
+		}
+	}
+
+	return nil
+}
+
+func (p Plugin) Create(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (resourceMeta webapi.ResourceMeta,
+	resource webapi.Resource, err error) {
+
+	taskTemplate, err := tCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputs, err := tCtx.InputReader().Get(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taskExecutionID := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+	s := p.getServer(taskTemplate.Type)
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := s.client.CreateTask(ctx, &service.CreateTaskRequest{
+		TaskType:        taskTemplate.Type,
+		Template:        taskTemplate,
+		Inputs:          inputs,
+		OutputPrefix:    tCtx.OutputWriter().GetOutputPrefixPath().String(),
+		TaskExecutionId: &taskExecutionID,
+		GeneratedName:   tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(),
+	})
+
+	if err != nil {
+		// The server rejected the task itself, retrying won't help.
+		if code := status.Code(err); code == codes.InvalidArgument || code == codes.FailedPrecondition {
+			return nil, &service.Resource{
+				Phase:   idlCore.TaskExecution_FAILED,
+				Message: status.Convert(err).Message(),
+			}, nil
+		}
+
+		return nil, nil, err
+	}
+
+	return ResourceMetaWrapper{
+		TaskType:     taskTemplate.Type,
+		ResourceMeta: resp.ResourceMeta,
+	}, nil, nil
+}
+
+func (p Plugin) Get(ctx context.Context, tCtx webapi.GetContext) (latest webapi.Resource, err error) {
+	meta := tCtx.ResourceMeta().(ResourceMetaWrapper)
+	s := p.getServer(meta.TaskType)
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := s.client.GetTask(ctx, &service.GetTaskRequest{
+		TaskType:     meta.TaskType,
+		ResourceMeta: meta.ResourceMeta,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Resource == nil {
+		return nil, errors.Errorf(ErrRemoteSystem, "Plugin server returned an empty resource.")
+	}
+
+	return resp.Resource, nil
+}
+
+func (p Plugin) Delete(ctx context.Context, tCtx webapi.DeleteContext) error {
+	if tCtx.ResourceMeta() == nil {
+		return nil
+	}
+
+	meta := tCtx.ResourceMeta().(ResourceMetaWrapper)
+	s := p.getServer(meta.TaskType)
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	_, err := s.client.DeleteTask(ctx, &service.DeleteTaskRequest{
+		TaskType:     meta.TaskType,
+		ResourceMeta: meta.ResourceMeta,
+		Reason:       tCtx.Reason(),
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "Deleted task of type [%v] on plugin server.", meta.TaskType)
+	return nil
+}
+
+func (p Plugin) Status(ctx context.Context, tCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
+	resource := tCtx.Resource().(*service.Resource)
+	taskInfo := createTaskInfo(resource)
+
+	// The phase is reported by the plugin server in GetTask, mapping it doesn't call the server.
+	switch resource.Phase {
+	case idlCore.TaskExecution_QUEUED:
+		return core.PhaseInfoQueued(time.Now(), resource.PhaseVersion, resource.Message), nil
+	case idlCore.TaskExecution_WAITING_FOR_RESOURCES:
+		return core.PhaseInfoWaitingForResources(time.Now(), resource.PhaseVersion, resource.Message), nil
+	case idlCore.TaskExecution_INITIALIZING:
+		return core.PhaseInfoInitializing(time.Now(), resource.PhaseVersion, resource.Message, taskInfo), nil
+	case idlCore.TaskExecution_RUNNING:
+		return core.PhaseInfoRunning(resource.PhaseVersion, taskInfo), nil
+	case idlCore.TaskExecution_FAILED:
+		code := resource.ErrorCode
+		if len(code) == 0 {
+			code = string(ErrRemoteUser)
+		}
+
+		if resource.Retryable {
+			return core.PhaseInfoRetryableFailure(code, resource.Message, taskInfo), nil
+		}
+
+		return core.PhaseInfoFailure(code, resource.Message, taskInfo), nil
+	case idlCore.TaskExecution_SUCCEEDED:
+		// Servers that wrote the outputs under the output prefix themselves return no outputs.
+		if resource.Outputs != nil {
+			err = tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(resource.Outputs, nil))
+			if err != nil {
+				logger.Warnf(ctx, "Failed to write outputs, err %s", err.Error())
+				return core.PhaseInfoUndefined, err
+			}
+		}
+
+		return core.PhaseInfoSuccess(taskInfo), nil
+	}
+
+	return core.PhaseInfoUndefined, errors.Errorf(ErrSystem, "Plugin server returned an invalid phase [%v].", resource.Phase)
+}
+
+func createTaskInfo(resource *service.Resource) *core.TaskInfo {
+	timeNow := time.Now()
+	return &core.TaskInfo{
+		OccurredAt: &timeNow,
+		Logs:       resource.Logs,
+	}
+}
+
+func dial(cfg ServerConfig) (*grpc.ClientConn, error) {
+	creds := grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, ""))
+	if cfg.Insecure {
+		creds = grpc.WithInsecure()
+	}
+
+	// Dial doesn't block; the connection is established in the background and re-established as needed.
+	return grpc.Dial(cfg.Endpoint, creds)
+}
+
+func newServer(cfg ServerConfig, d dialer) (server, error) {
+	conn, err := d(cfg)
+	if err != nil {
+		return server{}, fmt.Errorf("failed to connect to plugin server [%v]. Error: %w", cfg.Endpoint, err)
+	}
+
+	return server{
+		client:  service.NewPluginServiceClient(conn),
+		timeout: cfg.Timeout.Duration,
+	}, nil
+}
+
+func newPlugin(cfg *Config, metricScope promutils.Scope, d dialer) (Plugin, error) {
+	defaultServer, err := newServer(cfg.DefaultServer, d)
+	if err != nil {
+		return Plugin{}, err
+	}
+
+	servers := make(map[string]server, len(cfg.Servers))
+	for taskType, serverCfg := range cfg.Servers {
+		servers[taskType], err = newServer(serverCfg, d)
+		if err != nil {
+			return Plugin{}, err
+		}
+	}
+
+	return Plugin{
+		metricScope:   metricScope,
+		cfg:           cfg,
+		defaultServer: defaultServer,
+		servers:       servers,
+	}, nil
+}
+
+func NewPlugin(_ context.Context, cfg *Config, metricScope promutils.Scope) (Plugin, error) {
+	return newPlugin(cfg, metricScope, dial)
+}
+
+// loadPluginEntries returns the plugin entry handling the task types forwarded to the default plugin server and the
+// ones of the plugin servers configured per task type, if any.
+func loadPluginEntries(_ context.Context) ([]webapi.PluginEntry, error) {
+	cfg := GetConfig()
+	taskTypes := sets.NewString(cfg.SupportedTaskTypes...)
+	for taskType := range cfg.Servers {
+		taskTypes.Insert(taskType)
+	}
+
+	if taskTypes.Len() == 0 {
+		return nil, nil
+	}
+
+	return []webapi.PluginEntry{
+		{
+			ID:                 "grpc-bridge",
+			SupportedTaskTypes: taskTypes.List(),
+			PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+				return NewPlugin(ctx, GetConfig(), iCtx.MetricsScope())
+			},
+		},
+	}, nil
+}
+
+func init() {
+	// The task types are only known once the config is loaded.
+	pluginmachinery.PluginRegistry().RegisterRemotePluginLoader(loadPluginEntries)
+}
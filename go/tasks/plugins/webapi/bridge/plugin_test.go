@@ -0,0 +1,268 @@
+package bridge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/promutils"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	testing2 "k8s.io/utils/clock/testing"
+
+	errors2 "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/webapitest"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/webapi/bridge/example"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/webapi/bridge/service"
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/webapi/bridge/testserver"
+)
+
+func newTestPlugin(t *testing.T, cfg *Config, servers map[string]*testserver.Server) Plugin {
+	p, err := newPlugin(cfg, promutils.NewTestScope(), func(serverCfg ServerConfig) (*grpc.ClientConn, error) {
+		return servers[serverCfg.Endpoint].Dial(context.Background())
+	})
+	assert.NoError(t, err)
+	return p
+}
+
+func newTaskExecutionContext(taskType, sleep string, inputs *idlCore.LiteralMap) *mocks.TaskExecutionContextReader {
+	return webapitest.NewTaskExecutionContext(&idlCore.TaskTemplate{
+		Type: taskType,
+		Custom: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"sleep": {Kind: &structpb.Value_StringValue{StringValue: sleep}},
+		}},
+	}, inputs)
+}
+
+func newTestConfig() *Config {
+	cfg := defaultConfig
+	cfg.DefaultServer = ServerConfig{Endpoint: "default", Timeout: config.Duration{Duration: 10 * time.Second}}
+	return &cfg
+}
+
+func TestPlugin(t *testing.T) {
+	ctx := context.Background()
+	clck := testing2.NewFakeClock(time.Now())
+	ts := testserver.Start(example.NewServer(clck))
+	defer ts.Stop()
+
+	p := newTestPlugin(t, newTestConfig(), map[string]*testserver.Server{"default": ts})
+	inputs, err := utils.MakeLiteral(1)
+	assert.NoError(t, err)
+	inputMap := &idlCore.LiteralMap{Literals: map[string]*idlCore.Literal{"x": inputs}}
+
+	t.Run("Succeeded", func(t *testing.T) {
+		tCtx := newTaskExecutionContext("bridge", "1m", inputMap)
+		resourceMeta, resource, err := p.Create(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Nil(t, resource)
+		assert.Equal(t, ResourceMetaWrapper{TaskType: "bridge", ResourceMeta: []byte("my-id")}, resourceMeta)
+
+		latest, err := p.Get(ctx, webapitest.NewGetContext(resourceMeta, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, idlCore.TaskExecution_RUNNING, latest.(*service.Resource).Phase)
+
+		clck.Step(time.Minute)
+		latest, err = p.Get(ctx, webapitest.NewGetContext(resourceMeta, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, idlCore.TaskExecution_SUCCEEDED, latest.(*service.Resource).Phase)
+
+		ow := &ioMocks.OutputWriter{}
+		ow.OnPutMatch(ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			outputs, _, err := args.Get(1).(io.OutputReader).Read(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), outputs.Literals["x"].GetScalar().GetPrimitive().GetInteger())
+		})
+
+		sCtx := &mocks.StatusContext{}
+		sCtx.OnResource().Return(latest)
+		sCtx.OnOutputWriter().Return(ow)
+		phase, err := p.Status(ctx, sCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseSuccess, phase.Phase())
+		ow.AssertNumberOfCalls(t, "Put", 1)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		tCtx := newTaskExecutionContext("bridge", "not-a-duration", inputMap)
+		resourceMeta, resource, err := p.Create(ctx, tCtx)
+		assert.NoError(t, err)
+		assert.Nil(t, resourceMeta)
+
+		sCtx := &mocks.StatusContext{}
+		sCtx.OnResource().Return(resource)
+		phase, err := p.Status(ctx, sCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhasePermanentFailure, phase.Phase())
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		resourceMeta := ResourceMetaWrapper{TaskType: "bridge", ResourceMeta: []byte("my-id")}
+		dCtx := &mocks.DeleteContext{}
+		dCtx.OnResourceMeta().Return(resourceMeta)
+		dCtx.OnReason().Return("Aborted")
+		assert.NoError(t, p.Delete(ctx, dCtx))
+
+		latest, err := p.Get(ctx, webapitest.NewGetContext(resourceMeta, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, idlCore.TaskExecution_FAILED, latest.(*service.Resource).Phase)
+
+		sCtx := &mocks.StatusContext{}
+		sCtx.OnResource().Return(latest)
+		phase, err := p.Status(ctx, sCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRetryableFailure, phase.Phase())
+		assert.Equal(t, "Deleted", phase.Err().GetCode())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := p.Get(ctx, webapitest.NewGetContext(ResourceMetaWrapper{TaskType: "bridge", ResourceMeta: []byte("unknown")}, nil))
+		assert.Error(t, err)
+	})
+}
+
+func TestPlugin_Routing(t *testing.T) {
+	ctx := context.Background()
+	clck := testing2.NewFakeClock(time.Now())
+	defaultServer := example.NewServer(clck)
+	specialServer := example.NewServer(clck)
+	servers := map[string]*testserver.Server{
+		"default": testserver.Start(defaultServer),
+		"special": testserver.Start(specialServer),
+	}
+
+	defer servers["default"].Stop()
+	defer servers["special"].Stop()
+
+	cfg := newTestConfig()
+	cfg.Servers = map[string]ServerConfig{"special": {Endpoint: "special"}}
+	p := newTestPlugin(t, cfg, servers)
+
+	resourceMeta, _, err := p.Create(ctx, newTaskExecutionContext("special", "", nil))
+	assert.NoError(t, err)
+
+	_, err = p.Get(ctx, webapitest.NewGetContext(resourceMeta, nil))
+	assert.NoError(t, err)
+
+	// The task only exists on the server configured for its task type.
+	_, err = p.Get(ctx, webapitest.NewGetContext(ResourceMetaWrapper{TaskType: "bridge", ResourceMeta: []byte("my-id")}, nil))
+	assert.Error(t, err)
+}
+
+func TestLoadPluginEntries(t *testing.T) {
+	ctx := context.Background()
+	previous := *GetConfig()
+	defer func() {
+		assert.NoError(t, configSection.SetConfig(&previous))
+	}()
+
+	cfg := defaultConfig
+	cfg.SupportedTaskTypes = nil
+	assert.NoError(t, configSection.SetConfig(&cfg))
+	entries, err := loadPluginEntries(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	cfg.SupportedTaskTypes = []string{"bridge", "special"}
+	cfg.Servers = map[string]ServerConfig{"special": {Endpoint: "special"}, "other": {Endpoint: "other"}}
+	assert.NoError(t, configSection.SetConfig(&cfg))
+	entries, err = loadPluginEntries(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "grpc-bridge", entries[0].ID)
+	assert.Equal(t, []core.TaskType{"bridge", "other", "special"}, entries[0].SupportedTaskTypes)
+}
+
+func TestPlugin_ValidateTask(t *testing.T) {
+	ctx := context.Background()
+	ts := testserver.Start(example.NewServer(testing2.NewFakeClock(time.Now())))
+	defer ts.Stop()
+
+	cfg := newTestConfig()
+	cfg.Servers = map[string]ServerConfig{"special": {Endpoint: "default"}}
+	p := newTestPlugin(t, cfg, map[string]*testserver.Server{"default": ts})
+
+	newTaskTemplate := func(taskType string, outputType *idlCore.LiteralType) *idlCore.TaskTemplate {
+		return &idlCore.TaskTemplate{
+			Type: taskType,
+			Interface: &idlCore.TypedInterface{
+				Outputs: &idlCore.VariableMap{Variables: map[string]*idlCore.Variable{"y": {Type: outputType}}},
+			},
+		}
+	}
+
+	integerType := &idlCore.LiteralType{Type: &idlCore.LiteralType_Simple{Simple: idlCore.SimpleType_INTEGER}}
+	assert.NoError(t, p.ValidateTask(ctx, newTaskTemplate("bridge", integerType)))
+	assert.NoError(t, p.ValidateTask(ctx, newTaskTemplate("special", integerType)))
+
+	for name, taskTemplate := range map[string]*idlCore.TaskTemplate{
+		"Unknown task type": newTaskTemplate("other", integerType),
+		"Untyped output":    newTaskTemplate("bridge", nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := p.ValidateTask(ctx, taskTemplate)
+			assert.Error(t, err)
+			code, found := errors.GetErrorCode(err)
+			assert.True(t, found)
+			assert.Equal(t, errors2.BadTaskSpecification, code)
+		})
+	}
+}
+
+func TestPlugin_Status(t *testing.T) {
+	ctx := context.Background()
+	p := Plugin{}
+	getStatus := func(resource *service.Resource) (core.PhaseInfo, error) {
+		sCtx := &mocks.StatusContext{}
+		sCtx.OnResource().Return(resource)
+		return p.Status(ctx, sCtx)
+	}
+
+	for _, test := range []struct {
+		phase    idlCore.TaskExecution_Phase
+		expected core.Phase
+	}{
+		{idlCore.TaskExecution_QUEUED, core.PhaseQueued},
+		{idlCore.TaskExecution_WAITING_FOR_RESOURCES, core.PhaseWaitingForResources},
+		{idlCore.TaskExecution_INITIALIZING, core.PhaseInitializing},
+		{idlCore.TaskExecution_RUNNING, core.PhaseRunning},
+		{idlCore.TaskExecution_SUCCEEDED, core.PhaseSuccess},
+		{idlCore.TaskExecution_FAILED, core.PhasePermanentFailure},
+	} {
+		t.Run(test.phase.String(), func(t *testing.T) {
+			phase, err := getStatus(&service.Resource{Phase: test.phase, PhaseVersion: 2})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, phase.Phase())
+		})
+	}
+
+	t.Run("Retryable failure", func(t *testing.T) {
+		phase, err := getStatus(&service.Resource{
+			Phase:     idlCore.TaskExecution_FAILED,
+			ErrorCode: "OutOfMemory",
+			Message:   "the task ran out of memory",
+			Retryable: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRetryableFailure, phase.Phase())
+		assert.Equal(t, "OutOfMemory", phase.Err().GetCode())
+		assert.Equal(t, "the task ran out of memory", phase.Err().GetMessage())
+	})
+
+	for _, invalid := range []idlCore.TaskExecution_Phase{idlCore.TaskExecution_UNDEFINED, idlCore.TaskExecution_ABORTED} {
+		t.Run(invalid.String(), func(t *testing.T) {
+			_, err := getStatus(&service.Resource{Phase: invalid})
+			assert.Error(t, err)
+		})
+	}
+}
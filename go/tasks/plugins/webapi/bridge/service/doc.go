@@ -0,0 +1,8 @@
+// Package service defines the PluginService implemented by out-of-process plugin servers.
+//
+// plugin_service.pb.go is generated from go/tasks/plugins/webapi with:
+//
+//	protoc -I. -I<flyteidl>/protos --go_out=plugins=grpc,paths=source_relative:. bridge/service/plugin_service.proto
+//
+// using protoc-gen-go from github.com/golang/protobuf v1.4.3.
+package service
@@ -0,0 +1,50 @@
+// Package testserver runs a PluginService server in-process for tests. Connections go through an in-memory listener so
+// no network port is opened.
+package testserver
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/plugins/webapi/bridge/service"
+)
+
+const bufferSize = 1024 * 1024
+
+type Server struct {
+	listener *bufconn.Listener
+	server   *grpc.Server
+}
+
+// Dial opens a client connection to the server.
+func (s *Server) Dial(ctx context.Context) (*grpc.ClientConn, error) {
+	return grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return s.listener.Dial()
+		}),
+		grpc.WithInsecure())
+}
+
+// Stop stops the server and closes all open connections.
+func (s *Server) Stop() {
+	s.server.Stop()
+}
+
+// Start serves impl in the background until Stop is called.
+func Start(impl service.PluginServiceServer) *Server {
+	s := &Server{
+		listener: bufconn.Listen(bufferSize),
+		server:   grpc.NewServer(),
+	}
+
+	service.RegisterPluginServiceServer(s.server, impl)
+	go func() {
+		// Serve only returns once the server is stopped.
+		_ = s.server.Serve(s.listener)
+	}()
+
+	return s
+}
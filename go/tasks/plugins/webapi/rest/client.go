@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/flyteorg/flytestdlib/logger"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+const (
+	headerContentType = "Content-Type"
+	contentTypeJSON   = "application/json"
+)
+
+var secretRegex = regexp.MustCompile(`(?i){{\s*[\.$]Secrets\.(?P<secret_key>[^}\s]+)\s*}}`)
+
+// Request is a rendered request to the job service.
+type Request struct {
+	Method string
+	URL    string
+	Body   string
+}
+
+// statusError is returned when the job service responds with a non-2xx status code.
+type statusError struct {
+	statusCode int
+	body       string
+}
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("job service responded with status [%v]: %v", e.statusCode, e.body)
+}
+
+// isClientError reports whether the job service rejected the request itself, in which case retrying won't help.
+func isClientError(err error) bool {
+	if e, ok := err.(statusError); ok {
+		return e.statusCode >= 400 && e.statusCode < 500
+	}
+
+	return false
+}
+
+// renderHeaders evaluates the secrets referenced in the configured header values.
+func renderHeaders(ctx context.Context, secretManager core.SecretManager, headers map[string]string) (http.Header,
+	error) {
+	res := make(http.Header, len(headers)+1)
+	res.Set(headerContentType, contentTypeJSON)
+	for name, value := range headers {
+		var err error
+		value = secretRegex.ReplaceAllStringFunc(value, func(s string) string {
+			key := secretRegex.FindStringSubmatch(s)[1]
+			secret, getErr := getSecret(ctx, secretManager, key)
+			if getErr != nil && err == nil {
+				err = fmt.Errorf("failed to get secret [%v] for header [%v]: %w", key, name, getErr)
+			}
+
+			return secret
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		res.Set(name, value)
+	}
+
+	return res, nil
+}
+
+// getSecret retrieves the secret referenced as [group/]key[@version].
+func getSecret(ctx context.Context, secretManager core.SecretManager, key string) (string, error) {
+	ref, err := core.ParseSecretReference(key)
+	if err != nil {
+		return "", err
+	}
+
+	return core.GetSecret(ctx, secretManager, ref)
+}
+
+// do sends the request to the job service and decodes its JSON response.
+func do(ctx context.Context, client *http.Client, secretManager core.SecretManager, cfg IntegrationConfig,
+	r Request) (response interface{}, err error) {
+
+	if cfg.Timeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout.Duration)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, strings.NewReader(r.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header, err = renderHeaders(ctx, secretManager, cfg.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeBody(ctx, resp)
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, statusError{statusCode: resp.StatusCode, body: string(body)}
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+
+	// Keep numbers as they were sent, integers would otherwise lose precision as float64.
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err = decoder.Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response of [%v %v]: %w", r.Method, r.URL, err)
+	}
+
+	return response, nil
+}
+
+func closeBody(ctx context.Context, response *http.Response) {
+	_, err := io.Copy(ioutil.Discard, response.Body)
+	if err != nil {
+		logger.Errorf(ctx, "unexpected failure writing to devNull: %v", err)
+	}
+
+	err = response.Body.Close()
+	if err != nil {
+		logger.Warnf(ctx, "failure closing response body: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	pluginsConfig "github.com/flyteorg/flyteplugins/go/tasks/config"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+	"github.com/flyteorg/flytestdlib/config"
+)
+
+//go:generate pflags Config --default-var=defaultConfig
+
+var (
+	defaultConfig = Config{
+		WebAPI: webapi.PluginConfig{
+			ResourceQuotas: map[core.ResourceNamespace]int{
+				"default": 1000,
+			},
+			ReadRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			WriteRateLimiter: webapi.RateLimiterConfig{
+				Burst: 100,
+				QPS:   10,
+			},
+			Caching: webapi.CachingConfig{
+				Size:              500000,
+				ResyncInterval:    config.Duration{Duration: 30 * time.Second},
+				Workers:           10,
+				MaxSystemFailures: 5,
+				BatchSize:         10,
+			},
+			ResourceMeta: nil,
+		},
+
+		ResourceConstraints: core.ResourceConstraintsSpec{
+			ProjectScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 100,
+			},
+			NamespaceScopeResourceConstraint: &core.ResourceConstraint{
+				Value: 50,
+			},
+		},
+	}
+
+	configSection = pluginsConfig.MustRegisterSubSection("rest", &defaultConfig)
+)
+
+type Config struct {
+	WebAPI              webapi.PluginConfig          `json:"webApi" pflag:",Defines config for the base WebAPI plugin."`
+	ResourceConstraints core.ResourceConstraintsSpec `json:"resourceConstraints" pflag:"-,Defines resource constraints on how many executions to be created per project/overall at any given time."`
+
+	// The plugin handles the task types of the configured integrations.
+	Integrations map[string]IntegrationConfig `json:"integrations" pflag:"-,Defines how to interact with the job service of each task type."`
+}
+
+// IntegrationConfig defines how to submit, poll and cancel the jobs of a task type.
+//
+// URLs and bodies are templates evaluated when the job is submitted. They support the variables of the core/template
+// package (e.g. {{ .Inputs.myInput }}, {{ .OutputPrefix }} or {{ .PerRetryUniqueKey }}). The Get and Delete requests
+// additionally support {{ .ResourceMeta }}, the job identifier extracted from the Create response.
+type IntegrationConfig struct {
+	// Headers sent with every request. Values may reference secrets as {{ .Secrets.myKey }}, which are retrieved through
+	// the SecretManager on each call. Secrets can be referenced by group and version as {{ .Secrets.group/key@version }}.
+	Headers map[string]string `json:"headers"`
+
+	// The timeout of each request. No timeout is enforced if it's zero.
+	Timeout config.Duration `json:"timeout"`
+
+	// The request submitting the job. It defaults to a POST.
+	Create RequestConfig `json:"create"`
+
+	// The request retrieving the state of the job. It defaults to a GET.
+	Get RequestConfig `json:"get"`
+
+	// The request cancelling the job. It defaults to a DELETE. Jobs can't be cancelled if the URL is empty.
+	Delete RequestConfig `json:"delete"`
+
+	// Defines how to extract the relevant fields from the responses.
+	Response ResponseConfig `json:"response"`
+}
+
+type RequestConfig struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body"`
+}
+
+// ResponseConfig holds JSONPath expressions (e.g. {.status.state}) evaluated against the JSON responses of the job
+// service.
+type ResponseConfig struct {
+	// Extracts the job identifier from the Create response.
+	ResourceMeta string `json:"resourceMeta"`
+
+	// Extracts the state of the job from the Get response.
+	Phase string `json:"phase"`
+
+	// Extracts a human-readable message from the Get response, e.g. the reason of a failure. Optional.
+	Message string `json:"message"`
+
+	// Extracts the outputs of a succeeded job from the Get response, indexed by output name. Values are converted to
+	// the type declared in the task interface.
+	Outputs map[string]string `json:"outputs"`
+
+	// Maps the states returned by the job service to Flyte phases. Unknown states are considered running.
+	PhaseValues PhaseValues `json:"phaseValues"`
+}
+
+type PhaseValues struct {
+	Queued          []string `json:"queued"`
+	Succeeded       []string `json:"succeeded"`
+	Failed          []string `json:"failed"`
+	RetryableFailed []string `json:"retryableFailed"`
+}
+
+func (r RequestConfig) method(defaultMethod string) string {
+	if len(r.Method) == 0 {
+		return defaultMethod
+	}
+
+	return r.Method
+}
+
+func (c IntegrationConfig) createMethod() string {
+	return c.Create.method(http.MethodPost)
+}
+
+func (c IntegrationConfig) getMethod() string {
+	return c.Get.method(http.MethodGet)
+}
+
+func (c IntegrationConfig) deleteMethod() string {
+	return c.Delete.method(http.MethodDelete)
+}
+
+func GetConfig() *Config {
+	return configSection.GetConfig().(*Config)
+}
@@ -0,0 +1,466 @@
+// Package rest implements a webapi.AsyncPlugin for job services that follow the common REST pattern: a POST submits a
+// job, a GET polls its state and a DELETE cancels it. The interaction with each job service is entirely defined in
+// config, per task type, so integrating a new service requires no code.
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"time"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
+
+	errors2 "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/template"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/ioutils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi"
+)
+
+const (
+	ErrRemoteSystem errors.ErrorCode = "RemoteSystem"
+	ErrRemoteUser   errors.ErrorCode = "RemoteUser"
+	ErrSystem       errors.ErrorCode = "System"
+)
+
+var resourceMetaRegex = regexp.MustCompile(`(?i){{\s*[\.$]ResourceMeta\s*}}`)
+
+// ResourceMetaWrapper is the ResourceMeta persisted for each task. The Get and Delete requests are rendered when the job
+// is submitted since the task context isn't available later.
+type ResourceMetaWrapper struct {
+	TaskType string
+	ID       string
+	Get      Request
+	Delete   Request
+}
+
+// ResourceWrapper holds the fields extracted from the latest Get response.
+type ResourceWrapper struct {
+	Phase   core.Phase
+	Message string
+	// Raw output values indexed by output name. They're only extracted once the job succeeded.
+	Outputs map[string]string
+}
+
+type Plugin struct {
+	metricScope   promutils.Scope
+	cfg           *Config
+	client        *http.Client
+	secretManager core.SecretManager
+}
+
+func (p Plugin) GetConfig() webapi.PluginConfig {
+	cfg := GetConfig().WebAPI
+	cfg.ResourceMeta = ResourceMetaWrapper{}
+	return cfg
+}
+
+func (p Plugin) ResourceRequirements(_ context.Context, _ webapi.TaskExecutionContextReader) (
+	namespace core.ResourceNamespace, constraints core.ResourceConstraintsSpec, err error) {
+
+	// Resource requirements are assumed to be the same.
+	return "default", p.cfg.ResourceConstraints, nil
+}
+
+func (p Plugin) getIntegration(taskType string) (IntegrationConfig, error) {
+	integration, found := p.cfg.Integrations[taskType]
+	if !found {
+		return IntegrationConfig{}, errors.Errorf(ErrSystem, "No integration is configured for task type [%v].",
+			taskType)
+	}
+
+	return integration, nil
+}
+
+// ValidateTask checks that an integration is configured for the task type, that the inputs its requests reference are
+// declared in the task interface and that the outputs it reads are declared with a supported type.
+func (p Plugin) ValidateTask(_ context.Context, taskTemplate *idlCore.TaskTemplate) error {
+	integration, found := p.cfg.Integrations[taskTemplate.GetType()]
+	if !found {
+		return errors.Errorf(errors2.BadTaskSpecification, "No integration is configured for task type [%v].",
+			taskTemplate.GetType())
+	}
+
+	inputs := taskTemplate.GetInterface().GetInputs().GetVariables()
+	for _, t := range []string{
+		integration.Create.URL, integration.Create.Body,
+		integration.Get.URL, integration.Get.Body,
+		integration.Delete.URL, integration.Delete.Body,
+	} {
+		for _, name := range template.InputNames(t) {
+			if _, found := inputs[name]; !found {
+				return errors.Errorf(errors2.BadTaskSpecification,
+					"Input [%v] is referenced by the requests of task type [%v] but isn't declared in the task interface.",
+					name, taskTemplate.GetType())
+			}
+		}
+	}
+
+	outputs := taskTemplate.GetInterface().GetOutputs().GetVariables()
+	for name := range integration.Response.Outputs {
+		variable, found := outputs[name]
+		if !found {
+			return errors.Errorf(errors2.BadTaskSpecification, "Output [%v] isn't declared in the task interface.",
+				name)
+		}
+
+		if !isSupportedOutputType(variable.GetType()) {
+			return errors.Errorf(errors2.BadTaskSpecification, "Output [%v] has an unsupported type [%v].", name,
+				variable.GetType())
+		}
+	}
+
+	return nil
+}
+
+func (p Plugin) Create(ctx context.Context, tCtx webapi.TaskExecutionContextReader) (resourceMeta webapi.ResourceMeta,
+	resource webapi.Resource, err error) {
+
+	taskTemplate, err := tCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	integration, err := p.getIntegration(taskTemplate.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rendered, err := template.Render(ctx, []string{
+		integration.Create.URL, integration.Create.Body,
+		integration.Get.URL, integration.Get.Body,
+		integration.Delete.URL, integration.Delete.Body,
+	}, template.Parameters{
+		TaskExecMetadata: tCtx.TaskExecutionMetadata(),
+		Inputs:           tCtx.InputReader(),
+		OutputPath:       tCtx.OutputWriter(),
+		Task:             tCtx.TaskReader(),
+	})
+
+	if err != nil {
+		return nil, nil, errors.Wrapf(errors2.BadTaskSpecification, err, "Failed to render requests.")
+	}
+
+	response, err := do(ctx, p.client, p.secretManager, integration, Request{
+		Method: integration.createMethod(),
+		URL:    rendered[0],
+		Body:   rendered[1],
+	})
+
+	if err != nil {
+		// The job service rejected the job itself, retrying won't help.
+		if isClientError(err) {
+			return nil, ResourceWrapper{
+				Phase:   core.PhasePermanentFailure,
+				Message: err.Error(),
+			}, nil
+		}
+
+		return nil, nil, err
+	}
+
+	id, err := utils.EvaluateJSONPath("resourceMeta", integration.Response.ResourceMeta, response)
+	if err != nil {
+		return nil, nil, errors.Wrapf(ErrRemoteSystem, err, "Failed to extract the job identifier.")
+	}
+
+	if len(id) == 0 {
+		return nil, nil, errors.Errorf(ErrRemoteSystem, "Job service returned an empty job identifier.")
+	}
+
+	meta := ResourceMetaWrapper{
+		TaskType: taskTemplate.Type,
+		ID:       id,
+		Get: Request{
+			Method: integration.getMethod(),
+			URL:    resourceMetaRegex.ReplaceAllLiteralString(rendered[2], url.PathEscape(id)),
+			Body:   resourceMetaRegex.ReplaceAllLiteralString(rendered[3], id),
+		},
+	}
+
+	if len(integration.Delete.URL) > 0 {
+		meta.Delete = Request{
+			Method: integration.deleteMethod(),
+			URL:    resourceMetaRegex.ReplaceAllLiteralString(rendered[4], url.PathEscape(id)),
+			Body:   resourceMetaRegex.ReplaceAllLiteralString(rendered[5], id),
+		}
+	}
+
+	return meta, nil, nil
+}
+
+func (p Plugin) Get(ctx context.Context, tCtx webapi.GetContext) (latest webapi.Resource, err error) {
+	meta := tCtx.ResourceMeta().(ResourceMetaWrapper)
+	integration, err := p.getIntegration(meta.TaskType)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := do(ctx, p.client, p.secretManager, integration, meta.Get)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := utils.EvaluateJSONPath("phase", integration.Response.Phase, response)
+	if err != nil {
+		return nil, errors.Wrapf(ErrRemoteSystem, err, "Failed to extract the state of job [%v].", meta.ID)
+	}
+
+	resource := ResourceWrapper{
+		Phase: integration.Response.PhaseValues.toPhase(state),
+	}
+
+	if len(integration.Response.Message) > 0 {
+		// Messages are usually only set in some states, a missing message isn't an error.
+		resource.Message, err = utils.EvaluateJSONPath("message", integration.Response.Message, response)
+		if err != nil {
+			logger.Debugf(ctx, "Failed to extract the message of job [%v]. Error: %v", meta.ID, err)
+		}
+	}
+
+	if resource.Phase == core.PhaseSuccess {
+		resource.Outputs = make(map[string]string, len(integration.Response.Outputs))
+		for name, expr := range integration.Response.Outputs {
+			resource.Outputs[name], err = utils.EvaluateJSONPath(name, expr, response)
+			if err != nil {
+				return nil, errors.Wrapf(ErrRemoteSystem, err, "Failed to extract output [%v] of job [%v].", name,
+					meta.ID)
+			}
+		}
+	}
+
+	return resource, nil
+}
+
+func (p Plugin) Delete(ctx context.Context, tCtx webapi.DeleteContext) error {
+	if tCtx.ResourceMeta() == nil {
+		return nil
+	}
+
+	meta := tCtx.ResourceMeta().(ResourceMetaWrapper)
+	if len(meta.Delete.URL) == 0 {
+		logger.Infof(ctx, "Job [%v] of type [%v] can't be cancelled, no delete request is configured.", meta.ID,
+			meta.TaskType)
+		return nil
+	}
+
+	integration, err := p.getIntegration(meta.TaskType)
+	if err != nil {
+		return err
+	}
+
+	_, err = do(ctx, p.client, p.secretManager, integration, meta.Delete)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "Deleted job [%v] of type [%v].", meta.ID, meta.TaskType)
+	return nil
+}
+
+func (p Plugin) Status(ctx context.Context, tCtx webapi.StatusContext) (phase core.PhaseInfo, err error) {
+	resource := tCtx.Resource().(ResourceWrapper)
+	taskInfo := createTaskInfo(tCtx.ResourceMeta())
+
+	switch resource.Phase {
+	case core.PhaseQueued:
+		return core.PhaseInfoQueued(time.Now(), core.DefaultPhaseVersion, resource.Message), nil
+	case core.PhaseRunning:
+		return core.PhaseInfoRunning(core.DefaultPhaseVersion, taskInfo), nil
+	case core.PhaseRetryableFailure:
+		return core.PhaseInfoRetryableFailure(string(ErrRemoteSystem), resource.Message, taskInfo), nil
+	case core.PhasePermanentFailure:
+		return core.PhaseInfoFailure(string(ErrRemoteUser), resource.Message, taskInfo), nil
+	case core.PhaseSuccess:
+		err = writeOutputs(ctx, tCtx, resource.Outputs)
+		if err != nil {
+			logger.Warnf(ctx, "Failed to write outputs, err %s", err.Error())
+			return core.PhaseInfoUndefined, err
+		}
+
+		return core.PhaseInfoSuccess(taskInfo), nil
+	}
+
+	return core.PhaseInfoUndefined, errors.Errorf(ErrSystem, "Unknown execution phase [%v].", resource.Phase)
+}
+
+// writeOutputs converts the raw output values to literals of the types declared in the task interface.
+func writeOutputs(ctx context.Context, tCtx webapi.StatusContext, outputs map[string]string) error {
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	taskTemplate, err := tCtx.TaskReader().Read(ctx)
+	if err != nil {
+		return err
+	}
+
+	variables := taskTemplate.GetInterface().GetOutputs().GetVariables()
+	literals := make(map[string]*idlCore.Literal, len(outputs))
+	for name, value := range outputs {
+		variable, found := variables[name]
+		if !found {
+			return errors.Errorf(errors2.BadTaskSpecification, "Output [%v] isn't declared in the task interface.",
+				name)
+		}
+
+		literals[name], err = makeLiteral(variable.GetType(), value)
+		if err != nil {
+			return errors.Wrapf(errors2.BadTaskSpecification, err, "Failed to convert output [%v].", name)
+		}
+	}
+
+	return tCtx.OutputWriter().Put(ctx, ioutils.NewInMemoryOutputReader(&idlCore.LiteralMap{Literals: literals}, nil))
+}
+
+// isSupportedOutputType reports whether output values read from responses can be converted to the type.
+func isSupportedOutputType(t *idlCore.LiteralType) bool {
+	switch t.GetType().(type) {
+	case *idlCore.LiteralType_Simple, *idlCore.LiteralType_Blob:
+		return true
+	}
+
+	return false
+}
+
+func makeLiteral(t *idlCore.LiteralType, value string) (*idlCore.Literal, error) {
+	switch t.GetType().(type) {
+	case *idlCore.LiteralType_Simple:
+		return utils.MakeLiteralForSimpleType(t.GetSimple(), value)
+	case *idlCore.LiteralType_Blob:
+		return utils.MakeLiteralForBlob(storage.DataReference(value),
+			t.GetBlob().GetDimensionality() == idlCore.BlobType_MULTIPART, t.GetBlob().GetFormat()), nil
+	}
+
+	return nil, fmt.Errorf("unsupported output type [%v]", t)
+}
+
+func (v PhaseValues) toPhase(state string) core.Phase {
+	switch {
+	case contains(v.Queued, state):
+		return core.PhaseQueued
+	case contains(v.Succeeded, state):
+		return core.PhaseSuccess
+	case contains(v.Failed, state):
+		return core.PhasePermanentFailure
+	case contains(v.RetryableFailed, state):
+		return core.PhaseRetryableFailure
+	}
+
+	return core.PhaseRunning
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func createTaskInfo(resourceMeta webapi.ResourceMeta) *core.TaskInfo {
+	timeNow := time.Now()
+	taskInfo := &core.TaskInfo{
+		OccurredAt: &timeNow,
+	}
+
+	// Jobs rejected by the job service have no identifier.
+	if meta, ok := resourceMeta.(ResourceMetaWrapper); ok {
+		taskInfo.Metadata = &event.TaskExecutionMetadata{
+			ExternalResources: []*event.ExternalResourceInfo{
+				{
+					ExternalId: meta.ID,
+				},
+			},
+		}
+	}
+
+	return taskInfo
+}
+
+func validateConfig(cfg *Config) error {
+	for taskType, integration := range cfg.Integrations {
+		if len(integration.Create.URL) == 0 || len(integration.Get.URL) == 0 {
+			return fmt.Errorf("create and get URLs are required for task type [%v]", taskType)
+		}
+
+		if len(integration.Response.ResourceMeta) == 0 || len(integration.Response.Phase) == 0 {
+			return fmt.Errorf("resourceMeta and phase paths are required for task type [%v]", taskType)
+		}
+
+		expressions := map[string]string{
+			"resourceMeta": integration.Response.ResourceMeta,
+			"phase":        integration.Response.Phase,
+			"message":      integration.Response.Message,
+		}
+
+		for name, expr := range integration.Response.Outputs {
+			expressions[name] = expr
+		}
+
+		for name, expr := range expressions {
+			if _, err := utils.ParseJSONPath(name, expr); err != nil {
+				return fmt.Errorf("task type [%v]: %w", taskType, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func NewPlugin(_ context.Context, cfg *Config, secretManager core.SecretManager, metricScope promutils.Scope) (
+	Plugin, error) {
+
+	if err := validateConfig(cfg); err != nil {
+		return Plugin{}, err
+	}
+
+	return Plugin{
+		metricScope:   metricScope,
+		cfg:           cfg,
+		client:        &http.Client{},
+		secretManager: secretManager,
+	}, nil
+}
+
+// loadPluginEntries returns the plugin entry handling the task types of the configured integrations, if any.
+func loadPluginEntries(_ context.Context) ([]webapi.PluginEntry, error) {
+	cfg := GetConfig()
+	if len(cfg.Integrations) == 0 {
+		return nil, nil
+	}
+
+	taskTypes := make([]core.TaskType, 0, len(cfg.Integrations))
+	for taskType := range cfg.Integrations {
+		taskTypes = append(taskTypes, taskType)
+	}
+
+	sort.Strings(taskTypes)
+	return []webapi.PluginEntry{
+		{
+			ID:                 "rest",
+			SupportedTaskTypes: taskTypes,
+			PluginLoader: func(ctx context.Context, iCtx webapi.PluginSetupContext) (webapi.AsyncPlugin, error) {
+				return NewPlugin(ctx, GetConfig(), iCtx.SecretManager(), iCtx.MetricsScope())
+			},
+		},
+	}, nil
+}
+
+func init() {
+	// The task types are only known once the config is loaded.
+	pluginmachinery.PluginRegistry().RegisterRemotePluginLoader(loadPluginEntries)
+}
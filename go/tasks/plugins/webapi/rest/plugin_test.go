@@ -0,0 +1,373 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	idlCore "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errors2 "github.com/flyteorg/flyteplugins/go/tasks/errors"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	coreMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io"
+	ioMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/io/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/utils"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/mocks"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/webapi/webapitest"
+)
+
+// fakeJobService is a minimal job service that follows the POST/GET/DELETE pattern.
+type fakeJobService struct {
+	m      sync.Mutex
+	jobs   map[string]string
+	bodies []string
+}
+
+func (s *fakeJobService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer my-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/jobs":
+		body, _ := ioutil.ReadAll(r.Body)
+		s.bodies = append(s.bodies, string(body))
+		request := map[string]interface{}{}
+		if err := json.Unmarshal(body, &request); err != nil || request["query"] == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid query"}`))
+			return
+		}
+
+		s.jobs["job-1"] = "PENDING"
+		_, _ = w.Write([]byte(`{"job": {"id": "job-1"}}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/jobs/job-1":
+		_, _ = w.Write([]byte(`{"status": {"state": "` + s.jobs["job-1"] +
+			`", "reason": "some reason"}, "result": {"rows": 42, "location": "s3://bucket/result"}}`))
+	case r.Method == http.MethodDelete && r.URL.Path == "/jobs/job-1":
+		s.jobs["job-1"] = "CANCELLED"
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *fakeJobService) setState(state string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.jobs["job-1"] = state
+}
+
+func newIntegrationConfig(baseURL string) IntegrationConfig {
+	return IntegrationConfig{
+		Headers: map[string]string{
+			"Authorization": "Bearer {{ .Secrets.job-service-token }}",
+		},
+		Create: RequestConfig{
+			URL:  baseURL + "/jobs",
+			Body: `{"query": "{{ .Inputs.query }}", "name": "{{ .PerRetryUniqueKey }}"}`,
+		},
+		Get: RequestConfig{
+			URL: baseURL + "/jobs/{{ .ResourceMeta }}",
+		},
+		Delete: RequestConfig{
+			URL: baseURL + "/jobs/{{ .ResourceMeta }}",
+		},
+		Response: ResponseConfig{
+			ResourceMeta: "{.job.id}",
+			Phase:        "{.status.state}",
+			Message:      "{.status.reason}",
+			Outputs: map[string]string{
+				"rows":     "{.result.rows}",
+				"location": "{.result.location}",
+			},
+			PhaseValues: PhaseValues{
+				Queued:          []string{"PENDING"},
+				Succeeded:       []string{"DONE"},
+				Failed:          []string{"ERROR"},
+				RetryableFailed: []string{"CANCELLED"},
+			},
+		},
+	}
+}
+
+func newTestPlugin(t *testing.T, baseURL string) Plugin {
+	sm := &coreMocks.SecretManager{}
+	sm.OnGetMatch(mock.Anything, "job-service-token").Return("my-token", nil)
+
+	cfg := defaultConfig
+	cfg.Integrations = map[string]IntegrationConfig{"rest": newIntegrationConfig(baseURL)}
+	p, err := NewPlugin(context.Background(), &cfg, sm, promutils.NewTestScope())
+	assert.NoError(t, err)
+	return p
+}
+
+func newTaskTemplate() *idlCore.TaskTemplate {
+	return &idlCore.TaskTemplate{
+		Type: "rest",
+		Interface: &idlCore.TypedInterface{
+			Inputs: &idlCore.VariableMap{
+				Variables: map[string]*idlCore.Variable{
+					"query": {Type: &idlCore.LiteralType{Type: &idlCore.LiteralType_Simple{
+						Simple: idlCore.SimpleType_STRING,
+					}}},
+				},
+			},
+			Outputs: &idlCore.VariableMap{
+				Variables: map[string]*idlCore.Variable{
+					"rows": {Type: &idlCore.LiteralType{Type: &idlCore.LiteralType_Simple{
+						Simple: idlCore.SimpleType_INTEGER,
+					}}},
+					"location": {Type: &idlCore.LiteralType{Type: &idlCore.LiteralType_Blob{
+						Blob: &idlCore.BlobType{Dimensionality: idlCore.BlobType_MULTIPART},
+					}}},
+				},
+			},
+		},
+	}
+}
+
+func newTaskExecutionContext(query string) *mocks.TaskExecutionContextReader {
+	return webapitest.NewTaskExecutionContext(newTaskTemplate(), &idlCore.LiteralMap{Literals: map[string]*idlCore.Literal{
+		"query": utils.MustMakeLiteral(query),
+	}})
+}
+
+func TestPlugin(t *testing.T) {
+	ctx := context.Background()
+	jobService := &fakeJobService{jobs: map[string]string{}}
+	server := httptest.NewServer(jobService)
+	defer server.Close()
+
+	p := newTestPlugin(t, server.URL)
+
+	t.Run("Succeeded", func(t *testing.T) {
+		resourceMeta, resource, err := p.Create(ctx, newTaskExecutionContext("SELECT 1"))
+		assert.NoError(t, err)
+		assert.Nil(t, resource)
+		assert.Equal(t, "job-1", resourceMeta.(ResourceMetaWrapper).ID)
+		assert.Equal(t, server.URL+"/jobs/job-1", resourceMeta.(ResourceMetaWrapper).Get.URL)
+		assert.Equal(t, `{"query": "SELECT 1", "name": "my_id"}`, jobService.bodies[len(jobService.bodies)-1])
+
+		latest, err := p.Get(ctx, webapitest.NewGetContext(resourceMeta, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseQueued, latest.(ResourceWrapper).Phase)
+		assert.Equal(t, "some reason", latest.(ResourceWrapper).Message)
+
+		phase, err := p.Status(ctx, webapitest.NewStatusContext(newTaskTemplate(), resourceMeta, latest, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseQueued, phase.Phase())
+
+		jobService.setState("RUNNING")
+		latest, err = p.Get(ctx, webapitest.NewGetContext(resourceMeta, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRunning, latest.(ResourceWrapper).Phase)
+
+		jobService.setState("DONE")
+		latest, err = p.Get(ctx, webapitest.NewGetContext(resourceMeta, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"rows": "42", "location": "s3://bucket/result"},
+			latest.(ResourceWrapper).Outputs)
+
+		ow := &ioMocks.OutputWriter{}
+		ow.OnPutMatch(ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			outputs, _, err := args.Get(1).(io.OutputReader).Read(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(42), outputs.Literals["rows"].GetScalar().GetPrimitive().GetInteger())
+			assert.Equal(t, "s3://bucket/result", outputs.Literals["location"].GetScalar().GetBlob().GetUri())
+		})
+
+		phase, err = p.Status(ctx, webapitest.NewStatusContext(newTaskTemplate(), resourceMeta, latest, ow))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseSuccess, phase.Phase())
+		assert.Equal(t, "job-1", phase.Info().Metadata.ExternalResources[0].ExternalId)
+		ow.AssertNumberOfCalls(t, "Put", 1)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		resourceMeta, resource, err := p.Create(ctx, newTaskExecutionContext(""))
+		assert.NoError(t, err)
+		assert.Nil(t, resourceMeta)
+
+		phase, err := p.Status(ctx, webapitest.NewStatusContext(newTaskTemplate(), resourceMeta, resource, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhasePermanentFailure, phase.Phase())
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		resourceMeta, _, err := p.Create(ctx, newTaskExecutionContext("SELECT 1"))
+		assert.NoError(t, err)
+
+		dCtx := &mocks.DeleteContext{}
+		dCtx.OnResourceMeta().Return(resourceMeta)
+		dCtx.OnReason().Return("Aborted")
+		assert.NoError(t, p.Delete(ctx, dCtx))
+
+		latest, err := p.Get(ctx, webapitest.NewGetContext(resourceMeta, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, core.PhaseRetryableFailure, latest.(ResourceWrapper).Phase)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := p.Get(ctx, webapitest.NewGetContext(ResourceMetaWrapper{
+			TaskType: "rest",
+			ID:       "unknown",
+			Get:      Request{Method: http.MethodGet, URL: server.URL + "/jobs/unknown"},
+		}, nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown task type", func(t *testing.T) {
+		_, err := p.Get(ctx, webapitest.NewGetContext(ResourceMetaWrapper{TaskType: "unknown"}, nil))
+		assert.Error(t, err)
+	})
+}
+
+func TestRenderHeaders(t *testing.T) {
+	ctx := context.Background()
+	sm := &coreMocks.SecretManager{}
+	sm.OnGetMatch(mock.Anything, "token").Return("abc", nil)
+	sm.OnGetMatch(mock.Anything, "missing").Return("", assert.AnError)
+
+	headers, err := renderHeaders(ctx, sm, map[string]string{
+		"Authorization": "Token {{ .Secrets.token }}",
+		"X-Static":      "static",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Token abc", headers.Get("Authorization"))
+	assert.Equal(t, "static", headers.Get("X-Static"))
+	assert.Equal(t, contentTypeJSON, headers.Get(headerContentType))
+
+	_, err = renderHeaders(ctx, sm, map[string]string{"Authorization": "{{ .Secrets.missing }}"})
+	assert.Error(t, err)
+
+	t.Run("Structured reference", func(t *testing.T) {
+		sm := &coreMocks.StructuredSecretManager{}
+		sm.OnGetSecretMatch(mock.Anything, core.SecretReference{Group: "service", Key: "token", Version: "2"}).
+			Return("def", nil)
+
+		headers, err := renderHeaders(ctx, sm, map[string]string{"Authorization": "Token {{ .Secrets.service/token@2 }}"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Token def", headers.Get("Authorization"))
+
+		_, err = renderHeaders(ctx, &coreMocks.SecretManager{}, map[string]string{
+			"Authorization": "Token {{ .Secrets.service/token@2 }}",
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestPhaseValues_toPhase(t *testing.T) {
+	v := PhaseValues{
+		Queued:          []string{"PENDING"},
+		Succeeded:       []string{"DONE", "SKIPPED"},
+		Failed:          []string{"ERROR"},
+		RetryableFailed: []string{"CANCELLED"},
+	}
+
+	assert.Equal(t, core.PhaseQueued, v.toPhase("PENDING"))
+	assert.Equal(t, core.PhaseSuccess, v.toPhase("SKIPPED"))
+	assert.Equal(t, core.PhasePermanentFailure, v.toPhase("ERROR"))
+	assert.Equal(t, core.PhaseRetryableFailure, v.toPhase("CANCELLED"))
+	assert.Equal(t, core.PhaseRunning, v.toPhase("SOMETHING_ELSE"))
+}
+
+func TestPlugin_ValidateTask(t *testing.T) {
+	ctx := context.Background()
+	p := newTestPlugin(t, "http://localhost")
+	assert.NoError(t, p.ValidateTask(ctx, newTaskTemplate()))
+
+	unknownType := newTaskTemplate()
+	unknownType.Type = "other"
+	missingInput := newTaskTemplate()
+	missingInput.Interface.Inputs = nil
+	missingOutput := newTaskTemplate()
+	delete(missingOutput.Interface.Outputs.Variables, "rows")
+	unsupportedOutput := newTaskTemplate()
+	unsupportedOutput.Interface.Outputs.Variables["rows"] = &idlCore.Variable{Type: &idlCore.LiteralType{
+		Type: &idlCore.LiteralType_CollectionType{CollectionType: &idlCore.LiteralType{}},
+	}}
+
+	for name, taskTemplate := range map[string]*idlCore.TaskTemplate{
+		"Unknown task type":  unknownType,
+		"Missing input":      missingInput,
+		"Missing output":     missingOutput,
+		"Unsupported output": unsupportedOutput,
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := p.ValidateTask(ctx, taskTemplate)
+			assert.Error(t, err)
+			code, found := errors.GetErrorCode(err)
+			assert.True(t, found)
+			assert.Equal(t, errors2.BadTaskSpecification, code)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := defaultConfig
+	cfg.Integrations = map[string]IntegrationConfig{"rest": newIntegrationConfig("http://localhost")}
+	assert.NoError(t, validateConfig(&cfg))
+
+	missingURL := newIntegrationConfig("http://localhost")
+	missingURL.Get.URL = ""
+	cfg.Integrations = map[string]IntegrationConfig{"rest": missingURL}
+	assert.Error(t, validateConfig(&cfg))
+
+	invalidPath := newIntegrationConfig("http://localhost")
+	invalidPath.Response.Phase = "{.status.state"
+	cfg.Integrations = map[string]IntegrationConfig{"rest": invalidPath}
+	assert.Error(t, validateConfig(&cfg))
+}
+
+func TestLoadPluginEntries(t *testing.T) {
+	ctx := context.Background()
+	previous := *GetConfig()
+	defer func() {
+		assert.NoError(t, configSection.SetConfig(&previous))
+	}()
+
+	cfg := defaultConfig
+	assert.NoError(t, configSection.SetConfig(&cfg))
+	entries, err := loadPluginEntries(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	cfg.Integrations = map[string]IntegrationConfig{
+		"my-service":    newIntegrationConfig("http://localhost"),
+		"other-service": newIntegrationConfig("http://localhost"),
+	}
+	assert.NoError(t, configSection.SetConfig(&cfg))
+	entries, err = loadPluginEntries(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "rest", entries[0].ID)
+	assert.Equal(t, []core.TaskType{"my-service", "other-service"}, entries[0].SupportedTaskTypes)
+}
+
+func TestMakeLiteral(t *testing.T) {
+	l, err := makeLiteral(&idlCore.LiteralType{Type: &idlCore.LiteralType_Simple{Simple: idlCore.SimpleType_FLOAT}},
+		"1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, l.GetScalar().GetPrimitive().GetFloatValue())
+
+	l, err = makeLiteral(&idlCore.LiteralType{Type: &idlCore.LiteralType_Blob{Blob: &idlCore.BlobType{}}},
+		"s3://bucket/file")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://bucket/file", l.GetScalar().GetBlob().GetUri())
+
+	_, err = makeLiteral(&idlCore.LiteralType{Type: &idlCore.LiteralType_Schema{}}, "s3://bucket/schema")
+	assert.Error(t, err)
+}